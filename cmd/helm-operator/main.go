@@ -5,6 +5,7 @@ import (
 	golog "log"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"strings"
 	"sync"
 	"syscall"
@@ -12,6 +13,8 @@ import (
 
 	"github.com/go-kit/kit/log"
 	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/clientcmd"
@@ -29,7 +32,9 @@ import (
 	v3 "github.com/fluxcd/helm-operator/pkg/helm/v3"
 	daemonhttp "github.com/fluxcd/helm-operator/pkg/http/daemon"
 	"github.com/fluxcd/helm-operator/pkg/operator"
+	"github.com/fluxcd/helm-operator/pkg/queue"
 	"github.com/fluxcd/helm-operator/pkg/release"
+	"github.com/fluxcd/helm-operator/pkg/repository"
 	"github.com/fluxcd/helm-operator/pkg/status"
 	"github.com/fluxcd/helm-operator/pkg/utils"
 )
@@ -63,20 +68,39 @@ var (
 	convertReleaseStorage   *string
 
 	chartsSyncInterval   *time.Duration
+	releaseBackoffBase   *time.Duration
+	releaseBackoffMax    *time.Duration
 	statusUpdateInterval *time.Duration
 	logReleaseDiffs      *bool
 	updateDependencies   *bool
+	chartsCacheMaxSize   *string
+
+	shutdownGracePeriod       *time.Duration
+	recoverPendingAfter       *time.Duration
+	maxConcurrentPerNamespace *int
+	maxConcurrentPerChart     *[]string
 
 	gitTimeout      *time.Duration
 	gitPollInterval *time.Duration
 	gitDefaultRef   *string
+	gitShallow      *bool
+	gitSparseExport *bool
 
-	listenAddr *string
+	listenAddr        *string
+	listenMetricsAddr *string
+	listenTLSCert     *string
+	listenTLSKey      *string
+	apiAuthentication *bool
+	apiAuthorization  *bool
+	webhookSecret     *string
 
 	versionedHelmRepositoryIndexes *[]string
 
 	enabledHelmVersions *[]string
 	defaultHelmVersion  *string
+
+	helmStorageDriver *string
+	helmPluginsDir    *string
 )
 
 const (
@@ -105,8 +129,18 @@ func init() {
 	namespace = fs.String("allow-namespace", "", "if set, this limits the scope to a single namespace; if not specified, all namespaces will be watched")
 
 	workers = fs.Int("workers", 2, "amount of workers processing releases")
+	shutdownGracePeriod = fs.Duration("shutdown-grace-period", 20*time.Second, "duration to wait on shutdown for in-flight releases to finish, after which they are cancelled")
+	recoverPendingAfter = fs.Duration("recover-pending-after", 0, "age after which releases stuck in a pending-install, pending-upgrade or pending-rollback state are rolled back to their last deployed revision, or marked as failed; disabled if zero")
+	maxConcurrentPerNamespace = fs.Int("max-concurrent-per-namespace", 0, "maximum amount of concurrent installs and upgrades of releases in a target namespace; unlimited if zero")
+	maxConcurrentPerChart = fs.StringSlice("max-concurrent-per-chart", nil, "maximum amount of concurrent installs and upgrades of releases of a chart, e.g. nginx-ingress=1,podinfo=2; charts that are not listed are unlimited")
 
 	listenAddr = fs.StringP("listen", "l", ":3030", "Listen address where /metrics and API will be served")
+	listenMetricsAddr = fs.String("listen-metrics", "", "Listen address where /metrics will be served; if not specified, /metrics is served on the --listen address")
+	listenTLSCert = fs.String("listen-tls-cert-path", "", "path to certificate file used to serve HTTPS; the certificate is reloaded when the file changes")
+	listenTLSKey = fs.String("listen-tls-key-path", "", "path to private key file used to serve HTTPS; required if listen-tls-cert-path is provided")
+	apiAuthentication = fs.Bool("api-authentication", false, "require API requests to present a bearer token, which is validated using the Kubernetes TokenReview API")
+	apiAuthorization = fs.Bool("api-authorization", false, "authorize API requests using the Kubernetes SubjectAccessReview API; implies api-authentication")
+	webhookSecret = fs.String("webhook-secret", "", "namespace/name of the Secret with the 'token' webhook payloads are verified against; webhooks are disabled if not specified")
 
 	tillerIP = fs.String("tiller-ip", "", "Tiller IP address; required if run out-of-cluster")
 	tillerPort = fs.String("tiller-port", "", "Tiller port; required if run out-of-cluster")
@@ -123,17 +157,25 @@ func init() {
 	convertReleaseStorage = fs.String("convert-release-storage", "secrets", "v2 release storage type/object. It can be 'secrets' or 'configmaps'. This is only used with the 'tiller-out-cluster' flag (default 'secrets')")
 
 	chartsSyncInterval = fs.Duration("charts-sync-interval", 3*time.Minute, "period on which to reconcile the Helm releases with HelmRelease resources")
+	releaseBackoffBase = fs.Duration("release-backoff-base", 10*time.Second, "delay before the first retry of a failed release, which doubles on every consecutive failure")
+	releaseBackoffMax = fs.Duration("release-backoff-max", 15*time.Minute, "maximum delay between retries of a failed release")
 	statusUpdateInterval = fs.Duration("status-update-interval", 10*time.Second, "period on which to update the Helm release status in HelmRelease resources")
 	logReleaseDiffs = fs.Bool("log-release-diffs", false, "log the diff when a chart release diverges; potentially insecure")
 	updateDependencies = fs.Bool("update-chart-deps", true, "update chart dependencies before installing/upgrading a release")
+	chartsCacheMaxSize = fs.String("charts-cache-max-size", "", "maximum total size of the downloaded charts, e.g. '512Mi'; the least recently used charts are evicted once exceeded. Unlimited if not specified")
 
 	gitTimeout = fs.Duration("git-timeout", 20*time.Second, "duration after which git operations time out")
 	gitPollInterval = fs.Duration("git-poll-interval", 5*time.Minute, "period on which to poll git chart sources for changes")
 	gitDefaultRef = fs.String("git-default-ref", "master", "ref to clone chart from if ref is unspecified in a HelmRelease")
+	gitShallow = fs.Bool("git-shallow", false, "only fetch the latest commit of every ref of git chart sources, instead of the full history; chart sources pinned to a commit, tag or semver range are rejected")
+	gitSparseExport = fs.Bool("git-sparse-export", false, "only check out the chart path and its local (file://) dependencies when exporting git chart sources")
 
 	versionedHelmRepositoryIndexes = fs.StringSlice("helm-repository-import", nil, "Targeted version and the path of the Helm repository index to import, i.e. v3:/tmp/v3/index.yaml,v2:/tmp/v2/index.yaml")
 
 	enabledHelmVersions = fs.StringSlice("enabled-helm-versions", []string{helmv2.VERSION, helmv3.VERSION}, "Helm versions supported by this operator instance")
+
+	helmStorageDriver = fs.String("helm-storage-driver", getEnv("HELM_DRIVER", "secret"), "default storage driver for Helm 3 releases, one of 'secret', 'configmap' or 'sql'. The connection string for 'sql' is read from the HELM_DRIVER_SQL_CONNECTION_STRING environment variable")
+	helmPluginsDir = fs.String("helm-plugins-dir", getEnv("HELM_PLUGINS", ""), "directory Helm 3 plugins are loaded from, e.g. downloader plugins for additional repository protocols. Defaults to the Helm data directory")
 }
 
 func main() {
@@ -188,6 +230,47 @@ func main() {
 
 	mainLogger := log.With(logger, "component", "helm-operator")
 
+	var chartCacheMaxSize int64
+	if *chartsCacheMaxSize != "" {
+		q, err := resource.ParseQuantity(*chartsCacheMaxSize)
+		if err != nil {
+			mainLogger.Log("error", fmt.Sprintf("invalid --charts-cache-max-size: %s", err))
+			os.Exit(1)
+		}
+		chartCacheMaxSize = q.Value()
+	}
+
+	chartLimits, err := release.ParseChartLimits(*maxConcurrentPerChart)
+	if err != nil {
+		mainLogger.Log("error", fmt.Sprintf("invalid --max-concurrent-per-chart: %s", err))
+		os.Exit(1)
+	}
+
+	if *releaseBackoffBase <= 0 || *releaseBackoffMax < *releaseBackoffBase {
+		mainLogger.Log("error", "--release-backoff-base must be positive, and not exceed --release-backoff-max")
+		os.Exit(1)
+	}
+
+	if (*listenTLSCert == "") != (*listenTLSKey == "") {
+		mainLogger.Log("error", "both --listen-tls-cert-path and --listen-tls-key-path must be provided to serve HTTPS")
+		os.Exit(1)
+	}
+
+	if err := helmv3.ValidateStorageDriver(*helmStorageDriver); err != nil {
+		mainLogger.Log("error", fmt.Sprintf("invalid --helm-storage-driver: %s", err))
+		os.Exit(1)
+	}
+
+	var webhookSecretRef types.NamespacedName
+	if *webhookSecret != "" {
+		ns, name, err := cache.SplitMetaNamespaceKey(*webhookSecret)
+		if err != nil || ns == "" || name == "" {
+			mainLogger.Log("error", fmt.Sprintf("invalid --webhook-secret %q, expected namespace/name", *webhookSecret))
+			os.Exit(1)
+		}
+		webhookSecretRef = types.NamespacedName{Namespace: ns, Name: name}
+	}
+
 	// build Kubernetes clients
 	cfg, err := clientcmd.BuildConfigFromFlags(*master, *kubeconfig)
 	if err != nil {
@@ -226,7 +309,11 @@ func main() {
 				TLSHostname: *tillerTLSHostname,
 			}))
 		case helmv3.VERSION:
-			client := helmv3.New(versionedLogger, cfg)
+			client := helmv3.New(versionedLogger, cfg, helmv3.HelmOptions{
+				Driver:              *helmStorageDriver,
+				SQLConnectionString: os.Getenv("HELM_DRIVER_SQL_CONNECTION_STRING"),
+				PluginsDir:          *helmPluginsDir,
+			})
 			helmClients.Add(helmv3.VERSION, client)
 		default:
 			mainLogger.Log("error", fmt.Sprintf("unsupported Helm version: %s", v))
@@ -261,16 +348,24 @@ func main() {
 	nsOpt := ifinformers.WithNamespace(*namespace)
 	ifInformerFactory := ifinformers.NewSharedInformerFactoryWithOptions(ifClient, *chartsSyncInterval, nsOpt)
 	hrInformer := ifInformerFactory.Helm().V1().HelmReleases()
+	repoInformer := ifInformerFactory.Helm().V1().HelmRepositories()
 
 	// setup workqueue for HelmReleases
-	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "ChartRelease")
+	releaseQueue := queue.New(workqueue.DefaultControllerRateLimiter(), operator.ReleasePriority(hrInformer.Lister()))
 
 	gitChartSync := chartsync.NewGitChartSync(
 		log.With(logger, "component", "gitchartsync"),
 		kubeClient.CoreV1(),
+		ifClient.HelmV1(),
 		hrInformer.Lister(),
-		chartsync.GitConfig{GitTimeout: *gitTimeout, GitPollInterval: *gitPollInterval, GitDefaultRef: *gitDefaultRef},
-		queue,
+		chartsync.GitConfig{
+			GitTimeout:      *gitTimeout,
+			GitPollInterval: *gitPollInterval,
+			GitDefaultRef:   *gitDefaultRef,
+			GitShallow:      *gitShallow,
+			GitSparseExport: *gitSparseExport,
+		},
+		releaseQueue.Lane(queue.LaneSource),
 	)
 	converter := v3.Converter{
 		TillerNamespace:  *tillerNamespace,
@@ -278,14 +373,27 @@ func main() {
 		TillerOutCluster: *convertTillerOutCluster,
 		StorageType:      *convertReleaseStorage,
 	}
+	recorder := operator.NewEventRecorder(kubeClient)
 	rel := release.New(
 		log.With(logger, "component", "release"),
 		helmClients,
 		kubeClient.CoreV1(),
 		ifClient.HelmV1(),
+		repoInformer.Lister(),
 		gitChartSync,
-		release.Config{LogDiffs: *logReleaseDiffs, UpdateDeps: *updateDependencies, DefaultHelmVersion: *defaultHelmVersion},
+		release.Config{
+			ChartCacheMaxSize:  chartCacheMaxSize,
+			LogDiffs:           *logReleaseDiffs,
+			UpdateDeps:         *updateDependencies,
+			DefaultHelmVersion: *defaultHelmVersion,
+			Limits: release.Limits{
+				PerNamespace: *maxConcurrentPerNamespace,
+				PerChart:     chartLimits,
+			},
+			RecoverPendingAfter: *recoverPendingAfter,
+		},
 		converter,
+		recorder,
 	)
 
 	// prepare operator and start FluxRelease informer
@@ -293,19 +401,28 @@ func main() {
 	// _before_ starting it or else the cache sync seems to hang at
 	// random
 	opr := operator.New(log.With(logger, "component", "operator"),
-		*logReleaseDiffs, kubeClient, hrInformer, queue, rel, gitChartSync)
+		*logReleaseDiffs, *chartsSyncInterval, queue.NewBackoff(*releaseBackoffBase, *releaseBackoffMax),
+		recorder, ifClient.HelmV1(), hrInformer, releaseQueue, rel, gitChartSync)
+	repoController := repository.New(log.With(logger, "component", "repository"),
+		helmClients, kubeClient.CoreV1(), ifClient.HelmV1(), repoInformer,
+		filepath.Join(os.TempDir(), "helm-repository-certs"))
 	go ifInformerFactory.Start(shutdown)
 
 	// wait for the caches to be synced before starting _any_ workers
 	mainLogger.Log("info", "waiting for informer caches to sync")
-	if ok := cache.WaitForCacheSync(shutdown, hrInformer.Informer().HasSynced); !ok {
+	if ok := cache.WaitForCacheSync(shutdown, hrInformer.Informer().HasSynced, repoInformer.Informer().HasSynced); !ok {
 		mainLogger.Log("error", "failed to wait for caches to sync")
 		os.Exit(1)
 	}
 	mainLogger.Log("info", "informer caches synced")
 
+	// start repository controller, so that the repositories are
+	// added before the releases depending on them are synced
+	go repoController.Run(shutdown, shutdownWg)
+
 	// start operator
-	go opr.Run(*workers, shutdown, shutdownWg)
+	shutdownWg.Add(1)
+	go opr.Run(*workers, *shutdownGracePeriod, shutdown, shutdownWg)
 
 	// start git chart sync loop
 	go gitChartSync.Run(shutdown, errc, shutdownWg)
@@ -316,7 +433,15 @@ func main() {
 	go statusUpdater.Loop(shutdown, *statusUpdateInterval, log.With(logger, "component", "statusupdater"))
 
 	// start HTTP server
-	go daemonhttp.ListenAndServe(*listenAddr, gitChartSync, log.With(logger, "component", "daemonhttp"), shutdown)
+	go daemonhttp.ListenAndServe(daemonhttp.Config{
+		ListenAddr:        *listenAddr,
+		MetricsListenAddr: *listenMetricsAddr,
+		TLSCertFile:       *listenTLSCert,
+		TLSKeyFile:        *listenTLSKey,
+		Authentication:    *apiAuthentication,
+		Authorization:     *apiAuthorization,
+		WebhookSecret:     webhookSecretRef,
+	}, opr, kubeClient, log.With(logger, "component", "daemonhttp"), shutdown)
 
 	checkpoint.CheckForUpdates(product, version, nil, log.With(logger, "component", "checkpoint"))
 
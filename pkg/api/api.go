@@ -1,7 +1,38 @@
 package api
 
+import "errors"
+
+// ErrReleaseNotFound is returned when the HelmRelease referred to in
+// a request does not exist.
+var ErrReleaseNotFound = errors.New("HelmRelease not found")
+
+// ErrRemoteNotFound is returned when no git mirror is found for the
+// repository referred to in a request.
+var ErrRemoteNotFound = errors.New("no git mirror found for repository")
+
 // Server is the interface that must be satisfied in order to serve
 // HTTP API requests.
 type Server interface {
-	SyncMirrors()
+	SyncMirrors() error
+	SyncMirrorsForRemote(urls ...string) error
+	SyncRelease(namespace, name string, opts SyncReleaseOptions) error
+}
+
+// SyncReleaseOptions holds the options available for the sync of a
+// single HelmRelease.
+type SyncReleaseOptions struct {
+	// ForceUpgrade instructs the operator to perform an upgrade, even
+	// if the dry-run does not detect any changes.
+	ForceUpgrade bool
+	// NewObserver, if set, is called once the HelmRelease has been
+	// found and its sync is scheduled, to create the observer that is
+	// notified about the progress of the sync.
+	NewObserver func() SyncObserver
+}
+
+// SyncObserver is notified when the sync of a HelmRelease starts
+// and finishes.
+type SyncObserver interface {
+	SyncStarted()
+	SyncFinished(err error)
 }
@@ -50,3 +50,17 @@ type OptionalSecretKeySelector struct {
 	// +optional
 	Optional bool `json:"optional,omitempty"`
 }
+
+// KeyringSource refers to a Secret or ConfigMap, in the namespace of
+// the HelmRelease, holding the public keys signatures are verified
+// against. Every key in the data of the object is read, and may hold
+// one or more ASCII armored GPG public keys, or SSH public keys in
+// the `authorized_keys` format.
+type KeyringSource struct {
+	// SecretRef refers to a Secret holding the public keys.
+	// +optional
+	SecretRef *LocalObjectReference `json:"secretRef,omitempty"`
+	// ConfigMapRef refers to a ConfigMap holding the public keys.
+	// +optional
+	ConfigMapRef *LocalObjectReference `json:"configMapRef,omitempty"`
+}
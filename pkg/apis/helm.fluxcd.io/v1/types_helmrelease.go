@@ -102,6 +102,15 @@ func (hr HelmRelease) GetTimeout() time.Duration {
 	return time.Duration(*hr.Spec.Timeout) * time.Second
 }
 
+// GetInterval returns the interval at which the release is
+// reconciled, or the given default if it is not set.
+func (hr HelmRelease) GetInterval(defaultInterval time.Duration) time.Duration {
+	if hr.Spec.Interval == nil || hr.Spec.Interval.Duration <= 0 {
+		return defaultInterval
+	}
+	return hr.Spec.Interval.Duration
+}
+
 // GetMaxHistory returns the maximum number of release
 // revisions to keep (defaults to 10)
 func (hr HelmRelease) GetMaxHistory() int {
@@ -178,6 +187,17 @@ type ChartSource struct {
 	*GitChartSource `json:",inline"`
 	// +optional
 	*RepoChartSource `json:",inline"`
+	// ObjectChartSource sources the chart from a ConfigMap or Secret,
+	// instead of Git or a Helm repository.
+	// +optional
+	ObjectChartSource *ObjectChartSource `json:"objectRef,omitempty"`
+	// Verify requires the chart source to be signed by one of the keys
+	// in the referred keyring. For Git chart sources the HEAD commit of
+	// the Ref must be signed (using GPG or SSH), for Helm repository
+	// chart sources the chart must have a provenance file signed using
+	// GPG. Unverified charts are refused.
+	// +optional
+	Verify *KeyringSource `json:"verify,omitempty"`
 }
 
 // GitChartSource describes a Helm chart sourced from Git.
@@ -191,12 +211,35 @@ type GitChartSource struct {
 	// 'master', or the configured default Git ref.
 	// +kubebuilder:validation:Optional
 	Ref string `json:"ref"`
+	// Tag is the Git tag to use, it takes precedence over the Ref.
+	// +optional
+	Tag string `json:"tag,omitempty"`
+	// SemVer is a semver range (e.g. `>=1.0.0 <2.0.0`), the highest
+	// tag matching the range is used. It takes precedence over the
+	// Tag and Ref. Tags that are no valid semver versions are ignored.
+	// +optional
+	SemVer string `json:"semver,omitempty"`
+	// TagPrefix is stripped from tags before they are matched against
+	// the SemVer range, tags without it are ignored. This allows
+	// tagging multiple charts in one repository, e.g. `podinfo-` for
+	// `podinfo-1.2.3`.
+	// +optional
+	TagPrefix string `json:"tagPrefix,omitempty"`
+	// Commit is the exact Git commit to use, it takes precedence over
+	// the SemVer range, Tag and Ref.
+	// +optional
+	Commit string `json:"commit,omitempty"`
 	// Path is the path to the chart relative to the repository root.
 	// +kubebuilder:validation:Optional
 	Path string `json:"path"`
 	// SecretRef holds the authentication secret for accessing the Git
-	// repository (over HTTPS). The credentials will be added to an
-	// HTTPS GitURL before the mirror is started.
+	// repository. For HTTPS, the `username` and `password` (or the
+	// `githubAppID`, `githubAppInstallationID` and `githubAppPrivateKey`
+	// of a GitHub App) in the secret are used, and read again on every
+	// fetch so rotated credentials are picked up. For SSH, the private
+	// key in the `identity` of the secret is used, and the hosts in the
+	// `known_hosts` of the secret (if present) are trusted instead of
+	// the hosts known to the operator.
 	// +optional
 	SecretRef *ObjectReference `json:"secretRef,omitempty"`
 	// SkipDepUpdate will tell the operator to skip running
@@ -204,6 +247,16 @@ type GitChartSource struct {
 	// chart dependencies _must_ be present for this to succeed.
 	// +optional
 	SkipDepUpdate bool `json:"skipDepUpdate,omitempty"`
+	// Submodules will tell the operator to check out the Git
+	// submodules of the repository (recursively) when exporting the
+	// chart. The submodules are fetched using the SecretRef.
+	// +optional
+	Submodules bool `json:"submodules,omitempty"`
+	// LFS will tell the operator to fetch the Git LFS objects of the
+	// repository (and its submodules) when exporting the chart. The
+	// objects are fetched using the SecretRef.
+	// +optional
+	LFS bool `json:"lfs,omitempty"`
 }
 
 // RefOrDefault returns the configured ref of the chart source. If the chart source
@@ -231,8 +284,10 @@ type RepoChartSource struct {
 	// +kubebuilder:validation:Optional
 	Version string `json:"version"`
 	// ChartPullSecret holds the reference to the authentication secret for accessing
-	// the Helm repository using HTTPS basic auth.
-	// NOT IMPLEMENTED!
+	// the Helm repository. The secret may contain `username` and `password` for
+	// HTTPS basic auth, a bearer `token`, and `certFile`, `keyFile` and `caFile`
+	// data for TLS authentication. For object storage repositories, it may
+	// also contain the `endpoint` and `region` of the bucket.
 	// +kubebuilder:validation:Optional
 	// +optional
 	ChartPullSecret *LocalObjectReference `json:"chartPullSecret,omitempty"`
@@ -250,6 +305,26 @@ func (s RepoChartSource) CleanRepoURL() string {
 	return cleanURL.String()
 }
 
+// ObjectChartSource describes a Helm chart sourced from a ConfigMap
+// or Secret in the namespace of the HelmRelease. The object either
+// holds a packaged chart under the Key, or the files of an unpacked
+// chart keyed by their path relative to the root of the chart. As
+// keys can not contain slashes, `__` is used as path separator, e.g.
+// `templates__deployment.yaml`.
+type ObjectChartSource struct {
+	// Kind of the object holding the chart, one of ('ConfigMap',
+	// 'Secret').
+	// +kubebuilder:validation:Enum="ConfigMap";"Secret"
+	Kind string `json:"kind"`
+	// Name of the object holding the chart.
+	Name string `json:"name"`
+	// Key is the key of the packaged chart (`.tgz`) in the (binary)
+	// data of the object. If not set, the object is expected to hold
+	// the files of an unpacked chart.
+	// +optional
+	Key string `json:"key,omitempty"`
+}
+
 type ValuesFromSource struct {
 	// The reference to a config map with release values.
 	// +optional
@@ -339,6 +414,38 @@ func (r Rollback) GetMaxRetries() int64 {
 	return *r.MaxRetries
 }
 
+// Backoff configures the exponential backoff of the retries of failed
+// reconciliations of a Helm release.
+type Backoff struct {
+	// Base is the delay before the first retry of a failed
+	// reconciliation, e.g. `10s`, which doubles on every consecutive
+	// failure. If not supplied, it defaults to the configured release
+	// backoff base.
+	// +optional
+	Base *metav1.Duration `json:"base,omitempty"`
+	// Max is the maximum delay between retries of failed
+	// reconciliations, e.g. `30m`. If not supplied, it defaults to
+	// the configured release backoff max.
+	// +optional
+	Max *metav1.Duration `json:"max,omitempty"`
+}
+
+// GetBase returns the configured base delay, or the given default.
+func (b Backoff) GetBase(defaultBase time.Duration) time.Duration {
+	if b.Base == nil || b.Base.Duration <= 0 {
+		return defaultBase
+	}
+	return b.Base.Duration
+}
+
+// GetMax returns the configured maximum delay, or the given default.
+func (b Backoff) GetMax(defaultMax time.Duration) time.Duration {
+	if b.Max == nil || b.Max.Duration <= 0 {
+		return defaultMax
+	}
+	return b.Max.Duration
+}
+
 type Test struct {
 	// Enable will mark this Helm release for tests.
 	// +optional
@@ -413,6 +520,13 @@ type HelmReleaseSpec struct {
 	// MaxHistory is the maximum amount of revisions to keep for the
 	// Helm release. If not supplied, it defaults to 10.
 	MaxHistory *int `json:"maxHistory,omitempty"`
+	// StorageDriver is the storage backend used by Helm to store the
+	// release information, one of ('secret', 'configmap', 'sql'). Only
+	// supported by Helm 3. If not supplied, it defaults to the storage
+	// driver configured for the operator.
+	// +kubebuilder:validation:Enum="secret";"configmap";"sql"
+	// +optional
+	StorageDriver string `json:"storageDriver,omitempty"`
 	// ValueFileSecrets holds the local name references to secrets.
 	// DEPRECATED, use ValuesFrom.secretKeyRef instead.
 	ValueFileSecrets []LocalObjectReference `json:"valueFileSecrets,omitempty"`
@@ -427,6 +541,17 @@ type HelmReleaseSpec struct {
 	// upgrade operations.
 	// +optional
 	Timeout *int64 `json:"timeout,omitempty"`
+	// Interval is the interval at which the Helm release is
+	// reconciled with the HelmRelease, e.g. `1m` or `1h`. If not
+	// supplied, it defaults to the configured charts sync interval.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+	// Priority is the priority of the HelmRelease in the queue of
+	// the operator. Releases with a higher priority are processed
+	// before releases with a lower priority that have been queued
+	// for the same reason, e.g. a change of the spec.
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
 	// ResetValues will mark this Helm release to reset the values
 	// to the defaults of the targeted chart before performing
 	// an upgrade. Not explicitly setting this to `false` equals
@@ -453,6 +578,10 @@ type HelmReleaseSpec struct {
 	// The test settings for this Helm release.
 	// +optional
 	Test Test `json:"test,omitempty"`
+	// The backoff settings for retries of failed reconciliations of
+	// this Helm release.
+	// +optional
+	Backoff Backoff `json:"backoff,omitempty"`
 	// Values holds the values for this Helm release.
 	// +optional
 	Values *apiextensionsv1.JSON `json:"values,omitempty"`
@@ -464,11 +593,14 @@ type HelmReleaseSpec struct {
 // HelmReleaseConditionType represents an HelmRelease condition value.
 // Valid HelmReleaseConditionType values are:
 // "ChartFetched",
+// "ChartVerified",
 // "Deployed",
+// "Recovered",
 // "Released",
 // "RolledBack"
+// "SourceReady",
 // "Tested",
-// +kubebuilder:validation:Enum="ChartFetched";"Deployed";"Released";"RolledBack";"Tested"
+// +kubebuilder:validation:Enum="ChartFetched";"ChartVerified";"Deployed";"Recovered";"Released";"RolledBack";"SourceReady";"Tested"
 // +optional
 type HelmReleaseConditionType string
 
@@ -476,22 +608,31 @@ const (
 	// ChartFetched means the chart to which the HelmRelease refers
 	// has been fetched successfully.
 	HelmReleaseChartFetched HelmReleaseConditionType = "ChartFetched"
+	// ChartVerified means the signature of the chart source to which
+	// the HelmRelease refers has been verified successfully.
+	HelmReleaseChartVerified HelmReleaseConditionType = "ChartVerified"
 	// Deployed means the chart to which the HelmRelease refers has
 	// been successfully installed or upgraded.
 	HelmReleaseDeployed HelmReleaseConditionType = "Deployed"
+	// Recovered means the Helm release was stuck in a pending state,
+	// and has been recovered by the operator.
+	HelmReleaseRecovered HelmReleaseConditionType = "Recovered"
 	// Released means the chart release, as specified in this
 	// HelmRelease, has been processed by Helm.
 	HelmReleaseReleased HelmReleaseConditionType = "Released"
 	// RolledBack means the chart to which the HelmRelease refers
 	// has been rolled back.
 	HelmReleaseRolledBack HelmReleaseConditionType = "RolledBack"
+	// SourceReady means the (Git) chart source to which the
+	// HelmRelease refers is mirrored and resolved successfully.
+	HelmReleaseSourceReady HelmReleaseConditionType = "SourceReady"
 	// Tested means the chart to which the HelmRelease refers has
 	// been successfully tested.
 	HelmReleaseTested HelmReleaseConditionType = "Tested"
 )
 
 type HelmReleaseCondition struct {
-	// Type of the condition, one of ('ChartFetched', 'Deployed', 'Released', 'RolledBack', 'Tested').
+	// Type of the condition, one of ('ChartFetched', 'ChartVerified', 'Deployed', 'Recovered', 'Released', 'RolledBack', 'SourceReady', 'Tested').
 	Type HelmReleaseConditionType `json:"type"`
 
 	// Status of the condition, one of ('True', 'False', 'Unknown').
@@ -522,6 +663,8 @@ type HelmReleaseCondition struct {
 // Valid HelmReleasePhase values are:
 // "ChartFetched",
 // "ChartFetchFailed",
+// "ChartVerified",
+// "ChartVerificationFailed",
 // "Installing",
 // "Upgrading",
 // "Deployed",
@@ -534,7 +677,7 @@ type HelmReleaseCondition struct {
 // "RollingBack",
 // "RolledBack",
 // "RollbackFailed",
-// +kubebuilder:validation:Enum="ChartFetched";"ChartFetchFailed";"Installing";"Upgrading";"Deployed";"DeployFailed";"Testing";"TestFailed";"Tested";"Succeeded";"Failed";"RollingBack";"RolledBack";"RollbackFailed"
+// +kubebuilder:validation:Enum="ChartFetched";"ChartFetchFailed";"ChartVerified";"ChartVerificationFailed";"Installing";"Upgrading";"Deployed";"DeployFailed";"Testing";"TestFailed";"Tested";"Succeeded";"Failed";"RollingBack";"RolledBack";"RollbackFailed"
 // +optional
 type HelmReleasePhase string
 
@@ -545,6 +688,12 @@ const (
 	// ChartFetchedFailed means the chart to which the HelmRelease
 	// refers could not be fetched.
 	HelmReleasePhaseChartFetchFailed HelmReleasePhase = "ChartFetchFailed"
+	// ChartVerified means the signature of the chart source to which
+	// the HelmRelease refers has been verified successfully.
+	HelmReleasePhaseChartVerified HelmReleasePhase = "ChartVerified"
+	// ChartVerificationFailed means the signature of the chart source
+	// to which the HelmRelease refers could not be verified.
+	HelmReleasePhaseChartVerificationFailed HelmReleasePhase = "ChartVerificationFailed"
 
 	// Installing means the installation for the HelmRelease is running.
 	HelmReleasePhaseInstalling HelmReleasePhase = "Installing"
@@ -581,6 +730,16 @@ const (
 	HelmReleasePhaseRollbackFailed HelmReleasePhase = "RollbackFailed"
 )
 
+// GitSourceStatus holds the resolved reference of a Git chart source.
+type GitSourceStatus struct {
+	// Tag is the tag the chart source was resolved to, if any.
+	// +optional
+	Tag string `json:"tag,omitempty"`
+	// Commit is the commit the chart source was resolved to.
+	// +optional
+	Commit string `json:"commit,omitempty"`
+}
+
 // HelmReleaseStatus contains status information about an HelmRelease.
 type HelmReleaseStatus struct {
 	// ObservedGeneration is the most recent generation observed by
@@ -589,9 +748,10 @@ type HelmReleaseStatus struct {
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 
 	// Phase the release is in, one of ('ChartFetched',
-	// 'ChartFetchFailed', 'Installing', 'Upgrading', 'Deployed',
-	// 'DeployFailed', 'Testing', 'TestFailed', 'Tested', 'Succeeded',
-	// 'RollingBack', 'RolledBack', 'RollbackFailed')
+	// 'ChartFetchFailed', 'ChartVerified', 'ChartVerificationFailed',
+	// 'Installing', 'Upgrading', 'Deployed', 'DeployFailed', 'Testing',
+	// 'TestFailed', 'Tested', 'Succeeded', 'RollingBack', 'RolledBack',
+	// 'RollbackFailed')
 	// +optional
 	Phase HelmReleasePhase `json:"phase,omitempty"`
 
@@ -614,12 +774,27 @@ type HelmReleaseStatus struct {
 	// +optional
 	LastAttemptedRevision string `json:"lastAttemptedRevision,omitempty"`
 
+	// GitSource holds the tag and commit the Git chart source was
+	// resolved to during the latest chart sync.
+	// +optional
+	GitSource *GitSourceStatus `json:"gitSource,omitempty"`
+
 	// RollbackCount records the amount of rollback attempts made,
 	// it is incremented after a rollback failure and reset after a
 	// successful upgrade or revision change.
 	// +optional
 	RollbackCount int64 `json:"rollbackCount,omitempty"`
 
+	// Failures records the amount of consecutive failed
+	// reconciliations, it is reset after a successful reconciliation.
+	// +optional
+	Failures int64 `json:"failures,omitempty"`
+
+	// NextRetryTime is the time at which a failed reconciliation
+	// will be retried.
+	// +optional
+	NextRetryTime *metav1.Time `json:"nextRetryTime,omitempty"`
+
 	// Conditions contains observations of the resource's state, e.g.,
 	// has the chart which it refers to been fetched.
 	// +optional
@@ -2,8 +2,10 @@ package v1
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestRefOrDefault(t *testing.T) {
@@ -31,3 +33,55 @@ func TestRefOrDefault(t *testing.T) {
 		assert.Equal(t, tc.expected, got)
 	}
 }
+
+func TestGetInterval(t *testing.T) {
+	testCases := []struct {
+		interval *metav1.Duration
+		expected time.Duration
+	}{
+		{
+			interval: &metav1.Duration{Duration: time.Minute},
+			expected: time.Minute,
+		},
+		{
+			interval: nil,
+			expected: 3 * time.Minute,
+		},
+		{
+			interval: &metav1.Duration{},
+			expected: 3 * time.Minute,
+		},
+	}
+
+	for _, tc := range testCases {
+		hr := HelmRelease{Spec: HelmReleaseSpec{Interval: tc.interval}}
+		assert.Equal(t, tc.expected, hr.GetInterval(3*time.Minute))
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	testCases := []struct {
+		backoff     Backoff
+		expectedMin time.Duration
+		expectedMax time.Duration
+	}{
+		{
+			backoff: Backoff{
+				Base: &metav1.Duration{Duration: time.Second},
+				Max:  &metav1.Duration{Duration: time.Hour},
+			},
+			expectedMin: time.Second,
+			expectedMax: time.Hour,
+		},
+		{
+			backoff:     Backoff{},
+			expectedMin: 10 * time.Second,
+			expectedMax: 15 * time.Minute,
+		},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedMin, tc.backoff.GetBase(10*time.Second))
+		assert.Equal(t, tc.expectedMax, tc.backoff.GetMax(15*time.Minute))
+	}
+}
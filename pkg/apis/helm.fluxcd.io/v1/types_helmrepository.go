@@ -0,0 +1,125 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// HelmRepository is a type to represent a Helm chart repository that
+// is used by the HelmReleases in the same namespace.
+// +k8s:openapi-gen=true
+// +kubebuilder:printcolumn:name="URL",type="string",JSONPath=".spec.url",description="URL is the URL of the Helm chart repository."
+// +kubebuilder:printcolumn:name="Charts",type="integer",JSONPath=".status.chartCount",description="ChartCount is the number of charts in the last fetched index of the repository."
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description=""
+// +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].message",description=""
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="CreationTimestamp is a timestamp representing the server time when this object was created. It is not guaranteed to be set in happens-before order across separate operations. Clients may not set this value. It is represented in RFC3339 form and is in UTC."
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=helmrepositories,shortName=hrepo
+type HelmRepository struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	Spec   HelmRepositorySpec   `json:"spec"`
+	Status HelmRepositoryStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// HelmRepositoryList is a list of HelmRepositories
+type HelmRepositoryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []HelmRepository `json:"items"`
+}
+
+type HelmRepositorySpec struct {
+	// URL is the URL of the Helm chart repository, e.g.
+	// `https://kubernetes-charts.storage.googleapis.com/`. HelmReleases
+	// in the same namespace with a matching `.chart.repository` use the
+	// credentials of the repository.
+	// +kubebuilder:validation:Required
+	URL string `json:"url"`
+	// SecretRef holds the reference to a secret in the same namespace
+	// as the HelmRepository with the credentials for the repository.
+	// The secret may contain `username` and `password` for HTTPS basic
+	// auth, and `certFile`, `keyFile` and `caFile` data for TLS
+	// authentication. For object storage repositories, it may also
+	// contain the `endpoint` and `region` of the bucket.
+	// +optional
+	SecretRef *LocalObjectReference `json:"secretRef,omitempty"`
+}
+
+// HelmRepositoryConditionType represents an HelmRepository condition value.
+// Valid HelmRepositoryConditionType values are:
+// "Ready"
+// +kubebuilder:validation:Enum="Ready"
+// +optional
+type HelmRepositoryConditionType string
+
+const (
+	// HelmRepositoryReady means the index of the repository has been
+	// fetched.
+	HelmRepositoryReady HelmRepositoryConditionType = "Ready"
+)
+
+type HelmRepositoryCondition struct {
+	// Type of the condition, one of ('Ready').
+	Type HelmRepositoryConditionType `json:"type"`
+
+	// Status of the condition, one of ('True', 'False', 'Unknown').
+	Status ConditionStatus `json:"status"`
+
+	// LastUpdateTime is the timestamp corresponding to the last status
+	// update of this condition.
+	// +optional
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+
+	// LastTransitionTime is the timestamp corresponding to the last status
+	// change of this condition.
+	// +optional
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// Reason is a brief machine readable explanation for the condition's last
+	// transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Message is a human readable description of the details of the last
+	// transition, complementing reason.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// HelmRepositoryStatus contains status information about a
+// HelmRepository.
+type HelmRepositoryStatus struct {
+	// ObservedGeneration is the most recent generation observed by
+	// the operator.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// LastIndexFetchTime is the timestamp corresponding to the last
+	// successful fetch of the repository index.
+	// +optional
+	LastIndexFetchTime *metav1.Time `json:"lastIndexFetchTime,omitempty"`
+
+	// IndexGenerated is the timestamp at which the last fetched index
+	// was generated by the repository.
+	// +optional
+	IndexGenerated *metav1.Time `json:"indexGenerated,omitempty"`
+
+	// ChartCount is the number of charts in the last fetched index of
+	// the repository.
+	// +optional
+	ChartCount int `json:"chartCount,omitempty"`
+
+	// Conditions contains observations of the resource's state, e.g.,
+	// has the repository been added and its index fetched.
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	Conditions []HelmRepositoryCondition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
@@ -0,0 +1,187 @@
+package chartsync
+
+import (
+	"container/list"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ChartCache keeps track of the chart tarballs downloaded to a base
+// directory, and evicts the least recently used charts once their
+// total size exceeds the configured maximum. Charts that are in use
+// are pinned, and never evicted until they are released.
+type ChartCache struct {
+	base    string
+	maxSize int64
+
+	mu      sync.Mutex
+	size    int64
+	lru     *list.List
+	entries map[string]*list.Element
+	scanned map[string]bool
+}
+
+type cacheEntry struct {
+	path string
+	size int64
+	// refs is the number of users of the chart, the chart is only
+	// evicted when it is zero.
+	refs int
+}
+
+// NewChartCache returns a new chart cache for the given base
+// directory. A maxSize of zero or less disables eviction.
+func NewChartCache(base string, maxSize int64) *ChartCache {
+	return &ChartCache{
+		base:    base,
+		maxSize: maxSize,
+		lru:     list.New(),
+		entries: make(map[string]*list.Element),
+		scanned: make(map[string]bool),
+	}
+}
+
+// Base returns the base directory of the cache.
+func (c *ChartCache) Base() string {
+	return c.base
+}
+
+// Get reports whether the chart at the given path is in the cache.
+// If it is, it is marked as most recently used and pinned, and Release
+// must be called with the path once the chart is no longer in use.
+// The directory of the given client version is scanned for charts
+// downloaded before a restart the first time it is accessed.
+func (c *ChartCache) Get(clientVersion, path string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.scan(clientVersion)
+	e, ok := c.entries[path]
+	if !ok {
+		cacheMisses.Add(1)
+		return false
+	}
+	e.Value.(*cacheEntry).refs++
+	c.lru.MoveToFront(e)
+	cacheHits.Add(1)
+	return true
+}
+
+// Add records the chart at the given path as most recently used and
+// pins it, and evicts the least recently used charts that are not in
+// use until the size of the cache is within the maximum again.
+// Release must be called with the path once the chart is no longer in
+// use.
+func (c *ChartCache) Add(path string) error {
+	stat, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.entries[path]; ok {
+		// The chart was replaced, e.g. to fetch its provenance file.
+		entry := e.Value.(*cacheEntry)
+		c.size += stat.Size() - entry.size
+		entry.size = stat.Size()
+		entry.refs++
+		c.lru.MoveToFront(e)
+	} else {
+		c.entries[path] = c.lru.PushFront(&cacheEntry{path: path, size: stat.Size(), refs: 1})
+		c.size += stat.Size()
+	}
+	c.evict()
+	return nil
+}
+
+// Release unpins the chart at the given path, obtained from Get or
+// Add, making it eligible for eviction once it is no longer in use.
+func (c *ChartCache) Release(path string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[path]
+	if !ok {
+		return
+	}
+	if entry := e.Value.(*cacheEntry); entry.refs > 0 {
+		entry.refs--
+	}
+	c.evict()
+}
+
+// evict removes the least recently used charts that are not in use
+// until the size of the cache is within the maximum. It must be
+// called with the lock held.
+func (c *ChartCache) evict() {
+	for e := c.lru.Back(); e != nil && c.maxSize > 0 && c.size > c.maxSize; {
+		prev := e.Prev()
+		if entry := e.Value.(*cacheEntry); entry.refs == 0 {
+			c.size -= entry.size
+			c.lru.Remove(e)
+			delete(c.entries, entry.path)
+			removeChart(entry.path)
+			cacheEvictions.Add(1)
+		}
+		e = prev
+	}
+	cacheSize.Set(float64(c.size))
+}
+
+// scan adds the charts found in the directory of the given client
+// version to the cache, ordered by their modification time. It must
+// be called with the lock held.
+func (c *ChartCache) scan(clientVersion string) {
+	if c.scanned[clientVersion] {
+		return
+	}
+	c.scanned[clientVersion] = true
+
+	var found []*cacheEntry
+	modTimes := make(map[string]time.Time)
+	filepath.Walk(filepath.Join(c.base, clientVersion), func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+		if info.IsDir() {
+			// Remove what is left behind by interrupted downloads.
+			if strings.HasPrefix(info.Name(), downloadDirPrefix) {
+				os.RemoveAll(path)
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(path) != ".tgz" {
+			return nil
+		}
+		if _, ok := c.entries[path]; ok {
+			return nil
+		}
+		found = append(found, &cacheEntry{path: path, size: info.Size()})
+		modTimes[path] = info.ModTime()
+		return nil
+	})
+
+	// Oldest first, so the most recently used end up in front.
+	sort.Slice(found, func(i, j int) bool {
+		return modTimes[found[i].path].Before(modTimes[found[j].path])
+	})
+	for _, e := range found {
+		c.entries[e.path] = c.lru.PushFront(e)
+		c.size += e.size
+	}
+	c.evict()
+}
+
+// removeChart removes the chart at the given path, and its provenance
+// file if any, from the filesystem.
+func removeChart(path string) {
+	os.Remove(path)
+	os.Remove(path + ".prov")
+}
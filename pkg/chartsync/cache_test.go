@@ -0,0 +1,95 @@
+package chartsync
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeChart(t *testing.T, dir, name string, size int, modTime time.Time) string {
+	path := filepath.Join(dir, name)
+	require.NoError(t, ioutil.WriteFile(path, make([]byte, size), 0644))
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+	return path
+}
+
+func TestChartCache_Evict(t *testing.T) {
+	base, err := ioutil.TempDir("", "chart-cache-")
+	require.NoError(t, err)
+	defer os.RemoveAll(base)
+
+	dir := filepath.Join(base, "v3", "repo")
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	now := time.Now()
+	oldest := writeChart(t, dir, "a-1.0.0.tgz", 10, now.Add(-3*time.Hour))
+	older := writeChart(t, dir, "b-1.0.0.tgz", 10, now.Add(-2*time.Hour))
+	old := writeChart(t, dir, "c-1.0.0.tgz", 10, now.Add(-1*time.Hour))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, downloadDirPrefix+"123"), 0755))
+
+	cache := NewChartCache(base, 25)
+
+	// The scan evicts the oldest chart, and removes the leftovers of
+	// interrupted downloads.
+	assert.True(t, cache.Get("v3", older))
+	assert.NoFileExists(t, oldest)
+	assert.NoDirExists(t, filepath.Join(dir, downloadDirPrefix+"123"))
+
+	// As `older` was used last, adding a new chart evicts `old`.
+	added := writeChart(t, dir, "d-1.0.0.tgz", 10, now)
+	require.NoError(t, cache.Add(added))
+	assert.FileExists(t, older)
+	assert.FileExists(t, added)
+	assert.NoFileExists(t, old)
+	assert.False(t, cache.Get("v3", old))
+	cache.Release(older)
+	cache.Release(added)
+
+	// A chart larger than the maximum is kept, as it is about to be
+	// used.
+	large := writeChart(t, dir, "e-1.0.0.tgz", 30, now)
+	require.NoError(t, cache.Add(large))
+	assert.FileExists(t, large)
+	assert.NoFileExists(t, older)
+	assert.NoFileExists(t, added)
+}
+
+func TestChartCache_Pinned(t *testing.T) {
+	base, err := ioutil.TempDir("", "chart-cache-")
+	require.NoError(t, err)
+	defer os.RemoveAll(base)
+
+	dir := filepath.Join(base, "v3", "repo")
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	now := time.Now()
+	cache := NewChartCache(base, 15)
+
+	// A chart in use is not evicted, even if it is the least
+	// recently used.
+	inUse := writeChart(t, dir, "a-1.0.0.tgz", 10, now)
+	require.NoError(t, cache.Add(inUse))
+	added := writeChart(t, dir, "b-1.0.0.tgz", 10, now)
+	require.NoError(t, cache.Add(added))
+	assert.FileExists(t, inUse)
+	assert.FileExists(t, added)
+
+	// It is evicted once released, if the cache is still too large.
+	cache.Release(inUse)
+	cache.Release(added)
+	assert.NoFileExists(t, inUse)
+	assert.FileExists(t, added)
+
+	// Getting a chart does not modify the file.
+	before, err := os.Stat(added)
+	require.NoError(t, err)
+	time.Sleep(10 * time.Millisecond)
+	assert.True(t, cache.Get("v3", added))
+	after, err := os.Stat(added)
+	require.NoError(t, err)
+	assert.Equal(t, before.ModTime(), after.ModTime())
+	cache.Release(added)
+}
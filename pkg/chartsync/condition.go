@@ -0,0 +1,110 @@
+package chartsync
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/fluxcd/helm-operator/pkg/apis/helm.fluxcd.io/v1"
+	"github.com/fluxcd/helm-operator/pkg/git"
+	"github.com/fluxcd/helm-operator/pkg/status"
+)
+
+// Reasons of the SourceReady condition.
+const (
+	ReasonMirrorMissing      = "MirrorMissing"
+	ReasonResolutionFailed   = "ResolutionFailed"
+	ReasonShallowUnsupported = "ShallowRefUnsupported"
+	ReasonMirrorUnconfigured = "MirrorUnconfigured"
+	ReasonMirrorNew          = "MirrorNew"
+	ReasonMirrorCloned       = "MirrorCloned"
+	ReasonMirrorReady        = "MirrorReady"
+	ReasonMirrorUnreachable  = "MirrorUnreachable"
+)
+
+var mirrorReasons = map[git.GitRepoStatus]string{
+	git.RepoNoConfig:    ReasonMirrorUnconfigured,
+	git.RepoNew:         ReasonMirrorNew,
+	git.RepoCloned:      ReasonMirrorCloned,
+	git.RepoReady:       ReasonMirrorReady,
+	git.RepoUnreachable: ReasonMirrorUnreachable,
+}
+
+// setSourceReady updates the SourceReady condition of the given
+// `v1.HelmRelease` with the state of the given mirror, and the result
+// of syncing the source of the release with it. The status is not
+// written if the condition did not change. Failures are logged, as the
+// condition is informational.
+func (c *GitChartSync) setSourceReady(hr *v1.HelmRelease, repo *git.Repo, s sourceRef, syncErr error) {
+	condition := sourceReadyCondition(repo, s, syncErr)
+	if err := status.SetCondition(c.hrClient.HelmReleases(hr.Namespace), hr, condition); err != nil {
+		c.logger.Log("warning", "failed to update SourceReady condition", "resource", hr.ResourceID().String(), "err", err)
+	}
+}
+
+// sourceReadyCondition returns the SourceReady condition for a Git
+// chart source, from the state of the given mirror (which is nil if
+// there is no mirror) and the result of syncing the source with it.
+// The update time is the time of the last successful fetch, which is
+// not part of the message so that the condition is only written to the
+// status when the state of the source changes.
+func sourceReadyCondition(repo *git.Repo, s sourceRef, syncErr error) v1.HelmReleaseCondition {
+	condition := v1.HelmReleaseCondition{Type: v1.HelmReleaseSourceReady}
+	if repo == nil {
+		condition.Status = v1.ConditionUnknown
+		condition.Reason = ReasonMirrorMissing
+		condition.Message = "Git mirror has been requested."
+		return withTimes(condition)
+	}
+
+	state, mirrorErr := repo.Status()
+	condition.Reason = mirrorReasons[state]
+	switch {
+	case state == git.RepoReady && syncErr == nil:
+		condition.Status = v1.ConditionTrue
+		condition.Message = fmt.Sprintf("Resolved HEAD %s of %s", s.head, s.ref)
+		if s.tag != "" {
+			condition.Message += fmt.Sprintf(" (tag %s)", s.tag)
+		}
+		condition.Message += "."
+	case state == git.RepoReady:
+		condition.Status = v1.ConditionFalse
+		condition.Reason = ReasonResolutionFailed
+		if errors.Is(syncErr, ErrShallowRef) {
+			condition.Reason = ReasonShallowUnsupported
+		}
+		condition.Message = firstLine(syncErr)
+	case mirrorErr == nil || errors.Is(mirrorErr, git.ErrNotCloned) || errors.Is(mirrorErr, git.ErrClonedOnly):
+		condition.Status = v1.ConditionUnknown
+		condition.Message = fmt.Sprintf("Git mirror is %s.", state)
+	default:
+		condition.Status = v1.ConditionFalse
+		condition.Message = firstLine(mirrorErr)
+	}
+	condition = withTimes(condition)
+	if lastFetch := repo.LastFetch(); !lastFetch.IsZero() {
+		t := metav1.NewTime(lastFetch)
+		condition.LastUpdateTime = &t
+	}
+	return condition
+}
+
+// withTimes sets the update and transition times of the given
+// condition to now. The transition time is retained if the status
+// does not change when the condition is set.
+func withTimes(condition v1.HelmReleaseCondition) v1.HelmReleaseCondition {
+	now := metav1.NewTime(status.Clock.Now())
+	condition.LastUpdateTime = &now
+	condition.LastTransitionTime = &now
+	return condition
+}
+
+// firstLine returns the first line of the message of the given error,
+// as the full output of git that follows it may contain temporary
+// paths which differ on every attempt.
+func firstLine(err error) string {
+	line := strings.SplitN(err.Error(), "\n", 2)[0]
+	return strings.TrimSuffix(strings.TrimSpace(line), ", full output:")
+}
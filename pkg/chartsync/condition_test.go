@@ -0,0 +1,96 @@
+package chartsync
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/fluxcd/helm-operator/pkg/apis/helm.fluxcd.io/v1"
+	"github.com/fluxcd/helm-operator/pkg/client/clientset/versioned/fake"
+	"github.com/fluxcd/helm-operator/pkg/git"
+)
+
+func TestSourceReadyCondition(t *testing.T) {
+	dir, err := ioutil.TempDir("", "condition-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	out, err := exec.Command("git", "-C", dir, "init", "-q").CombinedOutput()
+	require.NoError(t, err, string(out))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	condition := sourceReadyCondition(nil, sourceRef{}, ErrNoMirror)
+	assert.Equal(t, v1.ConditionUnknown, condition.Status)
+	assert.Equal(t, ReasonMirrorMissing, condition.Reason)
+
+	repo := git.NewRepo(git.Remote{URL: dir})
+	condition = sourceReadyCondition(repo, sourceRef{}, nil)
+	assert.Equal(t, v1.ConditionUnknown, condition.Status)
+	assert.Equal(t, ReasonMirrorNew, condition.Reason)
+	assert.Equal(t, "Git mirror is new.", condition.Message)
+
+	require.NoError(t, repo.Ready(ctx))
+	defer repo.Clean()
+	condition = sourceReadyCondition(repo, sourceRef{ref: "master", head: "abc123"}, nil)
+	assert.Equal(t, v1.ConditionTrue, condition.Status)
+	assert.Equal(t, ReasonMirrorReady, condition.Reason)
+	assert.Equal(t, "Resolved HEAD abc123 of master.", condition.Message)
+	assert.Equal(t, repo.LastFetch().Unix(), condition.LastUpdateTime.Unix())
+
+	syncErr := ChartUnavailableError{Err: errors.New("unknown revision, full output:\n /tmp/flux-working123")}
+	condition = sourceReadyCondition(repo, sourceRef{}, syncErr)
+	assert.Equal(t, v1.ConditionFalse, condition.Status)
+	assert.Equal(t, ReasonResolutionFailed, condition.Reason)
+	assert.NotContains(t, condition.Message, "/tmp")
+
+	condition = sourceReadyCondition(repo, sourceRef{}, ChartUnavailableError{Err: ErrShallowRef})
+	assert.Equal(t, v1.ConditionFalse, condition.Status)
+	assert.Equal(t, ReasonShallowUnsupported, condition.Reason)
+
+	unreachable := git.NewRepo(git.Remote{URL: dir + "-missing"})
+	assert.Error(t, unreachable.Ready(ctx))
+	condition = sourceReadyCondition(unreachable, sourceRef{}, nil)
+	assert.Equal(t, v1.ConditionFalse, condition.Status)
+	assert.Equal(t, ReasonMirrorNew, condition.Reason)
+}
+
+func TestSetSourceReady_Unchanged(t *testing.T) {
+	dir, err := ioutil.TempDir("", "condition-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	out, err := exec.Command("git", "-C", dir, "init", "-q").CombinedOutput()
+	require.NoError(t, err, string(out))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	repo := git.NewRepo(git.Remote{URL: dir})
+	require.NoError(t, repo.Ready(ctx))
+	defer repo.Clean()
+
+	hr := &v1.HelmRelease{ObjectMeta: metav1.ObjectMeta{Name: "podinfo", Namespace: "default"}}
+	hrClient := fake.NewSimpleClientset(hr)
+	c := &GitChartSync{logger: log.NewNopLogger(), hrClient: hrClient.HelmV1()}
+	s := sourceRef{ref: "master", head: "abc123"}
+
+	c.setSourceReady(hr, repo, s, nil)
+	require.Len(t, hrClient.Actions(), 1)
+
+	// A later fetch that did not change the source does not update
+	// the status of the release.
+	hr, err = hrClient.HelmV1().HelmReleases("default").Get("podinfo", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.NoError(t, repo.Refresh(ctx))
+	hrClient.ClearActions()
+	c.setSourceReady(hr, repo, s, nil)
+	assert.Empty(t, hrClient.Actions())
+}
@@ -1,48 +1,148 @@
 package chartsync
 
 import (
+	"context"
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/helm/pkg/urlutil"
+
 	helmfluxv1 "github.com/fluxcd/helm-operator/pkg/apis/helm.fluxcd.io/v1"
+	iflister "github.com/fluxcd/helm-operator/pkg/client/listers/helm.fluxcd.io/v1"
 	"github.com/fluxcd/helm-operator/pkg/helm"
 )
 
 // EnsureChartFetched returns the path to a downloaded chart, fetching
-// it first if necessary. It returns the (expected) path to the chart,
-// a boolean indicating a fetch, and either an error or nil.
-func EnsureChartFetched(client helm.Client, base string, source *helmfluxv1.RepoChartSource) (string, bool, error) {
-	repoPath, filename, err := makeChartPath(base, client.Version(), source)
+// it into the given cache first if necessary. The credentials to pull
+// the chart are resolved in the given namespace (see `pullSecret`),
+// and a chart pulled with credentials is stored in a location specific
+// to that namespace. If a keyring is given, the provenance file of the
+// chart is fetched as well, and the chart is verified against the
+// keyring on every call. It returns the (expected) path to the chart,
+// a boolean indicating a fetch, and either an error or nil. If no
+// error is returned, the chart is pinned in the cache, and the caller
+// must call `cache.Release` with the path once it no longer uses it.
+func EnsureChartFetched(client helm.Client, coreV1Client corev1client.CoreV1Interface, repoLister iflister.HelmRepositoryLister,
+	cache *ChartCache, namespace string, source *helmfluxv1.RepoChartSource, verify *helmfluxv1.KeyringSource) (string, bool, error) {
+
+	secret, err := pullSecret(repoLister, namespace, source)
+	if err != nil {
+		return "", false, ChartUnavailableError{Err: err}
+	}
+	repoPath, filename, err := makeChartPath(cache.Base(), client.Version(), namespace, secret != "", source)
 	if err != nil {
-		return "", false, ChartUnavailableError{err}
+		return "", false, ChartUnavailableError{Err: err}
 	}
 	chartPath := filepath.Join(repoPath, filename)
+	cached := cache.Get(client.Version(), chartPath)
 	stat, err := os.Stat(chartPath)
+	fetch := os.IsNotExist(err)
+	if err == nil && verify != nil {
+		// The chart may have been fetched before verification was
+		// enabled, in which case we need to fetch it again to get
+		// the provenance file. The chart is replaced in place, so
+		// that other users of the chart are not affected.
+		if _, err = os.Stat(chartPath + ".prov"); os.IsNotExist(err) {
+			fetch, err = true, nil
+		}
+	}
+
+	var fetched bool
 	switch {
-	case os.IsNotExist(err):
-		chartPath, err = downloadChart(client, repoPath, source)
-		if err != nil {
-			return chartPath, false, ChartUnavailableError{err}
+	case fetch:
+		if err = downloadChart(client, coreV1Client, chartPath, namespace, secret, source, verify != nil); err != nil {
+			chartDownloadFailures.With(LabelRepository, helm.RedactRepoURL(source.RepoURL)).Add(1)
+			err = ChartUnavailableError{Err: err}
+			break
+		}
+		if stat, err := os.Stat(chartPath); err == nil {
+			chartDownloadBytes.With(LabelRepository, helm.RedactRepoURL(source.RepoURL)).Add(float64(stat.Size()))
+		}
+		if err = cache.Add(chartPath); err != nil {
+			err = ChartUnavailableError{Err: err}
+			break
+		}
+		if cached {
+			// The chart was replaced, keep a single pin.
+			cache.Release(chartPath)
 		}
-		return chartPath, true, nil
+		cached, fetched = true, true
 	case err != nil:
-		return chartPath, false, ChartUnavailableError{err}
+		err = ChartUnavailableError{Err: err}
 	case stat.IsDir():
-		return chartPath, false, ChartUnavailableError{errors.New("path to chart exists but is a directory")}
+		err = ChartUnavailableError{Err: errors.New("path to chart exists but is a directory")}
+	case !cached:
+		// The chart was written by another worker after the cache
+		// was consulted.
+		if err = cache.Add(chartPath); err != nil {
+			err = ChartUnavailableError{Err: err}
+			break
+		}
+		cached = true
 	}
-	return chartPath, false, nil
+
+	if err == nil && verify != nil {
+		if vErr := verifyChart(coreV1Client, namespace, chartPath, verify); vErr != nil {
+			err = ChartUnavailableError{Err: vErr, Reason: ReasonVerificationFailed}
+		}
+	}
+	if err != nil {
+		if cached {
+			cache.Release(chartPath)
+		}
+		return chartPath, fetched, err
+	}
+	return chartPath, fetched, nil
+}
+
+// pullSecret returns the name of the secret in the given namespace
+// with the credentials to pull the chart from the given source, or an
+// empty string if no credentials are required. This is the
+// `ChartPullSecret` of the source if set, or else the secret of a
+// `HelmRepository` in the same namespace with a matching URL. The
+// `HelmRepository` resources in other namespaces are never considered,
+// as a release must not be able to make use of credentials it does
+// not have access to.
+func pullSecret(repoLister iflister.HelmRepositoryLister, namespace string, source *helmfluxv1.RepoChartSource) (string, error) {
+	if source.ChartPullSecret != nil {
+		return source.ChartPullSecret.Name, nil
+	}
+	if repoLister == nil {
+		return "", nil
+	}
+	repos, err := repoLister.HelmRepositories(namespace).List(labels.Everything())
+	if err != nil {
+		return "", err
+	}
+	for _, repo := range repos {
+		if repo.Spec.SecretRef != nil && urlutil.Equal(repo.Spec.URL, source.RepoURL) {
+			return repo.Spec.SecretRef.Name, nil
+		}
+	}
+	return "", nil
 }
 
 // makeChartPath gives the expected filesystem location for a chart,
 // without testing whether the file exists or not.
-func makeChartPath(base string, clientVersion string, source *helmfluxv1.RepoChartSource) (string, string, error) {
+func makeChartPath(base, clientVersion, namespace string, credentials bool, source *helmfluxv1.RepoChartSource) (string, string, error) {
 	// We don't need to obscure the location of the charts in the
 	// filesystem; but we do need a stable, filesystem-friendly path
 	// to them that is based on the URL and the client version.
-	repoPath := filepath.Join(base, clientVersion, base64.URLEncoding.EncodeToString([]byte(source.CleanRepoURL())))
+	repoPath := filepath.Join(base, clientVersion)
+	if credentials {
+		// Charts pulled with credentials must not be available to
+		// releases in other namespaces, which may not have access
+		// to the credentials.
+		repoPath = filepath.Join(repoPath, "namespaces", namespace)
+	}
+	repoPath = filepath.Join(repoPath, base64.URLEncoding.EncodeToString([]byte(source.CleanRepoURL())))
 	if err := os.MkdirAll(repoPath, 00750); err != nil {
 		return "", "", err
 	}
@@ -50,9 +150,89 @@ func makeChartPath(base string, clientVersion string, source *helmfluxv1.RepoCha
 	return repoPath, filename, nil
 }
 
+// downloadDirPrefix is the prefix of the temporary directories charts
+// are downloaded to, before they are moved into place.
+const downloadDirPrefix = ".download-"
+
 // downloadChart attempts to pull a chart tarball, given the name,
-// version and repo URL in `source`, and the path to write the file
-// to in `destFolder`.
-func downloadChart(helm helm.Client, destFolder string, source *helmfluxv1.RepoChartSource) (string, error) {
-	return helm.PullWithRepoURL(source.RepoURL, source.Name, source.Version, destFolder)
+// version and repo URL in `source`, and moves it to `chartPath` once
+// the download has completed and its digest has been verified, so
+// interrupted downloads never end up in the cache. The credentials
+// from the given secret are used if set. If `provenance` is true, the
+// provenance file of the chart is moved along with it.
+func downloadChart(client helm.Client, coreV1Client corev1client.CoreV1Interface, chartPath, namespace, secret string,
+	source *helmfluxv1.RepoChartSource, provenance bool) error {
+
+	var opts helm.PullOptions
+	if secret != "" {
+		tmpDir, err := ioutil.TempDir("", "chart-pull-secret-")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(tmpDir)
+
+		if opts, err = getPullOptionsFromSecret(coreV1Client, namespace, secret, tmpDir); err != nil {
+			return fmt.Errorf("failed to get credentials from secret %s/%s: %w", namespace, secret, err)
+		}
+	}
+
+	downloadDir, err := ioutil.TempDir(filepath.Dir(chartPath), downloadDirPrefix)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(downloadDir)
+
+	opts.Provenance = provenance
+	path, err := client.PullWithRepoURL(source.RepoURL, source.Name, source.Version, downloadDir, opts)
+	if err != nil {
+		return err
+	}
+	if provenance {
+		// A missing provenance file is reported on verification.
+		if err := os.Rename(path+".prov", chartPath+".prov"); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return os.Rename(path, chartPath)
+}
+
+// getPullOptionsFromSecret resolves the secret with the given name
+// in the given namespace using the core v1 client, and returns the
+// pull options with the credentials from the secret, and for object
+// storage repositories the endpoint and region. Certificates and keys
+// are written to the given directory, which should be removed after
+// the pull.
+func getPullOptionsFromSecret(coreV1Client corev1client.CoreV1Interface, namespace, name, dir string) (helm.PullOptions, error) {
+	secret, err := coreV1Client.Secrets(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return helm.PullOptions{}, err
+	}
+
+	opts := helm.PullOptions{
+		Username: string(secret.Data["username"]),
+		Password: string(secret.Data["password"]),
+		Token:    string(secret.Data["token"]),
+		Endpoint: string(secret.Data["endpoint"]),
+		Region:   string(secret.Data["region"]),
+	}
+	for key, path := range map[string]*string{
+		"certFile": &opts.CertFile,
+		"keyFile":  &opts.KeyFile,
+		"caFile":   &opts.CAFile,
+	} {
+		data, ok := secret.Data[key]
+		if !ok {
+			continue
+		}
+		f := filepath.Join(dir, key)
+		if err := ioutil.WriteFile(f, data, 0600); err != nil {
+			return helm.PullOptions{}, err
+		}
+		*path = f
+	}
+
+	if !opts.HasCredentials() && opts.Endpoint == "" && opts.Region == "" {
+		return helm.PullOptions{}, fmt.Errorf("secret %s/%s does not contain any credentials", namespace, name)
+	}
+	return opts, nil
 }
@@ -0,0 +1,88 @@
+package chartsync
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	helmfluxv1 "github.com/fluxcd/helm-operator/pkg/apis/helm.fluxcd.io/v1"
+	iflister "github.com/fluxcd/helm-operator/pkg/client/listers/helm.fluxcd.io/v1"
+)
+
+func TestPullSecret(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, repo := range []*helmfluxv1.HelmRepository{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "private"},
+			Spec: helmfluxv1.HelmRepositorySpec{
+				URL:       "https://charts.example.com/",
+				SecretRef: &helmfluxv1.LocalObjectReference{Name: "team-a-credentials"},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "public"},
+			Spec:       helmfluxv1.HelmRepositorySpec{URL: "https://public.example.com/"},
+		},
+	} {
+		assert.NoError(t, indexer.Add(repo))
+	}
+	lister := iflister.NewHelmRepositoryLister(indexer)
+
+	for _, tt := range []struct {
+		name      string
+		namespace string
+		source    helmfluxv1.RepoChartSource
+		secret    string
+	}{
+		{
+			name:      "repository in same namespace",
+			namespace: "team-a",
+			source:    helmfluxv1.RepoChartSource{RepoURL: "https://charts.example.com"},
+			secret:    "team-a-credentials",
+		},
+		{
+			name:      "repository in other namespace",
+			namespace: "team-b",
+			source:    helmfluxv1.RepoChartSource{RepoURL: "https://charts.example.com/"},
+		},
+		{
+			name:      "repository without credentials",
+			namespace: "team-a",
+			source:    helmfluxv1.RepoChartSource{RepoURL: "https://public.example.com/"},
+		},
+		{
+			name:      "chart pull secret takes precedence",
+			namespace: "team-a",
+			source: helmfluxv1.RepoChartSource{
+				RepoURL:         "https://charts.example.com/",
+				ChartPullSecret: &helmfluxv1.LocalObjectReference{Name: "pull-secret"},
+			},
+			secret: "pull-secret",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			secret, err := pullSecret(lister, tt.namespace, &tt.source)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.secret, secret)
+		})
+	}
+}
+
+func TestMakeChartPath(t *testing.T) {
+	base := t.TempDir()
+	source := &helmfluxv1.RepoChartSource{RepoURL: "https://charts.example.com/", Name: "podinfo", Version: "1.0.0"}
+
+	shared, filename, err := makeChartPath(base, "v3", "team-a", false, source)
+	assert.NoError(t, err)
+	assert.Equal(t, "podinfo-1.0.0.tgz", filename)
+
+	teamA, _, err := makeChartPath(base, "v3", "team-a", true, source)
+	assert.NoError(t, err)
+	teamB, _, err := makeChartPath(base, "v3", "team-b", true, source)
+	assert.NoError(t, err)
+
+	assert.NotEqual(t, shared, teamA)
+	assert.NotEqual(t, teamA, teamB)
+}
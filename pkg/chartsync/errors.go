@@ -1,9 +1,15 @@
 package chartsync
 
+// ReasonVerificationFailed is the reason of a ChartUnavailableError
+// returned when the chart could not be verified.
+const ReasonVerificationFailed = "VerificationFailed"
+
 // ChartUnavailableError is returned when the requested chart is
-// unavailable, and the reason is known and finite.
+// unavailable, and the reason is known and finite. The reason may
+// optionally be given as Reason, for callers that need to act on it.
 type ChartUnavailableError struct {
-	Err error
+	Err    error
+	Reason string
 }
 
 func (err ChartUnavailableError) Unwrap() error {
@@ -42,3 +48,17 @@ func (err GitAuthError) Unwrap() error {
 func (err GitAuthError) Error() string {
 	return "git auth error: " + err.Err.Error()
 }
+
+// CommitVerificationError is returned when the signature of the
+// commit a chart is sourced from could not be verified.
+type CommitVerificationError struct {
+	Err error
+}
+
+func (err CommitVerificationError) Unwrap() error {
+	return err.Err
+}
+
+func (err CommitVerificationError) Error() string {
+	return "commit verification failed: " + err.Err.Error()
+}
@@ -0,0 +1,100 @@
+package chartsync
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/fluxcd/helm-operator/pkg/git"
+)
+
+// localDependencyPrefix is the prefix of the repository of chart
+// dependencies that are sourced from the local filesystem.
+const localDependencyPrefix = "file://"
+
+// sparseExportChart creates a sparse export of the given repo at the
+// given revision, of which only the chart at the given path and its
+// local (`file://`) dependencies are checked out. Dependencies outside
+// of the repository are ignored.
+func sparseExportChart(ctx context.Context, repo *git.Repo, revision, chartPath string) (*git.Export, error) {
+	chartPath = path.Clean(chartPath)
+	paths := []string{chartPath}
+	export, err := repo.SparseExport(ctx, revision, paths)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{chartPath: true}
+	pending := []string{chartPath}
+	for len(pending) > 0 {
+		var next []string
+		for _, p := range pending {
+			for _, dep := range localDependencies(export.Dir(), p) {
+				if !seen[dep] {
+					seen[dep] = true
+					next = append(next, dep)
+				}
+			}
+		}
+		if len(next) == 0 {
+			break
+		}
+		paths = append(paths, next...)
+		if err := export.SetSparsePaths(ctx, paths); err != nil {
+			export.Clean()
+			return nil, err
+		}
+		pending = next
+	}
+	return export, nil
+}
+
+// localDependencies returns the paths (relative to the root of the
+// repository) of the local dependencies of the chart at the given
+// path, as declared in its `Chart.yaml` or `requirements.yaml`.
+func localDependencies(root, chartPath string) []string {
+	var deps []string
+	for _, f := range []string{"Chart.yaml", "requirements.yaml"} {
+		b, err := ioutil.ReadFile(filepath.Join(root, chartPath, f))
+		if err != nil {
+			continue
+		}
+		var meta struct {
+			Dependencies []struct {
+				Repository string `json:"repository"`
+			} `json:"dependencies"`
+		}
+		if err := yaml.Unmarshal(b, &meta); err != nil {
+			// Helm will complain about it.
+			continue
+		}
+		for _, d := range meta.Dependencies {
+			if !strings.HasPrefix(d.Repository, localDependencyPrefix) {
+				continue
+			}
+			p := path.Join(chartPath, strings.TrimPrefix(d.Repository, localDependencyPrefix))
+			if p == ".." || strings.HasPrefix(p, "../") || path.IsAbs(p) {
+				continue
+			}
+			deps = append(deps, p)
+		}
+	}
+	return deps
+}
+
+// dirSize returns the total size of the files in the given directory.
+func dirSize(dir string) int64 {
+	var size int64
+	filepath.Walk(dir, func(_ string, info os.FileInfo, err error) error {
+		if err == nil && info.Mode().IsRegular() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size
+}
@@ -0,0 +1,49 @@
+package chartsync
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalDependencies(t *testing.T) {
+	root, err := ioutil.TempDir("", "local-deps-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	files := map[string]string{
+		"charts/app/Chart.yaml": `apiVersion: v2
+name: app
+version: 1.0.0
+dependencies:
+- name: lib
+  repository: file://../lib
+- name: redis
+  repository: https://charts.example.com
+- name: outside
+  repository: file://../../../elsewhere
+`,
+		"charts/legacy/requirements.yaml": `dependencies:
+- name: sub
+  repository: file://sub
+`,
+	}
+	for name, content := range files {
+		p := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(p, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	assert.Equal(t, []string{"charts/lib"}, localDependencies(root, "charts/app"))
+	assert.Equal(t, []string{"charts/legacy/sub"}, localDependencies(root, "charts/legacy"))
+	assert.Empty(t, localDependencies(root, "charts/missing"))
+}
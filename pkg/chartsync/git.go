@@ -9,16 +9,18 @@ import (
 	"sync"
 	"time"
 
-	"github.com/fluxcd/flux/pkg/git"
 	"github.com/go-kit/kit/log"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/tools/cache"
 
 	v1 "github.com/fluxcd/helm-operator/pkg/apis/helm.fluxcd.io/v1"
+	v1client "github.com/fluxcd/helm-operator/pkg/client/clientset/versioned/typed/helm.fluxcd.io/v1"
 	lister "github.com/fluxcd/helm-operator/pkg/client/listers/helm.fluxcd.io/v1"
+	"github.com/fluxcd/helm-operator/pkg/git"
 )
 
 // Various (final) errors.
@@ -38,6 +40,12 @@ type GitConfig struct {
 	GitTimeout      time.Duration
 	GitPollInterval time.Duration
 	GitDefaultRef   string
+	// GitShallow makes mirrors fetch only the latest commit of every
+	// ref.
+	GitShallow bool
+	// GitSparseExport makes exports only check out the chart path
+	// and its local dependencies.
+	GitSparseExport bool
 }
 
 // GitChartSync syncs `sourceRef`s with their mirrors, and queues
@@ -47,10 +55,13 @@ type GitChartSync struct {
 	config GitConfig
 
 	coreV1Client corev1client.CoreV1Interface
+	hrClient     v1client.HelmV1Interface
 	lister       lister.HelmReleaseLister
 
 	mirrors *git.Mirrors
 
+	githubAppTokens *githubAppTokens
+
 	releaseSourcesMu   sync.RWMutex
 	releaseSourcesByID map[string]sourceRef
 
@@ -66,6 +77,7 @@ type sourceRef struct {
 	remote string
 	ref    string
 	head   string
+	tag    string
 }
 
 // forHelmRelease returns true if the given `v1.HelmRelease`s
@@ -80,18 +92,20 @@ func (c sourceRef) forHelmRelease(hr *v1.HelmRelease, defaultGitRef string) bool
 		return false
 	}
 
-	return c.mirror == mirrorName(hr) && c.remote == hr.Spec.GitURL && c.ref == hr.Spec.GitChartSource.RefOrDefault(defaultGitRef)
+	return c.mirror == mirrorName(hr) && c.remote == hr.Spec.GitURL && c.ref == gitRefSpec(hr.Spec.GitChartSource, defaultGitRef)
 }
 
-func NewGitChartSync(logger log.Logger,
-	coreV1Client corev1client.CoreV1Interface, lister lister.HelmReleaseLister, cfg GitConfig, queue ReleaseQueue) *GitChartSync {
+func NewGitChartSync(logger log.Logger, coreV1Client corev1client.CoreV1Interface, hrClient v1client.HelmV1Interface,
+	lister lister.HelmReleaseLister, cfg GitConfig, queue ReleaseQueue) *GitChartSync {
 
 	return &GitChartSync{
 		logger:             logger,
 		config:             cfg,
 		coreV1Client:       coreV1Client,
+		hrClient:           hrClient,
 		lister:             lister,
 		mirrors:            git.NewMirrors(),
+		githubAppTokens:    newGitHubAppTokens(),
 		releaseSourcesByID: make(map[string]sourceRef),
 		releaseQueue:       queue,
 	}
@@ -129,6 +143,7 @@ func (c *GitChartSync) Run(stopCh <-chan struct{}, errCh chan error, wg *sync.Wa
 						c.logger.Log("warning", ErrNoMirror.Error(), "mirror", mirrorName)
 						for _, hr := range hrs {
 							c.maybeMirror(mirrorName, hr.Spec.GitChartSource, hr.Namespace)
+							c.setSourceReady(hr, nil, sourceRef{}, ErrNoMirror)
 						}
 						// Wait for the signal from the newly requested mirror...
 						continue
@@ -138,11 +153,12 @@ func (c *GitChartSync) Run(stopCh <-chan struct{}, errCh chan error, wg *sync.Wa
 					// resource refers to it anymore.
 					if ok && len(hrs) == 0 {
 						// Garbage collect the mirror.
-						c.mirrors.StopOne(mirrorName)
+						c.stopMirror(mirrorName)
 						continue
 					}
 
 					c.processChangedMirror(mirrorName, repo, hrs)
+					gitMirrorSize.With(LabelMirror, mirrorName).Set(float64(dirSize(repo.Dir())))
 				}
 			case <-stopCh:
 				c.logger.Log("info", "stopping sync of git chart sources")
@@ -155,30 +171,72 @@ func (c *GitChartSync) Run(stopCh <-chan struct{}, errCh chan error, wg *sync.Wa
 }
 
 // GetMirrorCopy returns a newly exported copy of the git mirror at the
-// recorded HEAD and a string with the HEAD commit hash, or an error.
-func (c *GitChartSync) GetMirrorCopy(hr *v1.HelmRelease) (*git.Export, string, error) {
+// recorded HEAD, a string with the HEAD commit hash, and the tag the
+// HEAD was resolved from (if any), or an error.
+func (c *GitChartSync) GetMirrorCopy(hr *v1.HelmRelease) (*git.Export, string, string, error) {
 	mirror := mirrorName(hr)
 	repo, ok := c.mirrors.Get(mirror)
 	if !ok {
 		// We did not find a mirror; request one, return, and wait for
 		// signal.
 		c.maybeMirror(mirror, hr.Spec.GitChartSource, hr.Namespace)
-		return nil, "", ChartNotReadyError{ErrNoMirror}
+		return nil, "", "", ChartNotReadyError{ErrNoMirror}
 	}
 
 	s, ok, err := c.sync(hr, mirror, repo)
 	if err != nil {
-		return nil, "", err
+		return nil, "", "", err
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), c.config.GitTimeout)
 	defer cancel()
-	export, err := repo.Export(ctx, s.head)
+	start := time.Now()
+	var export *git.Export
+	if c.config.GitSparseExport {
+		export, err = sparseExportChart(ctx, repo, s.head, hr.Spec.GitChartSource.Path)
+	} else {
+		export, err = repo.Export(ctx, s.head)
+	}
 	if err != nil {
-		return nil, "", ChartUnavailableError{err}
+		return nil, "", "", ChartUnavailableError{Err: err}
+	}
+	gitExportDuration.With(LabelSparse, fmt.Sprint(c.config.GitSparseExport)).Observe(time.Since(start).Seconds())
+
+	// Submodules and LFS objects are not part of the mirror, and are
+	// fetched from the upstream using the credentials of the mirror.
+	source := hr.Spec.GitChartSource
+	if source.Submodules {
+		if err := repo.UpdateSubmodules(ctx, export); err != nil {
+			export.Clean()
+			return nil, "", "", ChartUnavailableError{Err: err}
+		}
+	}
+	if source.LFS {
+		if err := repo.PullLFS(ctx, export, source.Submodules); err != nil {
+			export.Clean()
+			return nil, "", "", ChartUnavailableError{Err: err}
+		}
 	}
 
-	return export, s.head, nil
+	if verify := hr.Spec.ChartSource.Verify; verify != nil {
+		if err := c.verify(ctx, hr.Namespace, verify, export.Dir(), s.head); err != nil {
+			export.Clean()
+			return nil, "", "", CommitVerificationError{err}
+		}
+	}
+
+	return export, s.head, s.tag, nil
+}
+
+// verify verifies the signature of the given revision in the git
+// repository in the given directory against the keyring from the
+// given `v1.KeyringSource`.
+func (c *GitChartSync) verify(ctx context.Context, namespace string, source *v1.KeyringSource, dir, revision string) error {
+	kr, err := getKeyring(ctx, c.coreV1Client, namespace, source)
+	if err != nil {
+		return fmt.Errorf("failed to get keyring: %w", err)
+	}
+	return verifyCommit(ctx, dir, revision, kr)
 }
 
 // Delete cleans up the source reference for the given `v1.HelmRelease`,
@@ -197,13 +255,14 @@ func (c *GitChartSync) Delete(hr *v1.HelmRelease) bool {
 		if hrs, err := c.helmReleasesForMirror(source.mirror); err == nil && len(hrs) == 0 {
 			// The mirror is no longer in use by any source;
 			// stop and delete the mirror.
-			c.mirrors.StopOne(source.mirror)
+			c.stopMirror(source.mirror)
 		}
 	}
 	return ok
 }
 
-// SyncMirror instructs the helmrelease's git mirror to sync from its upstream
+// SyncMirror instructs the helmrelease's git mirror to sync from its
+// upstream. It returns an error if the refresh of the mirror failed.
 func (c *GitChartSync) SyncMirror(hr *v1.HelmRelease) error {
 	mirror := mirrorName(hr)
 	c.logger.Log("info", "starting sync of git mirror", "mirror", mirror)
@@ -212,28 +271,86 @@ func (c *GitChartSync) SyncMirror(hr *v1.HelmRelease) error {
 		return ChartNotReadyError{ErrNoMirror}
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), c.config.GitTimeout)
-	repo.Refresh(ctx)
+	err := repo.Refresh(ctx)
 	cancel()
+	if err != nil {
+		c.logger.Log("error", ErrMirrorSync.Error(), "mirror", mirror, "err", err)
+		return fmt.Errorf("%s: %w", ErrMirrorSync.Error(), err)
+	}
 	c.logger.Log("info", "finished syncing git mirror", "mirror", mirror)
 	return nil
 }
 
 // SyncMirrors instructs all git mirrors to sync from their respective
-// upstreams.
-func (c *GitChartSync) SyncMirrors() {
+// upstreams. It returns an aggregate of the errors that occurred
+// while syncing, or nil.
+func (c *GitChartSync) SyncMirrors() error {
 	c.logger.Log("info", "starting sync of git mirrors")
-	for _, err := range c.mirrors.RefreshAll(c.config.GitTimeout) {
+	errs := c.mirrors.RefreshAll(c.config.GitTimeout)
+	for _, err := range errs {
 		c.logger.Log("error", ErrMirrorSync.Error(), "err", err)
 	}
 	c.logger.Log("info", "finished syncing git mirror")
+	return utilerrors.NewAggregate(errs)
+}
+
+// SyncMirrorsForRemote instructs the git mirrors of which the remote
+// of a recorded source matches any of the given URLs to sync from
+// their upstream. The URLs are expected to be alternative URLs of
+// the same repository (e.g. the HTTPS and SSH URL), and are matched
+// regardless of protocol, credentials and `.git` suffix. It returns
+// ErrNoMirror if no mirror matches, or an aggregate of the errors
+// that occurred while syncing.
+func (c *GitChartSync) SyncMirrorsForRemote(urls ...string) error {
+	remotes := make(map[string]struct{}, len(urls))
+	for _, u := range urls {
+		if r := normalizeRemote(u); r != "" {
+			remotes[r] = struct{}{}
+		}
+	}
+
+	mirrors := make(map[string]struct{})
+	c.releaseSourcesMu.RLock()
+	for _, s := range c.releaseSourcesByID {
+		if _, ok := remotes[normalizeRemote(s.remote)]; ok {
+			mirrors[s.mirror] = struct{}{}
+		}
+	}
+	c.releaseSourcesMu.RUnlock()
+
+	if len(mirrors) == 0 {
+		return ErrNoMirror
+	}
+
+	var errs []error
+	for mirror := range mirrors {
+		repo, ok := c.mirrors.Get(mirror)
+		if !ok {
+			continue
+		}
+		c.logger.Log("info", "starting sync of git mirror", "mirror", mirror)
+		ctx, cancel := context.WithTimeout(context.Background(), c.config.GitTimeout)
+		err := repo.Refresh(ctx)
+		cancel()
+		if err != nil {
+			c.logger.Log("error", ErrMirrorSync.Error(), "mirror", mirror, "err", err)
+			errs = append(errs, err)
+			continue
+		}
+		c.logger.Log("info", "finished syncing git mirror", "mirror", mirror)
+	}
+	return utilerrors.NewAggregate(errs)
 }
 
 // processChangedMirror syncs all given `v1.HelmRelease`s with the
 // mirror we received a change signal for and schedules a release,
-// but only if the sync indicated the change was relevant.
+// but only if the sync indicated the change was relevant. The
+// SourceReady condition of every release is updated with the result.
 func (c *GitChartSync) processChangedMirror(mirror string, repo *git.Repo, hrs []*v1.HelmRelease) {
 	for _, hr := range hrs {
-		if _, ok, _ := c.sync(hr, mirror, repo); ok {
+		s, ok, err := c.sync(hr, mirror, repo)
+		c.setSourceReady(hr, repo, s, err)
+		if ok {
 			cacheKey, err := cache.MetaNamespaceKeyFunc(hr.GetObjectMeta())
 			if err != nil {
 				continue // this should never happen
@@ -265,15 +382,15 @@ func (c *GitChartSync) sync(hr *v1.HelmRelease, mirrorName string, repo *git.Rep
 
 	var changed bool
 	if !ok || !s.forHelmRelease(hr, c.config.GitDefaultRef) {
-		s = sourceRef{mirror: mirrorName, remote: source.GitURL, ref: source.RefOrDefault(c.config.GitDefaultRef)}
+		s = sourceRef{mirror: mirrorName, remote: source.GitURL, ref: gitRefSpec(source, c.config.GitDefaultRef)}
 		changed = true
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), c.config.GitTimeout)
-	head, err := repo.Revision(ctx, s.ref)
+	head, tag, err := resolveGitRef(ctx, repo, source, c.config.GitDefaultRef)
 	cancel()
 	if err != nil {
-		return sourceRef{}, false, ChartUnavailableError{err}
+		return sourceRef{}, false, ChartUnavailableError{Err: err}
 	}
 
 	if !changed {
@@ -285,16 +402,16 @@ func (c *GitChartSync) sync(hr *v1.HelmRelease, mirrorName string, repo *git.Rep
 		// Check if the mirror has seen commits in paths we are interested in for
 		// this release.
 		ctx, cancel = context.WithTimeout(context.Background(), c.config.GitTimeout)
-		commits, err := repo.CommitsBetween(ctx, s.head, head, false, source.Path)
+		changed, err = pathChanged(ctx, repo, s.head, head, source.Path)
 		cancel()
 		if err != nil {
-			return sourceRef{}, false, ChartUnavailableError{err}
+			return sourceRef{}, false, ChartUnavailableError{Err: err}
 		}
-		changed = len(commits) > 0
 	}
 
 	// Update the HEAD reference
 	s.head = head
+	s.tag = tag
 
 	c.releaseSourcesMu.Lock()
 	c.releaseSourcesByID[hr.ResourceID().String()] = s
@@ -308,26 +425,63 @@ func (c *GitChartSync) sync(hr *v1.HelmRelease, mirrorName string, repo *git.Rep
 // `false` otherwise).
 func (c *GitChartSync) maybeMirror(mirrorName string, source *v1.GitChartSource, namespace string) bool {
 	gitURL := source.GitURL
-	var err error
 
 	if source.SecretRef != nil && source.SecretRef.Namespace == "" {
 		source.SecretRef.Namespace = namespace
 	}
 
-	if gitURL, err = c.addAuthForHTTPS(gitURL, source.SecretRef); err != nil {
-		c.logger.Log("error", GitAuthError{err}.Error())
-		return false
+	if _, ok := c.mirrors.Get(mirrorName); ok {
+		return true
 	}
 
-	ok := c.mirrors.Mirror(
-		mirrorName, git.Remote{URL: gitURL}, git.Timeout(c.config.GitTimeout),
-		git.PollInterval(c.config.GitPollInterval), git.ReadOnly)
+	opts := []git.Option{git.Timeout(c.config.GitTimeout), git.PollInterval(c.config.GitPollInterval)}
+	if source.SecretRef != nil && isSSHURL(gitURL) {
+		opts = append(opts, c.sshKeys(*source.SecretRef))
+	} else if source.SecretRef != nil && isHTTPSURL(gitURL) {
+		opts = append(opts, c.httpsCredentials(*source.SecretRef))
+	}
+
+	if c.config.GitShallow {
+		opts = append(opts, git.Shallow)
+	}
+
+	ok := c.mirrors.Mirror(mirrorName, git.Remote{URL: gitURL}, opts...)
 	if !ok {
 		c.logger.Log("info", "started mirroring new remote", "remote", source.GitURL, "mirror", mirrorName)
 	}
 	return ok
 }
 
+// pathChanged returns true if anything under the given path changed
+// between the given revisions. As the history between the revisions
+// is not available in a shallow repo, the trees of the path are
+// compared instead.
+func pathChanged(ctx context.Context, repo *git.Repo, from, to, path string) (bool, error) {
+	if repo.Shallow() {
+		fromTree, err := repo.TreeHash(ctx, from, path)
+		if err != nil {
+			// The previous revision may no longer be available.
+			return true, nil
+		}
+		toTree, err := repo.TreeHash(ctx, to, path)
+		if err != nil {
+			return false, err
+		}
+		return fromTree != toTree, nil
+	}
+	commits, err := repo.CommitsBetween(ctx, from, to, false, path)
+	if err != nil {
+		return false, err
+	}
+	return len(commits) > 0, nil
+}
+
+// stopMirror stops and deletes the mirror with the given name.
+func (c *GitChartSync) stopMirror(mirrorName string) {
+	c.mirrors.StopOne(mirrorName)
+	gitMirrorSize.With(LabelMirror, mirrorName).Set(0)
+}
+
 // helmReleasesForMirror returns a slice of `HelmRelease`s that make
 // use of the given mirror.
 func (c *GitChartSync) helmReleasesForMirror(mirror string) ([]*v1.HelmRelease, error) {
@@ -357,54 +511,55 @@ func mirrorName(hr *v1.HelmRelease) string {
 	return ""
 }
 
-// addAuthForHTTPS will attempt to add basic auth credentials from the
-// given secretRef to the given gitURL and return the result, but only
-// if the scheme of the URL is HTTPS. In case of a failure it returns
-// an error.
-func (c *GitChartSync) addAuthForHTTPS(gitURL string, secretRef *v1.ObjectReference) (string, error) {
-	if secretRef == nil {
-		return gitURL, nil
-	}
-
-	modifiedURL, err := url.Parse(strings.ToLower(gitURL))
-	if err != nil {
-		return "", err
-	}
-
-	if modifiedURL.Scheme != "https" {
-		return gitURL, nil
-	}
+// isHTTPSURL returns true if the scheme of the given Git URL is
+// HTTPS.
+func isHTTPSURL(gitURL string) bool {
+	u, err := url.Parse(gitURL)
+	return err == nil && strings.ToLower(u.Scheme) == "https"
+}
 
-	username, password, err := c.getAuthFromSecret(secretRef)
-	if err != nil {
-		return "", err
+// httpsCredentials returns the `git.Credentials` for the given
+// secretRef. The secret is resolved every time the credentials are
+// requested, so that rotated credentials are picked up by the mirror.
+func (c *GitChartSync) httpsCredentials(secretRef v1.ObjectReference) git.Credentials {
+	return func(ctx context.Context) (string, string, error) {
+		username, password, err := c.getAuthFromSecret(ctx, &secretRef)
+		if err != nil {
+			return "", "", GitAuthError{err}
+		}
+		return username, password, nil
 	}
-
-	modifiedURL.User = url.UserPassword(username, password)
-
-	return modifiedURL.String(), nil
 }
 
 // getAuthFromSecret resolve the given `secretRef` from the given namespace
 // using the core v1 secrets client, and return the username and password.
-// If this errors, or the secret does not contain the expected keys, an
-// error is returned.
-func (c *GitChartSync) getAuthFromSecret(secretRef *v1.ObjectReference) (string, string, error) {
+// If the secret holds the credentials of a GitHub App, an installation
+// token is obtained for it instead. If this errors, or the secret does
+// not contain the expected keys, an error is returned.
+func (c *GitChartSync) getAuthFromSecret(ctx context.Context, secretRef *v1.ObjectReference) (string, string, error) {
 
 	secretName := secretRef.Name
 	ns := secretRef.Namespace
-	secret, err := c.coreV1Client.Secrets(ns).Get(context.Background(), secretName, metav1.GetOptions{})
+	secret, err := c.getSecret(ctx, secretRef)
 	if err != nil {
 		return "", "", err
 	}
 
-	d, ok := secret.Data["username"]
+	if _, ok := secret[githubAppIDKey]; ok {
+		token, err := c.githubAppTokens.get(ctx, secret)
+		if err != nil {
+			return "", "", err
+		}
+		return githubAppUsername, token, nil
+	}
+
+	d, ok := secret["username"]
 	if !ok {
 		return "", "", fmt.Errorf("could not find username key in secret %s/%s", ns, secretName)
 	}
 	username := string(d)
 
-	d, ok = secret.Data["password"]
+	d, ok = secret["password"]
 	if !ok {
 		return "", "", fmt.Errorf("could not find password key in secret %s/%s", ns, secretName)
 	}
@@ -412,3 +567,13 @@ func (c *GitChartSync) getAuthFromSecret(secretRef *v1.ObjectReference) (string,
 
 	return username, password, nil
 }
+
+// getSecret resolves the given `secretRef` using the core v1 secrets
+// client, and returns the data of the secret.
+func (c *GitChartSync) getSecret(ctx context.Context, secretRef *v1.ObjectReference) (map[string][]byte, error) {
+	secret, err := c.coreV1Client.Secrets(secretRef.Namespace).Get(ctx, secretRef.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return secret.Data, nil
+}
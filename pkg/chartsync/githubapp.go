@@ -0,0 +1,184 @@
+package chartsync
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// githubAppIDKey is the key of the ID of a GitHub App in a Git
+	// secret. If present, the secret is expected to hold the
+	// credentials of a GitHub App instead of a username and password.
+	githubAppIDKey = "githubAppID"
+	// githubAppInstallationIDKey is the key of the ID of the
+	// installation of the GitHub App in a Git secret.
+	githubAppInstallationIDKey = "githubAppInstallationID"
+	// githubAppPrivateKeyKey is the key of the PEM encoded private key
+	// of the GitHub App in a Git secret.
+	githubAppPrivateKeyKey = "githubAppPrivateKey"
+	// githubAppBaseURLKey is the key of the (optional) API URL of the
+	// GitHub (Enterprise) instance in a Git secret.
+	githubAppBaseURLKey = "githubAppBaseURL"
+
+	githubAPIURL = "https://api.github.com"
+	// githubAppUsername is the username for Git over HTTPS with an
+	// installation token.
+	githubAppUsername = "x-access-token"
+)
+
+// githubAppTokens obtains installation access tokens for GitHub Apps,
+// and caches them until they are about to expire.
+type githubAppTokens struct {
+	client *http.Client
+	// requests deduplicates concurrent requests for the same token,
+	// so that the cache is not locked while a token is requested.
+	requests singleflight.Group
+
+	mu     sync.Mutex
+	tokens map[string]githubAppToken
+}
+
+type githubAppToken struct {
+	token   string
+	expires time.Time
+}
+
+// githubAppTokenExpiryWindow is the time before the expiry of a token
+// from which a new token is requested.
+const githubAppTokenExpiryWindow = 5 * time.Minute
+
+func newGitHubAppTokens() *githubAppTokens {
+	return &githubAppTokens{
+		client: &http.Client{Timeout: 30 * time.Second},
+		tokens: make(map[string]githubAppToken),
+	}
+}
+
+// get returns an installation access token for the GitHub App in
+// the given secret data.
+func (g *githubAppTokens) get(ctx context.Context, secret map[string][]byte) (string, error) {
+	appID := strings.TrimSpace(string(secret[githubAppIDKey]))
+	installationID := strings.TrimSpace(string(secret[githubAppInstallationIDKey]))
+	if appID == "" || installationID == "" || len(secret[githubAppPrivateKeyKey]) == 0 {
+		return "", fmt.Errorf("secret must contain %s, %s and %s", githubAppIDKey, githubAppInstallationIDKey, githubAppPrivateKeyKey)
+	}
+	baseURL := strings.TrimSuffix(strings.TrimSpace(string(secret[githubAppBaseURLKey])), "/")
+	if baseURL == "" {
+		baseURL = githubAPIURL
+	}
+
+	// The private key is part of the cache key, so a rotated key is
+	// used right away.
+	keyHash := sha256.Sum256(secret[githubAppPrivateKeyKey])
+	cacheKey := fmt.Sprintf("%s/%s/%s/%x", baseURL, appID, installationID, keyHash)
+
+	if token, ok := g.cached(cacheKey, time.Now()); ok {
+		return token, nil
+	}
+	v, err, _ := g.requests.Do(cacheKey, func() (interface{}, error) {
+		key, err := jwt.ParseRSAPrivateKeyFromPEM(secret[githubAppPrivateKeyKey])
+		if err != nil {
+			return "", fmt.Errorf("failed to parse private key: %w", err)
+		}
+		signed, err := githubAppJWT(appID, key, time.Now())
+		if err != nil {
+			return "", err
+		}
+		t, err := g.createInstallationToken(ctx, baseURL, installationID, signed)
+		if err != nil {
+			return "", fmt.Errorf("failed to create installation token for GitHub App %s: %w", appID, err)
+		}
+		g.store(cacheKey, t, time.Now())
+		return t.token, nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return v.(string), nil
+}
+
+// cached returns the cached token for the given key, if it does not
+// expire within the expiry window.
+func (g *githubAppTokens) cached(cacheKey string, now time.Time) (string, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if t, ok := g.tokens[cacheKey]; ok && now.Add(githubAppTokenExpiryWindow).Before(t.expires) {
+		return t.token, true
+	}
+	return "", false
+}
+
+// store caches the given token under the given key, and removes the
+// tokens that expire within the expiry window, e.g. those of deleted
+// secrets or rotated keys.
+func (g *githubAppTokens) store(cacheKey string, t githubAppToken, now time.Time) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for k, cached := range g.tokens {
+		if !now.Add(githubAppTokenExpiryWindow).Before(cached.expires) {
+			delete(g.tokens, k)
+		}
+	}
+	g.tokens[cacheKey] = t
+}
+
+// createInstallationToken exchanges the given JWT for an access
+// token of the given installation.
+func (g *githubAppTokens) createInstallationToken(ctx context.Context, baseURL, installationID, jwt string) (githubAppToken, error) {
+	u := fmt.Sprintf("%s/app/installations/%s/access_tokens", baseURL, installationID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, nil)
+	if err != nil {
+		return githubAppToken{}, err
+	}
+	req.Header.Set("Authorization", "Bearer "+jwt)
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return githubAppToken{}, err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return githubAppToken{}, err
+	}
+	if resp.StatusCode != http.StatusCreated {
+		return githubAppToken{}, fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var out struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+	if err := json.Unmarshal(body, &out); err != nil {
+		return githubAppToken{}, err
+	}
+	if out.Token == "" {
+		return githubAppToken{}, errors.New("response does not contain a token")
+	}
+	return githubAppToken{token: out.Token, expires: out.ExpiresAt}, nil
+}
+
+// githubAppJWT returns a JWT to authenticate as the GitHub App with
+// the given ID, signed with the given private key.
+func githubAppJWT(appID string, key *rsa.PrivateKey, now time.Time) (string, error) {
+	// The issued at time is set in the past to allow for clock drift,
+	// the expiration time may be at most ten minutes in the future.
+	return jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.StandardClaims{
+		IssuedAt:  now.Add(-time.Minute).Unix(),
+		ExpiresAt: now.Add(9 * time.Minute).Unix(),
+		Issuer:    appID,
+	}).SignedString(key)
+}
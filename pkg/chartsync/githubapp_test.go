@@ -0,0 +1,136 @@
+package chartsync
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitHubAppTokens(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "/app/installations/42/access_tokens", r.URL.Path)
+
+		// Verify the JWT was signed with the private key of the app.
+		parts := strings.Split(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "), ".")
+		require.Len(t, parts, 3)
+		sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+		require.NoError(t, err)
+		hash := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+		assert.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, hash[:], sig))
+		claims, err := base64.RawURLEncoding.DecodeString(parts[1])
+		require.NoError(t, err)
+		assert.Contains(t, string(claims), `"iss":"1234"`)
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"token":      "ghs_token",
+			"expires_at": time.Now().Add(time.Hour),
+		})
+	}))
+	defer srv.Close()
+
+	secret := map[string][]byte{
+		githubAppIDKey:             []byte("1234"),
+		githubAppInstallationIDKey: []byte("42"),
+		githubAppPrivateKeyKey:     keyPEM,
+		githubAppBaseURLKey:        []byte(srv.URL),
+	}
+	g := newGitHubAppTokens()
+	for i := 0; i < 2; i++ {
+		token, err := g.get(context.Background(), secret)
+		require.NoError(t, err)
+		assert.Equal(t, "ghs_token", token)
+	}
+	assert.Equal(t, 1, requests, "expected token to be cached")
+
+	delete(secret, githubAppInstallationIDKey)
+	_, err = g.get(context.Background(), secret)
+	assert.Error(t, err)
+}
+
+func TestGitHubAppTokens_Concurrent(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	var requests int32
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		<-release
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"token":      "ghs_" + strings.Split(r.URL.Path, "/")[3],
+			"expires_at": time.Now().Add(time.Hour),
+		})
+	}))
+	defer srv.Close()
+
+	secret := func(installationID string) map[string][]byte {
+		return map[string][]byte{
+			githubAppIDKey:             []byte("1234"),
+			githubAppInstallationIDKey: []byte(installationID),
+			githubAppPrivateKeyKey:     keyPEM,
+			githubAppBaseURLKey:        []byte(srv.URL),
+		}
+	}
+	g := newGitHubAppTokens()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			token, err := g.get(context.Background(), secret("42"))
+			assert.NoError(t, err)
+			assert.Equal(t, "ghs_42", token)
+		}()
+	}
+
+	// A token for another installation is not blocked by the pending
+	// request.
+	require.Eventually(t, func() bool { return atomic.LoadInt32(&requests) == 1 }, time.Second, 10*time.Millisecond)
+	g.mu.Lock()
+	g.tokens["expired"] = githubAppToken{token: "ghs_expired", expires: time.Now()}
+	g.mu.Unlock()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		token, err := g.get(context.Background(), secret("43"))
+		assert.NoError(t, err)
+		assert.Equal(t, "ghs_43", token)
+	}()
+	require.Eventually(t, func() bool { return atomic.LoadInt32(&requests) == 2 }, time.Second, 10*time.Millisecond)
+
+	close(release)
+	wg.Wait()
+	<-done
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests), "expected concurrent requests for a token to be deduplicated")
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	assert.NotContains(t, g.tokens, "expired", "expected expired tokens to be pruned")
+	assert.Len(t, g.tokens, 2)
+}
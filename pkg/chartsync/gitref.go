@@ -0,0 +1,106 @@
+package chartsync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+
+	v1 "github.com/fluxcd/helm-operator/pkg/apis/helm.fluxcd.io/v1"
+	"github.com/fluxcd/helm-operator/pkg/git"
+)
+
+// gitRefSpec returns a string describing the reference the given
+// `v1.GitChartSource` should be resolved to, so that changes to it
+// can be detected. A commit takes precedence over a semver range,
+// which takes precedence over a tag, which takes precedence over the
+// ref (or the given default ref).
+func gitRefSpec(source *v1.GitChartSource, defaultRef string) string {
+	switch {
+	case source.Commit != "":
+		return "commit:" + source.Commit
+	case source.SemVer != "":
+		return "semver:" + source.TagPrefix + ":" + source.SemVer
+	case source.Tag != "":
+		return "tag:" + source.Tag
+	}
+	return source.RefOrDefault(defaultRef)
+}
+
+// ErrShallowRef is returned when a `v1.GitChartSource` refers to a
+// commit, tag or semver range while the mirror is shallow. The
+// history such a ref may point into is not fetched into a shallow
+// mirror, so it can not be resolved reliably.
+var ErrShallowRef = errors.New("commit, tag and semver refs are not supported with shallow Git mirrors (--git-shallow), use a branch ref or disable shallow mirrors")
+
+// resolveGitRef resolves the given `v1.GitChartSource` in the given
+// repo. It returns the commit, and the tag if the source refers to a
+// tag or semver range, or an error.
+func resolveGitRef(ctx context.Context, repo *git.Repo, source *v1.GitChartSource, defaultRef string) (string, string, error) {
+	if repo.Shallow() && (source.Commit != "" || source.SemVer != "" || source.Tag != "") {
+		return "", "", ErrShallowRef
+	}
+
+	var ref, tag string
+	switch {
+	case source.Commit != "":
+		ref = source.Commit
+	case source.SemVer != "":
+		var err error
+		if tag, err = latestTagInRange(ctx, repo, source.TagPrefix, source.SemVer); err != nil {
+			return "", "", err
+		}
+		ref = "refs/tags/" + tag
+	case source.Tag != "":
+		tag = source.Tag
+		ref = "refs/tags/" + tag
+	default:
+		ref = source.RefOrDefault(defaultRef)
+	}
+
+	commit, err := repo.Revision(ctx, ref)
+	if err != nil {
+		return "", "", err
+	}
+	if source.Commit != "" && !strings.HasPrefix(commit, strings.ToLower(source.Commit)) {
+		// A ref with the name of the commit took precedence.
+		return "", "", fmt.Errorf("commit %s not found", source.Commit)
+	}
+	return commit, tag, nil
+}
+
+// latestTagInRange returns the tag in the given repo with the highest
+// version within the given semver range, after stripping the given
+// prefix from the tags. Tags without the prefix, or which are not a
+// valid semver version, are ignored.
+func latestTagInRange(ctx context.Context, repo *git.Repo, prefix, semverRange string) (string, error) {
+	constraint, err := semver.NewConstraint(semverRange)
+	if err != nil {
+		return "", fmt.Errorf("invalid semver range %q: %w", semverRange, err)
+	}
+	tags, err := repo.Tags(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	var latest *semver.Version
+	var latestTag string
+	for _, t := range tags {
+		if !strings.HasPrefix(t, prefix) {
+			continue
+		}
+		v, err := semver.NewVersion(strings.TrimPrefix(t, prefix))
+		if err != nil || !constraint.Check(v) {
+			continue
+		}
+		if latest == nil || v.GreaterThan(latest) {
+			latest, latestTag = v, t
+		}
+	}
+	if latestTag == "" {
+		return "", fmt.Errorf("no tag with prefix %q matches semver range %q", prefix, semverRange)
+	}
+	return latestTag, nil
+}
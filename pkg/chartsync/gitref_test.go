@@ -0,0 +1,110 @@
+package chartsync
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v1 "github.com/fluxcd/helm-operator/pkg/apis/helm.fluxcd.io/v1"
+	"github.com/fluxcd/helm-operator/pkg/git"
+)
+
+func TestResolveGitRef(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gitref-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	run := func(args ...string) string {
+		out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput()
+		require.NoError(t, err, string(out))
+		return strings.TrimSpace(string(out))
+	}
+	run("init", "-q", "-b", "master")
+	run("config", "user.email", "example@example.com")
+	run("config", "user.name", "example")
+	commits := make(map[string]string)
+	for _, tag := range []string{"podinfo-1.0.0", "podinfo-1.1.0", "other-1.2.0", "podinfo-2.0.0", "unreleased"} {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "file"), []byte(tag), 0600))
+		run("add", "file")
+		run("commit", "-q", "-m", tag)
+		run("tag", tag)
+		commits[tag] = run("rev-parse", "HEAD")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	repo := git.NewRepo(git.Remote{URL: dir})
+	require.NoError(t, repo.Ready(ctx))
+	defer repo.Clean()
+
+	tests := []struct {
+		name       string
+		source     v1.GitChartSource
+		wantCommit string
+		wantTag    string
+		wantErr    bool
+	}{
+		{"default ref", v1.GitChartSource{}, commits["unreleased"], "", false},
+		{"tag", v1.GitChartSource{Tag: "podinfo-1.0.0"}, commits["podinfo-1.0.0"], "podinfo-1.0.0", false},
+		{"semver", v1.GitChartSource{SemVer: "<2.0.0", TagPrefix: "podinfo-"}, commits["podinfo-1.1.0"], "podinfo-1.1.0", false},
+		{"semver precedence", v1.GitChartSource{SemVer: ">=1.0.0", TagPrefix: "podinfo-", Tag: "podinfo-1.0.0"}, commits["podinfo-2.0.0"], "podinfo-2.0.0", false},
+		{"semver without match", v1.GitChartSource{SemVer: ">=3.0.0", TagPrefix: "podinfo-"}, "", "", true},
+		{"commit", v1.GitChartSource{Commit: commits["other-1.2.0"][:12], Tag: "podinfo-1.0.0"}, commits["other-1.2.0"], "", false},
+		{"unknown commit", v1.GitChartSource{Commit: "0123456789abcdef"}, "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commit, tag, err := resolveGitRef(ctx, repo, &tt.source, "master")
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantCommit, commit)
+			assert.Equal(t, tt.wantTag, tag)
+		})
+	}
+}
+
+func TestResolveGitRef_Shallow(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gitref-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	for _, args := range [][]string{
+		{"init", "-q", "-b", "master"},
+		{"config", "user.email", "example@example.com"},
+		{"config", "user.name", "example"},
+		{"commit", "-q", "--allow-empty", "-m", "initial"},
+		{"tag", "podinfo-1.0.0"},
+	} {
+		out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	repo := git.NewRepo(git.Remote{URL: "file://" + dir}, git.Shallow)
+	require.NoError(t, repo.Ready(ctx))
+	defer repo.Clean()
+
+	_, _, err = resolveGitRef(ctx, repo, &v1.GitChartSource{}, "master")
+	assert.NoError(t, err)
+	for _, source := range []v1.GitChartSource{
+		{Commit: "0123456789abcdef"},
+		{Tag: "podinfo-1.0.0"},
+		{SemVer: ">=1.0.0", TagPrefix: "podinfo-"},
+	} {
+		_, _, err = resolveGitRef(ctx, repo, &source, "master")
+		assert.True(t, errors.Is(err, ErrShallowRef), "expected %v to be rejected", source)
+	}
+}
@@ -0,0 +1,67 @@
+package chartsync
+
+import (
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	cacheHits = prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Namespace: "flux",
+		Subsystem: "helm_operator",
+		Name:      "chart_cache_hits_total",
+		Help:      "Count of charts served from the chart cache.",
+	}, []string{})
+	cacheMisses = prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Namespace: "flux",
+		Subsystem: "helm_operator",
+		Name:      "chart_cache_misses_total",
+		Help:      "Count of charts that had to be downloaded because they were not in the chart cache.",
+	}, []string{})
+	cacheEvictions = prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Namespace: "flux",
+		Subsystem: "helm_operator",
+		Name:      "chart_cache_evictions_total",
+		Help:      "Count of charts evicted from the chart cache.",
+	}, []string{})
+	cacheSize = prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+		Namespace: "flux",
+		Subsystem: "helm_operator",
+		Name:      "chart_cache_size_bytes",
+		Help:      "Total size of the charts in the chart cache.",
+	}, []string{})
+)
+
+const (
+	LabelMirror     = "mirror"
+	LabelSparse     = "sparse"
+	LabelRepository = "repository"
+)
+
+var (
+	gitMirrorSize = prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+		Namespace: "flux",
+		Subsystem: "helm_operator",
+		Name:      "git_mirror_size_bytes",
+		Help:      "Disk usage of the Git mirror of chart sources.",
+	}, []string{LabelMirror})
+	gitExportDuration = prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+		Namespace: "flux",
+		Subsystem: "helm_operator",
+		Name:      "git_export_duration_seconds",
+		Help:      "Duration of exporting a Git chart source from its mirror in seconds.",
+		Buckets:   []float64{0.1, 0.5, 1, 5, 10, 30, 60, 120},
+	}, []string{LabelSparse})
+	chartDownloadBytes = prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Namespace: "flux",
+		Subsystem: "helm_operator",
+		Name:      "chart_download_bytes_total",
+		Help:      "Total size of the charts downloaded from a Helm repository.",
+	}, []string{LabelRepository})
+	chartDownloadFailures = prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Namespace: "flux",
+		Subsystem: "helm_operator",
+		Name:      "chart_download_failures_total",
+		Help:      "Count of failed chart downloads from a Helm repository.",
+	}, []string{LabelRepository})
+)
@@ -0,0 +1,185 @@
+package chartsync
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+
+	helmfluxv1 "github.com/fluxcd/helm-operator/pkg/apis/helm.fluxcd.io/v1"
+)
+
+// objectPathSeparator is used in place of the path separator in the
+// keys of an object holding an unpacked chart.
+const objectPathSeparator = "__"
+
+// chartRootFiles are the files at the root of an unpacked chart that
+// are part of the chart, all other chart files are in a directory.
+var chartRootFiles = map[string]bool{
+	"Chart.yaml":         true,
+	"Chart.lock":         true,
+	"values.yaml":        true,
+	"values.schema.json": true,
+	"requirements.yaml":  true,
+	"requirements.lock":  true,
+	".helmignore":        true,
+}
+
+// ExportObjectChart writes the chart held by the ConfigMap or Secret
+// the given source refers to in the given namespace to a new
+// temporary directory. It returns the path to the chart, the revision
+// of the chart (the SHA-256 digest of the chart data of the object,
+// see `chartFiles`), a function to remove the temporary directory,
+// and either an error or nil.
+func ExportObjectChart(coreV1Client corev1client.CoreV1Interface, namespace string,
+	source *helmfluxv1.ObjectChartSource) (string, string, func() error, error) {
+
+	data, err := getObjectData(coreV1Client, namespace, source)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// The object may be created at a later stage, e.g. by
+			// bootstrap tooling.
+			return "", "", nil, ChartNotReadyError{err}
+		}
+		return "", "", nil, ChartUnavailableError{Err: err}
+	}
+	if source.Key != "" {
+		b, ok := data[source.Key]
+		if !ok {
+			return "", "", nil, ChartUnavailableError{Err: fmt.Errorf("%s %s/%s does not contain key %q",
+				source.Kind, namespace, source.Name, source.Key)}
+		}
+		data = map[string][]byte{source.Key: b}
+	} else {
+		data = chartFiles(data)
+		if _, ok := data["Chart.yaml"]; !ok {
+			return "", "", nil, ChartUnavailableError{Err: fmt.Errorf("%s %s/%s does not contain a Chart.yaml",
+				source.Kind, namespace, source.Name)}
+		}
+	}
+
+	dir, err := ioutil.TempDir("", "chart-object-")
+	if err != nil {
+		return "", "", nil, ChartUnavailableError{Err: err}
+	}
+	clean := func() error { return os.RemoveAll(dir) }
+
+	chartPath := filepath.Join(dir, "chart")
+	if source.Key != "" {
+		chartPath += ".tgz"
+		err = ioutil.WriteFile(chartPath, data[source.Key], 0600)
+	} else {
+		err = writeChartFiles(chartPath, data)
+	}
+	if err != nil {
+		clean()
+		return "", "", nil, ChartUnavailableError{Err: err}
+	}
+	return chartPath, objectRevision(data), clean, nil
+}
+
+// getObjectData returns the (binary) data of the object the given
+// source refers to in the given namespace.
+func getObjectData(coreV1Client corev1client.CoreV1Interface, namespace string,
+	source *helmfluxv1.ObjectChartSource) (map[string][]byte, error) {
+
+	data := make(map[string][]byte)
+	switch source.Kind {
+	case "ConfigMap":
+		cm, err := coreV1Client.ConfigMaps(namespace).Get(context.Background(), source.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range cm.Data {
+			data[k] = []byte(v)
+		}
+		for k, v := range cm.BinaryData {
+			data[k] = v
+		}
+	case "Secret":
+		secret, err := coreV1Client.Secrets(namespace).Get(context.Background(), source.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range secret.Data {
+			data[k] = v
+		}
+	default:
+		return nil, fmt.Errorf("unsupported object kind %q", source.Kind)
+	}
+	return data, nil
+}
+
+// chartFiles returns the data of the given object holding an unpacked
+// chart without the keys that are not chart files: keys for files at
+// the root of the chart other than the chart metadata, values, README
+// and LICENSE files. Other data in the object, e.g. added by tooling,
+// is neither written nor part of the revision of the chart.
+func chartFiles(data map[string][]byte) map[string][]byte {
+	files := make(map[string][]byte, len(data))
+	for key, b := range data {
+		if strings.Contains(key, objectPathSeparator) || chartRootFiles[key] ||
+			strings.HasPrefix(key, "README") || strings.HasPrefix(key, "LICENSE") {
+			files[key] = b
+		}
+	}
+	return files
+}
+
+// writeChartFiles writes the files of an unpacked chart to the given
+// directory, translating the keys to paths.
+func writeChartFiles(dir string, data map[string][]byte) error {
+	for key, b := range data {
+		path, err := objectKeyPath(key)
+		if err != nil {
+			return err
+		}
+		path = filepath.Join(dir, path)
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(path, b, 0600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// objectKeyPath returns the relative path for the given key of an
+// object holding an unpacked chart. Keys that would result in a path
+// outside of the chart are rejected.
+func objectKeyPath(key string) (string, error) {
+	segments := strings.Split(key, objectPathSeparator)
+	for _, s := range segments {
+		if s == "" || s == "." || s == ".." {
+			return "", fmt.Errorf("invalid chart file key %q", key)
+		}
+	}
+	return filepath.Join(segments...), nil
+}
+
+// objectRevision returns the SHA-256 digest of the given data, which
+// changes whenever any of the keys or values changes.
+func objectRevision(data map[string][]byte) string {
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	h := sha256.New()
+	for _, k := range keys {
+		fmt.Fprintf(h, "%s\x00%d\x00", k, len(data[k]))
+		h.Write(data[k])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
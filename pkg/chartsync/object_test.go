@@ -0,0 +1,85 @@
+package chartsync
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	helmfluxv1 "github.com/fluxcd/helm-operator/pkg/apis/helm.fluxcd.io/v1"
+)
+
+func TestExportObjectChart(t *testing.T) {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "glue", Namespace: "default"},
+		Data: map[string]string{
+			"Chart.yaml":                "name: glue\nversion: 0.1.0\n",
+			"templates__configmap.yaml": "kind: ConfigMap\n",
+		},
+	}
+	client := fake.NewSimpleClientset(cm)
+	source := &helmfluxv1.ObjectChartSource{Kind: "ConfigMap", Name: "glue"}
+
+	chartPath, revision, clean, err := ExportObjectChart(client.CoreV1(), "default", source)
+	require.NoError(t, err)
+	defer clean()
+
+	b, err := ioutil.ReadFile(filepath.Join(chartPath, "templates", "configmap.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "kind: ConfigMap\n", string(b))
+
+	_, sameRevision, clean2, err := ExportObjectChart(client.CoreV1(), "default", source)
+	require.NoError(t, err)
+	clean2()
+	assert.Equal(t, revision, sameRevision)
+
+	// Data that is not part of the chart does not change the revision.
+	cm.Data["kustomize-hash"] = "abc"
+	_, err = client.CoreV1().ConfigMaps("default").Update(context.Background(), cm, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	otherPath, sameRevision, clean3, err := ExportObjectChart(client.CoreV1(), "default", source)
+	require.NoError(t, err)
+	defer clean3()
+	assert.Equal(t, revision, sameRevision)
+	_, err = os.Stat(filepath.Join(otherPath, "kustomize-hash"))
+	assert.True(t, os.IsNotExist(err))
+
+	require.NoError(t, clean())
+	_, err = os.Stat(chartPath)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestExportObjectChart_Errors(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "glue", Namespace: "default"},
+		Data: map[string][]byte{
+			"Chart.yaml":      []byte("name: glue\nversion: 0.1.0\n"),
+			"..__etc__passwd": []byte("root"),
+		},
+	}
+	noChart := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "no-chart", Namespace: "default"},
+		Data:       map[string][]byte{"templates__configmap.yaml": []byte("kind: ConfigMap\n")},
+	}
+	client := fake.NewSimpleClientset(secret, noChart)
+
+	_, _, _, err := ExportObjectChart(client.CoreV1(), "default", &helmfluxv1.ObjectChartSource{Kind: "Secret", Name: "glue"})
+	assert.True(t, errors.As(err, &ChartUnavailableError{}))
+
+	_, _, _, err = ExportObjectChart(client.CoreV1(), "default", &helmfluxv1.ObjectChartSource{Kind: "Secret", Name: "no-chart"})
+	assert.True(t, errors.As(err, &ChartUnavailableError{}))
+
+	_, _, _, err = ExportObjectChart(client.CoreV1(), "default", &helmfluxv1.ObjectChartSource{Kind: "Secret", Name: "glue", Key: "chart.tgz"})
+	assert.True(t, errors.As(err, &ChartUnavailableError{}))
+
+	_, _, _, err = ExportObjectChart(client.CoreV1(), "default", &helmfluxv1.ObjectChartSource{Kind: "Secret", Name: "missing"})
+	assert.True(t, errors.As(err, &ChartNotReadyError{}))
+}
@@ -0,0 +1,60 @@
+package chartsync
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	v1 "github.com/fluxcd/helm-operator/pkg/apis/helm.fluxcd.io/v1"
+	"github.com/fluxcd/helm-operator/pkg/git"
+)
+
+const (
+	// sshIdentityKey is the key of the SSH private key in a Git
+	// secret.
+	sshIdentityKey = "identity"
+	// sshKnownHostsKey is the key of the (optional) known hosts in a
+	// Git secret.
+	sshKnownHostsKey = "known_hosts"
+)
+
+// isSSHURL returns true if the given Git URL makes use of SSH, i.e.
+// it has an `ssh://` scheme or is in the scp-like form of
+// `[user@]host:path`. Like Git, a URL without a scheme is only taken
+// to be scp-like if there is no slash before the first colon, so that
+// local paths containing a colon are not mistaken for it.
+func isSSHURL(gitURL string) bool {
+	if i := strings.Index(gitURL, "://"); i >= 0 {
+		scheme := strings.ToLower(gitURL[:i])
+		return scheme == "ssh" || scheme == "git+ssh" || scheme == "ssh+git"
+	}
+	colon := strings.Index(gitURL, ":")
+	if colon < 0 {
+		return false
+	}
+	if slash := strings.Index(gitURL, "/"); slash >= 0 && slash < colon {
+		return false
+	}
+	host := gitURL[:colon]
+	if at := strings.LastIndex(host, "@"); at >= 0 {
+		host = host[at+1:]
+	}
+	return host != ""
+}
+
+// sshKeys returns the `git.SSHKeys` for the given secretRef. The
+// secret is resolved every time the keys are requested, so that
+// rotated keys are picked up by the mirror.
+func (c *GitChartSync) sshKeys(secretRef v1.ObjectReference) git.SSHKeys {
+	return func(ctx context.Context) ([]byte, []byte, error) {
+		secret, err := c.getSecret(ctx, &secretRef)
+		if err != nil {
+			return nil, nil, GitAuthError{err}
+		}
+		identity, ok := secret[sshIdentityKey]
+		if !ok {
+			return nil, nil, GitAuthError{fmt.Errorf("could not find %s key in secret %s/%s", sshIdentityKey, secretRef.Namespace, secretRef.Name)}
+		}
+		return identity, secret[sshKnownHostsKey], nil
+	}
+}
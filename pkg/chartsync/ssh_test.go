@@ -0,0 +1,61 @@
+package chartsync
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	v1 "github.com/fluxcd/helm-operator/pkg/apis/helm.fluxcd.io/v1"
+)
+
+func TestIsSSHURL(t *testing.T) {
+	for u, expect := range map[string]bool{
+		"git@github.com:org/repo":                 true,
+		"github.com:org/repo":                     true,
+		"ssh://git@example.com:2222/org/repo.git": true,
+		"git+ssh://example.com/org/repo":          true,
+		"https://github.com/org/repo":             false,
+		"http://example.com:8080/org/repo":        false,
+		"/local/repo":                             false,
+		"./local/repo:with-colon":                 false,
+		"/local/repo:with-colon":                  false,
+		":org/repo":                               false,
+		"git@:org/repo":                           false,
+	} {
+		assert.Equal(t, expect, isSSHURL(u), u)
+	}
+}
+
+func TestSSHKeys(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "deploy-key", Namespace: "team"},
+		Data: map[string][]byte{
+			sshIdentityKey:   []byte("private key"),
+			sshKnownHostsKey: []byte("github.com ssh-ed25519 AAAA"),
+		},
+	}
+	client := fake.NewSimpleClientset(secret)
+	c := &GitChartSync{coreV1Client: client.CoreV1()}
+	keys := c.sshKeys(v1.ObjectReference{LocalObjectReference: v1.LocalObjectReference{Name: "deploy-key"}, Namespace: "team"})
+
+	identity, knownHosts, err := keys(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "private key", string(identity))
+	assert.Equal(t, "github.com ssh-ed25519 AAAA", string(knownHosts))
+
+	// A rotated key is picked up on the next fetch.
+	secret.Data[sshIdentityKey] = []byte("rotated key")
+	_, err = client.CoreV1().Secrets("team").Update(context.Background(), secret, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	identity, _, err = keys(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "rotated key", string(identity))
+
+	_, _, err = c.sshKeys(v1.ObjectReference{LocalObjectReference: v1.LocalObjectReference{Name: "missing"}, Namespace: "team"})(context.Background())
+	assert.Error(t, err)
+}
@@ -0,0 +1,268 @@
+package chartsync
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"hash"
+	"os"
+	"os/exec"
+	"strings"
+
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/ssh"
+	"helm.sh/helm/v3/pkg/provenance"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+
+	v1 "github.com/fluxcd/helm-operator/pkg/apis/helm.fluxcd.io/v1"
+)
+
+// Various verification errors.
+var (
+	ErrNoSignature     = errors.New("commit is not signed")
+	ErrUnknownSigner   = errors.New("signature is not made by a key in the keyring")
+	ErrEmptyKeyring    = errors.New("keyring does not contain any public keys")
+	ErrInvalidKeyring  = errors.New("keyring source must refer to either a Secret or a ConfigMap")
+	ErrInvalidSSHSig   = errors.New("invalid SSH signature")
+	ErrSSHSigNamespace = errors.New("SSH signature is not made for git")
+	ErrNoProvenance    = errors.New("chart has no provenance file")
+	ErrNoGPGKeys       = errors.New("keyring does not contain any GPG public keys")
+)
+
+const (
+	pgpSignatureHeader = "-----BEGIN PGP SIGNATURE-----"
+	sshSignatureHeader = "-----BEGIN SSH SIGNATURE-----"
+	pgpPublicKeyHeader = "-----BEGIN PGP PUBLIC KEY BLOCK-----"
+
+	// sshSigMagic is the preamble of SSH signatures, see
+	// https://github.com/openssh/openssh-portable/blob/master/PROTOCOL.sshsig
+	sshSigMagic = "SSHSIG"
+	// sshSigNamespace is the namespace git signs commits in.
+	sshSigNamespace = "git"
+)
+
+// keyring holds the public keys signatures are verified against.
+type keyring struct {
+	pgp openpgp.EntityList
+	ssh []ssh.PublicKey
+}
+
+// getKeyring resolves the given `v1.KeyringSource` in the given
+// namespace using the core v1 client, and returns the keyring it
+// holds.
+func getKeyring(ctx context.Context, client corev1client.CoreV1Interface, namespace string, source *v1.KeyringSource) (*keyring, error) {
+	data := make(map[string][]byte)
+	switch {
+	case source.SecretRef != nil && source.ConfigMapRef == nil:
+		secret, err := client.Secrets(namespace).Get(ctx, source.SecretRef.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		data = secret.Data
+	case source.ConfigMapRef != nil && source.SecretRef == nil:
+		cm, err := client.ConfigMaps(namespace).Get(ctx, source.ConfigMapRef.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range cm.Data {
+			data[k] = []byte(v)
+		}
+		for k, v := range cm.BinaryData {
+			data[k] = v
+		}
+	default:
+		return nil, ErrInvalidKeyring
+	}
+	return parseKeyring(data)
+}
+
+// parseKeyring parses the ASCII armored GPG public keys and SSH
+// public keys in the given data.
+func parseKeyring(data map[string][]byte) (*keyring, error) {
+	kr := &keyring{}
+	for name, d := range data {
+		if bytes.Contains(d, []byte(pgpPublicKeyHeader)) {
+			entities, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(d))
+			if err != nil {
+				return nil, fmt.Errorf("failed to read GPG keys from %q: %w", name, err)
+			}
+			kr.pgp = append(kr.pgp, entities...)
+			continue
+		}
+		for rest := d; len(bytes.TrimSpace(rest)) > 0; {
+			key, _, _, r, err := ssh.ParseAuthorizedKey(rest)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read SSH keys from %q: %w", name, err)
+			}
+			kr.ssh = append(kr.ssh, key)
+			rest = r
+		}
+	}
+	if len(kr.pgp) == 0 && len(kr.ssh) == 0 {
+		return nil, ErrEmptyKeyring
+	}
+	return kr, nil
+}
+
+// verifyCommit verifies the signature of the given revision in the
+// git repository in the given directory against the keyring.
+func verifyCommit(ctx context.Context, dir, revision string, kr *keyring) error {
+	cmd := exec.CommandContext(ctx, "git", "cat-file", "commit", revision)
+	cmd.Dir = dir
+	stderr := &bytes.Buffer{}
+	cmd.Stderr = stderr
+	commit, err := cmd.Output()
+	if err != nil {
+		return fmt.Errorf("failed to read commit %s: %s", revision, strings.TrimSpace(stderr.String()))
+	}
+	return verifyCommitObject(commit, kr)
+}
+
+// verifyCommitObject verifies the signature of the given raw git
+// commit object against the keyring.
+func verifyCommitObject(commit []byte, kr *keyring) error {
+	payload, signature := splitCommitSignature(commit)
+	switch {
+	case len(signature) == 0:
+		return ErrNoSignature
+	case bytes.HasPrefix(signature, []byte(pgpSignatureHeader)):
+		if len(kr.pgp) == 0 {
+			return ErrUnknownSigner
+		}
+		if _, err := openpgp.CheckArmoredDetachedSignature(kr.pgp, bytes.NewReader(payload), bytes.NewReader(signature)); err != nil {
+			return fmt.Errorf("%w: %v", ErrUnknownSigner, err)
+		}
+		return nil
+	case bytes.HasPrefix(signature, []byte(sshSignatureHeader)):
+		return verifySSHSignature(signature, payload, kr.ssh)
+	default:
+		return fmt.Errorf("unsupported signature format")
+	}
+}
+
+// verifyChart verifies the chart at the given path against its
+// provenance file, using the GPG keys from the keyring the given
+// `v1.KeyringSource` refers to in the given namespace.
+func verifyChart(client corev1client.CoreV1Interface, namespace, chartPath string, source *v1.KeyringSource) error {
+	kr, err := getKeyring(context.Background(), client, namespace, source)
+	if err != nil {
+		return err
+	}
+	if len(kr.pgp) == 0 {
+		return ErrNoGPGKeys
+	}
+	provPath := chartPath + ".prov"
+	if _, err := os.Stat(provPath); os.IsNotExist(err) {
+		return ErrNoProvenance
+	}
+	signatory := &provenance.Signatory{KeyRing: kr.pgp}
+	if _, err := signatory.Verify(chartPath, provPath); err != nil {
+		return fmt.Errorf("failed to verify provenance: %w", err)
+	}
+	return nil
+}
+
+// splitCommitSignature splits the given raw git commit object into
+// the signed payload (the object without the signature header), and
+// the signature.
+func splitCommitSignature(commit []byte) ([]byte, []byte) {
+	var payload, signature bytes.Buffer
+	lines := bytes.SplitAfter(commit, []byte("\n"))
+	inHeaders, inSignature := true, false
+	for _, line := range lines {
+		if !inHeaders {
+			payload.Write(line)
+			continue
+		}
+		switch {
+		case len(bytes.TrimRight(line, "\n")) == 0:
+			inHeaders, inSignature = false, false
+			payload.Write(line)
+		case inSignature && bytes.HasPrefix(line, []byte(" ")):
+			signature.Write(line[1:])
+		case bytes.HasPrefix(line, []byte("gpgsig ")), bytes.HasPrefix(line, []byte("gpgsig-sha256 ")):
+			inSignature = true
+			// only use the first signature, but strip all signature
+			// headers from the payload
+			if signature.Len() == 0 {
+				signature.Write(line[bytes.IndexByte(line, ' ')+1:])
+			}
+		default:
+			inSignature = false
+			payload.Write(line)
+		}
+	}
+	return payload.Bytes(), signature.Bytes()
+}
+
+// verifySSHSignature verifies the given armored SSH signature of the
+// message was made in the git namespace by one of the given keys.
+func verifySSHSignature(armored, message []byte, keys []ssh.PublicKey) error {
+	block, _ := pem.Decode(armored)
+	if block == nil || block.Type != "SSH SIGNATURE" || !bytes.HasPrefix(block.Bytes, []byte(sshSigMagic)) {
+		return ErrInvalidSSHSig
+	}
+	var sig struct {
+		Version       uint32
+		PublicKey     []byte
+		Namespace     string
+		Reserved      []byte
+		HashAlgorithm string
+		Signature     []byte
+	}
+	if err := ssh.Unmarshal(block.Bytes[len(sshSigMagic):], &sig); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSSHSig, err)
+	}
+	if sig.Version != 1 {
+		return fmt.Errorf("%w: unsupported version %d", ErrInvalidSSHSig, sig.Version)
+	}
+	if sig.Namespace != sshSigNamespace {
+		return ErrSSHSigNamespace
+	}
+
+	pub, err := ssh.ParsePublicKey(sig.PublicKey)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSSHSig, err)
+	}
+	var known bool
+	for _, k := range keys {
+		if bytes.Equal(k.Marshal(), pub.Marshal()) {
+			known = true
+			break
+		}
+	}
+	if !known {
+		return ErrUnknownSigner
+	}
+
+	var h hash.Hash
+	switch sig.HashAlgorithm {
+	case "sha256":
+		h = sha256.New()
+	case "sha512":
+		h = sha512.New()
+	default:
+		return fmt.Errorf("%w: unsupported hash algorithm %q", ErrInvalidSSHSig, sig.HashAlgorithm)
+	}
+	h.Write(message)
+
+	var signature ssh.Signature
+	if err := ssh.Unmarshal(sig.Signature, &signature); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSSHSig, err)
+	}
+	signed := append([]byte(sshSigMagic), ssh.Marshal(struct {
+		Namespace     string
+		Reserved      []byte
+		HashAlgorithm string
+		Hash          []byte
+	}{sig.Namespace, sig.Reserved, sig.HashAlgorithm, h.Sum(nil)})...)
+	if err := pub.Verify(signed, &signature); err != nil {
+		return fmt.Errorf("%w: %v", ErrUnknownSigner, err)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package chartsync
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
+	"golang.org/x/crypto/ssh"
+)
+
+const unsignedCommit = `tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904
+author Jane Doe <jane@example.com> 1600000000 +0000
+committer Jane Doe <jane@example.com> 1600000000 +0000
+
+Update chart
+
+With a body.
+`
+
+// signCommit inserts the given signature as gpgsig header in the
+// unsigned commit, the way git does.
+func signCommit(signature string) []byte {
+	lines := strings.Split(strings.TrimSuffix(signature, "\n"), "\n")
+	header := "gpgsig " + strings.Join(lines, "\n ") + "\n"
+	i := strings.Index(unsignedCommit, "\n\n")
+	return []byte(unsignedCommit[:i+1] + header + unsignedCommit[i+1:])
+}
+
+func pgpSign(t *testing.T, entity *openpgp.Entity, message string) string {
+	var b bytes.Buffer
+	require.NoError(t, openpgp.ArmoredDetachSign(&b, entity, strings.NewReader(message), nil))
+	return b.String()
+}
+
+func pgpPublicKey(t *testing.T, entity *openpgp.Entity) []byte {
+	var b bytes.Buffer
+	w, err := armor.Encode(&b, openpgp.PublicKeyType, nil)
+	require.NoError(t, err)
+	require.NoError(t, entity.Serialize(w))
+	require.NoError(t, w.Close())
+	return b.Bytes()
+}
+
+func sshSign(t *testing.T, key ed25519.PrivateKey, namespace, message string) string {
+	signer, err := ssh.NewSignerFromKey(key)
+	require.NoError(t, err)
+	h := sha512.Sum512([]byte(message))
+	signed := append([]byte(sshSigMagic), ssh.Marshal(struct {
+		Namespace     string
+		Reserved      []byte
+		HashAlgorithm string
+		Hash          []byte
+	}{namespace, nil, "sha512", h[:]})...)
+	sig, err := signer.Sign(rand.Reader, signed)
+	require.NoError(t, err)
+	blob := append([]byte(sshSigMagic), ssh.Marshal(struct {
+		Version       uint32
+		PublicKey     []byte
+		Namespace     string
+		Reserved      []byte
+		HashAlgorithm string
+		Signature     []byte
+	}{1, signer.PublicKey().Marshal(), namespace, nil, "sha512", ssh.Marshal(sig)})...)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "SSH SIGNATURE", Bytes: blob}))
+}
+
+func Test_verifyCommitObject(t *testing.T) {
+	trusted, err := openpgp.NewEntity("Jane Doe", "", "jane@example.com", nil)
+	require.NoError(t, err)
+	untrusted, err := openpgp.NewEntity("Mallory", "", "mallory@example.com", nil)
+	require.NoError(t, err)
+	_, sshKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	_, untrustedSSHKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	sshPub, err := ssh.NewPublicKey(sshKey.Public())
+	require.NoError(t, err)
+
+	kr, err := parseKeyring(map[string][]byte{
+		"jane.asc":        pgpPublicKey(t, trusted),
+		"authorized_keys": ssh.MarshalAuthorizedKey(sshPub),
+	})
+	require.NoError(t, err)
+
+	for _, tt := range []struct {
+		name    string
+		commit  []byte
+		wantErr error
+	}{
+		{"unsigned", []byte(unsignedCommit), ErrNoSignature},
+		{"gpg signed", signCommit(pgpSign(t, trusted, unsignedCommit)), nil},
+		{"gpg signed by unknown key", signCommit(pgpSign(t, untrusted, unsignedCommit)), ErrUnknownSigner},
+		{"gpg signed other content", signCommit(pgpSign(t, trusted, "tampered")), ErrUnknownSigner},
+		{"ssh signed", signCommit(sshSign(t, sshKey, "git", unsignedCommit)), nil},
+		{"ssh signed by unknown key", signCommit(sshSign(t, untrustedSSHKey, "git", unsignedCommit)), ErrUnknownSigner},
+		{"ssh signed other content", signCommit(sshSign(t, sshKey, "git", "tampered")), ErrUnknownSigner},
+		{"ssh signed in other namespace", signCommit(sshSign(t, sshKey, "file", unsignedCommit)), ErrSSHSigNamespace},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := verifyCommitObject(tt.commit, kr)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func Test_parseKeyring(t *testing.T) {
+	_, err := parseKeyring(map[string][]byte{})
+	assert.ErrorIs(t, err, ErrEmptyKeyring)
+
+	_, err = parseKeyring(map[string][]byte{"key": []byte("not a key")})
+	assert.Error(t, err)
+}
@@ -32,6 +32,10 @@ func (c *FakeHelmV1) HelmReleases(namespace string) v1.HelmReleaseInterface {
 	return &FakeHelmReleases{c, namespace}
 }
 
+func (c *FakeHelmV1) HelmRepositories(namespace string) v1.HelmRepositoryInterface {
+	return &FakeHelmRepositories{c, namespace}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeHelmV1) RESTClient() rest.Interface {
@@ -27,6 +27,7 @@ import (
 type HelmV1Interface interface {
 	RESTClient() rest.Interface
 	HelmReleasesGetter
+	HelmRepositoriesGetter
 }
 
 // HelmV1Client is used to interact with features provided by the helm.fluxcd.io group.
@@ -38,6 +39,10 @@ func (c *HelmV1Client) HelmReleases(namespace string) HelmReleaseInterface {
 	return newHelmReleases(c, namespace)
 }
 
+func (c *HelmV1Client) HelmRepositories(namespace string) HelmRepositoryInterface {
+	return newHelmRepositories(c, namespace)
+}
+
 // NewForConfig creates a new HelmV1Client for the given config.
 func NewForConfig(c *rest.Config) (*HelmV1Client, error) {
 	config := *c
@@ -55,6 +55,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 	// Group=helm.fluxcd.io, Version=v1
 	case v1.SchemeGroupVersion.WithResource("helmreleases"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Helm().V1().HelmReleases().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("helmrepositories"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Helm().V1().HelmRepositories().Informer()}, nil
 
 	}
 
@@ -26,6 +26,8 @@ import (
 type Interface interface {
 	// HelmReleases returns a HelmReleaseInformer.
 	HelmReleases() HelmReleaseInformer
+	// HelmRepositories returns a HelmRepositoryInformer.
+	HelmRepositories() HelmRepositoryInformer
 }
 
 type version struct {
@@ -43,3 +45,8 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 func (v *version) HelmReleases() HelmReleaseInformer {
 	return &helmReleaseInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// HelmRepositories returns a HelmRepositoryInformer.
+func (v *version) HelmRepositories() HelmRepositoryInformer {
+	return &helmRepositoryInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
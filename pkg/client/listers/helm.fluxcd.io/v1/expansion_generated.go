@@ -25,3 +25,11 @@ type HelmReleaseListerExpansion interface{}
 // HelmReleaseNamespaceListerExpansion allows custom methods to be added to
 // HelmReleaseNamespaceLister.
 type HelmReleaseNamespaceListerExpansion interface{}
+
+// HelmRepositoryListerExpansion allows custom methods to be added to
+// HelmRepositoryLister.
+type HelmRepositoryListerExpansion interface{}
+
+// HelmRepositoryNamespaceListerExpansion allows custom methods to be added to
+// HelmRepositoryNamespaceLister.
+type HelmRepositoryNamespaceListerExpansion interface{}
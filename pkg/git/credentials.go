@@ -0,0 +1,146 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Credentials returns the username and password for a remote over
+// HTTPS. It is called before every operation that talks to the
+// upstream, so that rotated credentials are picked up without
+// restarting the mirror. The credentials are handed to git using a
+// credential helper, and never end up in the remote URL or the
+// configuration of the mirror.
+type Credentials func(ctx context.Context) (username, password string, err error)
+
+func (c Credentials) apply(r *Repo) {
+	r.credentials = c
+}
+
+// SSHKeys returns the SSH private key and (optional) known hosts for
+// a remote over SSH. Like Credentials, it is called before every
+// operation that talks to the upstream, so that rotated keys are
+// picked up without restarting the mirror. The keys are written to a
+// temporary directory for the duration of the operation only.
+type SSHKeys func(ctx context.Context) (identity, knownHosts []byte, err error)
+
+func (k SSHKeys) apply(r *Repo) {
+	r.sshKeys = k
+}
+
+// remoteEnv returns the environment for git commands that talk to
+// the upstream, and a function to clean up after the command has
+// completed.
+func (r *Repo) remoteEnv(ctx context.Context) ([]string, func(), error) {
+	env := r.env
+	var cleanups []func()
+	cleanup := func() {
+		for _, c := range cleanups {
+			c()
+		}
+	}
+
+	if r.credentials != nil {
+		credentialEnv, c, err := r.credentialHelperEnv(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		env, cleanups = append(credentialEnv, env...), append(cleanups, c)
+	}
+	if r.sshKeys != nil {
+		sshEnv, c, err := r.sshCommandEnv(ctx)
+		if err != nil {
+			cleanup()
+			return nil, nil, err
+		}
+		// The SSH command takes precedence over one set in the
+		// environment of the repo.
+		env, cleanups = append(env[:len(env):len(env)], sshEnv...), append(cleanups, c)
+	}
+	return env, cleanup, nil
+}
+
+// credentialHelperEnv returns the environment for a credential
+// helper handing out the credentials of the repo, and a function to
+// remove the credentials.
+func (r *Repo) credentialHelperEnv(ctx context.Context) ([]string, func(), error) {
+	username, password, err := r.credentials(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get credentials: %w", err)
+	}
+	if strings.ContainsAny(username+password, "\n\x00") {
+		return nil, nil, errors.New("credentials contain invalid characters")
+	}
+
+	f, err := ioutil.TempFile("", "git-credentials-")
+	if err != nil {
+		return nil, nil, err
+	}
+	cleanup := func() { os.Remove(f.Name()) }
+	_, err = fmt.Fprintf(f, "username=%s\npassword=%s\n", username, password)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+
+	// The first (empty) helper resets any helpers configured
+	// globally, the second answers requests for credentials with
+	// the contents of the file. The second is scoped to the host of
+	// the origin, so that the credentials are not handed out to other
+	// hosts (e.g. those of submodules).
+	helperKey := "credential.helper"
+	if u, err := url.Parse(r.origin.URL); err == nil && u.Scheme != "" && u.Host != "" {
+		helperKey = fmt.Sprintf("credential.%s://%s.helper", u.Scheme, u.Host)
+	}
+	return []string{
+		"GIT_CONFIG_COUNT=2",
+		"GIT_CONFIG_KEY_0=credential.helper",
+		"GIT_CONFIG_VALUE_0=",
+		"GIT_CONFIG_KEY_1=" + helperKey,
+		fmt.Sprintf("GIT_CONFIG_VALUE_1=!f() { test \"$1\" = get && cat '%s'; }; f", f.Name()),
+	}, cleanup, nil
+}
+
+// sshCommandEnv returns the environment with a `GIT_SSH_COMMAND`
+// making use of the SSH keys of the repo, and a function to remove
+// the keys.
+func (r *Repo) sshCommandEnv(ctx context.Context) ([]string, func(), error) {
+	identity, knownHosts, err := r.sshKeys(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get SSH keys: %w", err)
+	}
+
+	dir, err := ioutil.TempDir("", "git-ssh-")
+	if err != nil {
+		return nil, nil, err
+	}
+	cleanup := func() { os.RemoveAll(dir) }
+	identityPath := filepath.Join(dir, "identity")
+	if err := ioutil.WriteFile(identityPath, identity, 0400); err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	// Only the given identity is offered, so that a key known to the
+	// Git server but without access to the repository (e.g. the key
+	// mounted into the container) can not take precedence.
+	cmd := fmt.Sprintf("ssh -i '%s' -o IdentitiesOnly=yes", identityPath)
+
+	if len(knownHosts) > 0 {
+		knownHostsPath := filepath.Join(dir, "known_hosts")
+		if err := ioutil.WriteFile(knownHostsPath, knownHosts, 0400); err != nil {
+			cleanup()
+			return nil, nil, err
+		}
+		cmd += fmt.Sprintf(" -o UserKnownHostsFile='%s' -o StrictHostKeyChecking=yes", knownHostsPath)
+	}
+	return []string{"GIT_SSH_COMMAND=" + cmd}, cleanup, nil
+}
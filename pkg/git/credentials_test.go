@@ -0,0 +1,90 @@
+package git
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestRemoteEnvCredentialHelper(t *testing.T) {
+	r := NewRepo(Remote{URL: "https://example.com/org/repo"}, Credentials(func(context.Context) (string, string, error) {
+		return "user", "s3cr3t", nil
+	}))
+
+	env, cleanup, err := r.remoteEnv(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := exec.Command("git", "credential", "fill")
+	cmd.Env = append(os.Environ(), append(env, "GIT_TERMINAL_PROMPT=0")...)
+	cmd.Stdin = strings.NewReader("protocol=https\nhost=example.com\n\n")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git credential fill: %s", out)
+	}
+	if !strings.Contains(string(out), "username=user\n") || !strings.Contains(string(out), "password=s3cr3t\n") {
+		t.Errorf("expected credentials from helper, got:\n%s", out)
+	}
+
+	cleanup()
+	cmd = exec.Command("git", "credential", "fill")
+	cmd.Env = append(os.Environ(), append(env, "GIT_TERMINAL_PROMPT=0")...)
+	cmd.Stdin = strings.NewReader("protocol=https\nhost=example.com\n\n")
+	if out, err := cmd.CombinedOutput(); err == nil {
+		t.Errorf("expected no credentials after cleanup, got:\n%s", out)
+	}
+}
+
+func TestRemoteEnvCredentialHelperScope(t *testing.T) {
+	r := NewRepo(Remote{URL: "https://example.com/org/repo"}, Credentials(func(context.Context) (string, string, error) {
+		return "user", "s3cr3t", nil
+	}))
+
+	env, cleanup, err := r.remoteEnv(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cleanup()
+
+	cmd := exec.Command("git", "credential", "fill")
+	cmd.Env = append(os.Environ(), append(env, "GIT_TERMINAL_PROMPT=0")...)
+	cmd.Stdin = strings.NewReader("protocol=https\nhost=other.example.com\n\n")
+	if out, err := cmd.CombinedOutput(); err == nil {
+		t.Errorf("expected no credentials for other host, got:\n%s", out)
+	}
+}
+
+func TestRemoteEnvSSHKeys(t *testing.T) {
+	var calls int
+	r := NewRepo(Remote{URL: "git@example.com:org/repo"}, Env{"GIT_SSH_COMMAND=ssh"}, SSHKeys(func(context.Context) ([]byte, []byte, error) {
+		calls++
+		return []byte("private key"), []byte("example.com ssh-ed25519 AAAA"), nil
+	}))
+
+	for i := 1; i <= 2; i++ {
+		env, cleanup, err := r.remoteEnv(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if calls != i {
+			t.Errorf("expected keys to be resolved for every operation, got %d calls", calls)
+		}
+		cmd := env[len(env)-1]
+		if !strings.HasPrefix(cmd, "GIT_SSH_COMMAND=ssh -i '") || !strings.Contains(cmd, "UserKnownHostsFile=") {
+			t.Fatalf("expected SSH command with keys to take precedence, got %q", cmd)
+		}
+		identity := strings.SplitN(strings.TrimPrefix(cmd, "GIT_SSH_COMMAND=ssh -i '"), "'", 2)[0]
+		if b, err := ioutil.ReadFile(identity); err != nil || string(b) != "private key" {
+			t.Errorf("expected identity to be written, got %q (%v)", b, err)
+		}
+
+		cleanup()
+		if _, err := os.Stat(identity); !os.IsNotExist(err) {
+			t.Errorf("expected identity to be removed after cleanup")
+		}
+	}
+}
@@ -0,0 +1,12 @@
+// Package git maintains read-only mirrors of git repositories, and
+// exports of them at a given revision. It started out as a copy of the
+// `pkg/git` package of Flux, trimmed down to what is needed to source
+// charts, and extended with per repository configuration (e.g. the
+// environment the git commands run with).
+//
+// The copy exists because the package of Flux runs every git command
+// with the environment of the process, and expects credentials to be
+// part of the remote URL. Per release SSH keys and HTTPS credentials
+// require a different environment per repository, resolved before
+// every fetch, which can not be added without changing Flux itself.
+package git
@@ -0,0 +1,105 @@
+package git
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+)
+
+type Export struct {
+	dir string
+}
+
+func (e *Export) Dir() string {
+	return e.dir
+}
+
+func (e *Export) Clean() error {
+	if e.dir != "" {
+		return os.RemoveAll(e.dir)
+	}
+	return nil
+}
+
+// Export creates a minimal clone of the repo, at the ref given.
+func (r *Repo) Export(ctx context.Context, ref string) (*Export, error) {
+	dir, err := r.workingClone(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	if err = checkout(ctx, dir, ref); err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+	return &Export{dir}, nil
+}
+
+// SparseExport creates a minimal clone of the repo, at the ref given,
+// of which only the given directories (and the files at the root of
+// the repository) are checked out.
+func (r *Repo) SparseExport(ctx context.Context, ref string, paths []string) (*Export, error) {
+	dir, err := r.workingClone(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	if err = sparseCheckout(ctx, dir, paths); err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+	if err = checkout(ctx, dir, ref); err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+	return &Export{dir}, nil
+}
+
+// SetSparsePaths changes the directories checked out in a sparse
+// export to the given paths.
+func (e *Export) SetSparsePaths(ctx context.Context, paths []string) error {
+	args := append([]string{"sparse-checkout", "set"}, paths...)
+	return execGitCmd(ctx, args, gitCmdConfig{dir: e.Dir()})
+}
+
+// UpdateSubmodules checks out the submodules of the given export
+// (made from this repo), recursively. Relative submodule URLs are
+// resolved against the origin of the repo, and the submodules are
+// fetched with the same environment and credentials as the repo.
+func (r *Repo) UpdateSubmodules(ctx context.Context, e *Export) error {
+	env, cleanup, err := r.remoteEnv(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	return submoduleUpdate(ctx, e.Dir(), r.Origin().URL, env)
+}
+
+// PullLFS replaces the Git LFS pointers in the given export (made
+// from this repo) with the objects they point to, fetched from the
+// origin of the repo. If recursive is true, this is done for the
+// submodules as well.
+func (r *Repo) PullLFS(ctx context.Context, e *Export, recursive bool) error {
+	env, cleanup, err := r.remoteEnv(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	return lfsPull(ctx, e.Dir(), r.Origin().URL, recursive, env)
+}
+
+// HasCommit returns true if the given revision is available in the
+// export. This is not the case for revisions outside of the history
+// fetched into a shallow mirror, or removed from it by a force push.
+func (e *Export) HasCommit(ctx context.Context, rev string) (bool, error) {
+	return commitExists(ctx, e.Dir(), rev)
+}
+
+// ChangedFiles does a git diff listing changed files
+func (e *Export) ChangedFiles(ctx context.Context, sinceRef string, paths []string) ([]string, error) {
+	list, err := changed(ctx, e.Dir(), sinceRef, paths)
+	if err == nil {
+		for i, file := range list {
+			list[i] = filepath.Join(e.Dir(), file)
+		}
+	}
+	return list, err
+}
@@ -0,0 +1,248 @@
+package git
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// createRepo initialises a git repository in the given directory,
+// with a commit per given file.
+func createRepo(t *testing.T, dir string, files ...string) {
+	t.Helper()
+	run := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %s", args, out)
+		}
+	}
+	run("init", "-q")
+	run("config", "user.email", "example@example.com")
+	run("config", "user.name", "example")
+	for _, f := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, f), []byte(f), 0600); err != nil {
+			t.Fatal(err)
+		}
+		run("add", f)
+		run("commit", "-q", "-m", "add "+f)
+	}
+}
+
+func TestExportAtRevision(t *testing.T) {
+	newDir, err := ioutil.TempDir("", "git-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(newDir)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	createRepo(t, newDir, "one", "two")
+	repo := NewRepo(Remote{URL: newDir})
+	if err := repo.Ready(ctx); err != nil {
+		t.Fatal(err)
+	}
+	defer repo.Clean()
+
+	headMinusOne, err := repo.Revision(ctx, "HEAD^1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	export, err := repo.Export(ctx, headMinusOne)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer export.Clean()
+
+	exportHead, err := refRevision(ctx, export.dir, "HEAD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if headMinusOne != exportHead {
+		t.Errorf("exported %s, but head in export dir %s is %s", headMinusOne, export.dir, exportHead)
+	}
+	if _, err := os.Stat(filepath.Join(export.dir, "one")); err != nil {
+		t.Errorf("expected file of exported commit to be present: %s", err)
+	}
+	if _, err := os.Stat(filepath.Join(export.dir, "two")); !os.IsNotExist(err) {
+		t.Errorf("expected file of later commit to be absent from export")
+	}
+}
+
+func TestSparseExport(t *testing.T) {
+	newDir, err := ioutil.TempDir("", "git-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(newDir)
+	for _, d := range []string{"a", "b", "c"} {
+		if err := os.Mkdir(filepath.Join(newDir, d), 0700); err != nil {
+			t.Fatal(err)
+		}
+	}
+	createRepo(t, newDir, "root", "a/file", "b/file", "c/file")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	repo := NewRepo(Remote{URL: newDir}, Shallow)
+	if err := repo.Ready(ctx); err != nil {
+		t.Fatal(err)
+	}
+	defer repo.Clean()
+
+	head, err := repo.Revision(ctx, "HEAD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	export, err := repo.SparseExport(ctx, head, []string{"a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer export.Clean()
+
+	exists := func(path string) bool {
+		_, err := os.Stat(filepath.Join(export.Dir(), path))
+		return err == nil
+	}
+	if !exists("root") || !exists("a/file") || exists("b/file") {
+		t.Errorf("expected only root files and directory a to be checked out")
+	}
+	if err := export.SetSparsePaths(ctx, []string{"a", "b"}); err != nil {
+		t.Fatal(err)
+	}
+	if !exists("b/file") || exists("c/file") {
+		t.Errorf("expected directory b to be checked out after setting sparse paths")
+	}
+}
+
+func TestExportHasCommit(t *testing.T) {
+	newDir, err := ioutil.TempDir("", "git-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(newDir)
+	createRepo(t, newDir, "one", "two")
+	headMinusOne, err := exec.Command("git", "-C", newDir, "rev-parse", "HEAD^1").Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// The depth is ignored for clones of local paths.
+	repo := NewRepo(Remote{URL: "file://" + newDir}, Shallow)
+	if err := repo.Ready(ctx); err != nil {
+		t.Fatal(err)
+	}
+	defer repo.Clean()
+
+	head, err := repo.Revision(ctx, "HEAD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	export, err := repo.Export(ctx, head)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer export.Clean()
+
+	if ok, err := export.HasCommit(ctx, head); err != nil || !ok {
+		t.Errorf("expected HEAD to be available in export, got %t (err: %v)", ok, err)
+	}
+	if ok, err := export.HasCommit(ctx, string(headMinusOne[:len(headMinusOne)-1])); err != nil || ok {
+		t.Errorf("expected parent of HEAD not to be available in shallow export, got %t (err: %v)", ok, err)
+	}
+	if ok, err := export.HasCommit(ctx, "1.0.0"); err != nil || ok {
+		t.Errorf("expected chart version not to be available in export, got %t (err: %v)", ok, err)
+	}
+}
+
+func TestUpdateSubmodules(t *testing.T) {
+	newDir, err := ioutil.TempDir("", "git-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(newDir)
+	subDir, parentDir := filepath.Join(newDir, "sub"), filepath.Join(newDir, "parent")
+	for _, d := range []string{subDir, parentDir} {
+		if err := os.Mkdir(d, 0700); err != nil {
+			t.Fatal(err)
+		}
+	}
+	createRepo(t, subDir, "lib")
+	createRepo(t, parentDir, "root")
+
+	// Submodules over the file transport are not allowed by default.
+	allowFile := Env{"GIT_CONFIG_COUNT=1", "GIT_CONFIG_KEY_0=protocol.file.allow", "GIT_CONFIG_VALUE_0=always"}
+	for _, args := range [][]string{
+		{"-c", "protocol.file.allow=always", "submodule", "add", "-q", "../sub", "shared"},
+		{"commit", "-q", "-m", "add submodule"},
+	} {
+		cmd := exec.Command("git", append([]string{"-C", parentDir}, args...)...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %s", args, out)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	repo := NewRepo(Remote{URL: parentDir}, allowFile)
+	if err := repo.Ready(ctx); err != nil {
+		t.Fatal(err)
+	}
+	defer repo.Clean()
+
+	head, err := repo.Revision(ctx, "HEAD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	export, err := repo.Export(ctx, head)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer export.Clean()
+
+	libPath := filepath.Join(export.Dir(), "shared", "lib")
+	if _, err := os.Stat(libPath); !os.IsNotExist(err) {
+		t.Fatalf("expected submodule not to be checked out by export")
+	}
+	// The relative submodule URL is resolved against the origin of
+	// the repo, not against the mirror the export was cloned from.
+	if err := repo.UpdateSubmodules(ctx, export); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(libPath); err != nil {
+		t.Errorf("expected file of submodule to be present: %s", err)
+	}
+}
+
+func TestRepoEnv(t *testing.T) {
+	newDir, err := ioutil.TempDir("", "git-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(newDir)
+	createRepo(t, newDir, "one")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// An SSH command that fails proves the environment is passed on
+	// to the clone of an SSH remote.
+	repo := NewRepo(Remote{URL: "ssh://example.com/repo.git"}, Env{"GIT_SSH_COMMAND=false"})
+	if err := repo.Ready(ctx); err == nil {
+		t.Fatal("expected clone with failing SSH command to fail")
+	}
+	if status, _ := repo.Status(); status == RepoReady {
+		t.Errorf("expected repo not to be ready, got %q", status)
+	}
+}
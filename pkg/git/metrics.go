@@ -0,0 +1,84 @@
+package git
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	MetricRepoReady   = 1
+	MetricRepoUnready = 0
+)
+
+const (
+	LabelRemote  = "remote"
+	LabelSuccess = "success"
+)
+
+var (
+	metricGitReady = prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+		Namespace: "flux",
+		Subsystem: "git",
+		Name:      "ready",
+		Help:      "Status of the git repository.",
+	}, []string{})
+	metricFetchDuration = prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+		Namespace: "flux",
+		Subsystem: "helm_operator",
+		Name:      "git_fetch_duration_seconds",
+		Help:      "Duration of cloning or fetching a Git mirror from its remote in seconds.",
+		Buckets:   []float64{0.5, 1, 5, 10, 30, 60, 120, 300},
+	}, []string{LabelRemote, LabelSuccess})
+	metricFetchFailures = prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Namespace: "flux",
+		Subsystem: "helm_operator",
+		Name:      "git_fetch_failures_total",
+		Help:      "Count of failed clones or fetches of a Git mirror from its remote.",
+	}, []string{LabelRemote})
+	metricMirrorCount = prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+		Namespace: "flux",
+		Subsystem: "helm_operator",
+		Name:      "git_mirror_count",
+		Help:      "Count of Git mirrors.",
+	}, []string{})
+)
+
+// observeFetch records the duration and result of a clone or fetch
+// from the given remote URL.
+func observeFetch(remoteURL string, start time.Time, err error) {
+	remote := redactURL(remoteURL)
+	metricFetchDuration.With(
+		LabelRemote, remote,
+		LabelSuccess, fmt.Sprint(err == nil),
+	).Observe(time.Since(start).Seconds())
+	if err != nil {
+		metricFetchFailures.With(LabelRemote, remote).Add(1)
+	}
+}
+
+// redactURL returns the given URL without user information and query
+// string, so it can be used as a label value without exposing
+// credentials.
+func redactURL(remoteURL string) string {
+	if !strings.Contains(remoteURL, "://") {
+		// An scp-like address (user@host:path), which has no query
+		// string.
+		if i := strings.Index(remoteURL, "@"); i >= 0 && i < strings.Index(remoteURL, ":") {
+			return remoteURL[i+1:]
+		}
+		return remoteURL
+	}
+	u, err := url.Parse(remoteURL)
+	if err != nil {
+		return ""
+	}
+	u.User = nil
+	u.RawQuery = ""
+	u.Fragment = ""
+	return u.String()
+}
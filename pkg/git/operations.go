@@ -0,0 +1,365 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// Env vars that are allowed to be inherited from the OS
+var allowedEnvVars = []string{
+	// these are for people using (no) proxies. Git follows the curl conventions, so HTTP_PROXY
+	// is intentionally missing
+	"http_proxy", "https_proxy", "no_proxy", "HTTPS_PROXY", "NO_PROXY", "GIT_PROXY_COMMAND",
+	// these are needed for GPG to find its files
+	"HOME", "GNUPGHOME",
+	// these are for Google Cloud SDK to find its files (which will
+	// have to be mounted, if running in a container)
+	"CLOUDSDK_CONFIG", "CLOUDSDK_PYTHON",
+	// those vars are for NSS_WRAPPER, which is used to solve ssh error - "No user exists for uid xxxxxxxxxx",
+	// when container is running in hardened Openshift environments and user id is not found in /etc/passwd
+	"NSS_WRAPPER_PASSWD", "NSS_WRAPPER_GROUP", "LD_PRELOAD",
+	// variables used by the AWS CodeCommit helper to get temporary git credentials when using Kubernetes
+	// service account IAM role integration
+	"AWS_WEB_IDENTITY_TOKEN_FILE", "AWS_ROLE_ARN",
+}
+
+type gitCmdConfig struct {
+	dir string
+	env []string
+	out io.Writer
+}
+
+func clone(ctx context.Context, workingDir, repoURL, repoBranch string) (path string, err error) {
+	repoPath := workingDir
+	// The clone is always followed by a checkout of the requested
+	// ref, so there is no need to check out the default branch first.
+	args := []string{"clone", "--no-checkout"}
+	if repoBranch != "" {
+		args = append(args, "--branch", repoBranch)
+	}
+	args = append(args, repoURL, repoPath)
+	if err := execGitCmd(ctx, args, gitCmdConfig{dir: workingDir}); err != nil {
+		return "", errors.Wrap(err, "git clone")
+	}
+	return repoPath, nil
+}
+
+func mirror(ctx context.Context, workingDir, repoURL string, shallow bool, env []string) (path string, err error) {
+	repoPath := workingDir
+	args := []string{"clone", "--mirror"}
+	if shallow {
+		args = append(args, "--depth", "1")
+	}
+	args = append(args, repoURL, repoPath)
+	if err := execGitCmd(ctx, args, gitCmdConfig{dir: workingDir, env: env}); err != nil {
+		return "", errors.Wrap(err, "git clone --mirror")
+	}
+	return repoPath, nil
+}
+
+// sparseCheckout limits the working tree to the given directories
+// (and the files at the root of the repository).
+func sparseCheckout(ctx context.Context, workingDir string, paths []string) error {
+	if err := execGitCmd(ctx, []string{"sparse-checkout", "init", "--cone"}, gitCmdConfig{dir: workingDir}); err != nil {
+		return errors.Wrap(err, "git sparse-checkout init")
+	}
+	args := append([]string{"sparse-checkout", "set"}, paths...)
+	if err := execGitCmd(ctx, args, gitCmdConfig{dir: workingDir}); err != nil {
+		return errors.Wrap(err, "git sparse-checkout set")
+	}
+	return nil
+}
+
+func checkout(ctx context.Context, workingDir, ref string) error {
+	args := []string{"checkout", ref, "--"}
+	err := execGitCmd(ctx, args, gitCmdConfig{dir: workingDir, env: []string{skipSmudgeEnv}})
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// skipSmudgeEnv prevents Git LFS (when installed) from fetching
+// objects on checkout, as the objects are not in the mirror. They are
+// fetched from the upstream on request using `lfsPull`.
+const skipSmudgeEnv = "GIT_LFS_SKIP_SMUDGE=1"
+
+// setOrigin points the origin of a working clone at the given
+// upstream, instead of the mirror it was cloned from.
+func setOrigin(ctx context.Context, workingDir, upstream string) error {
+	args := []string{"remote", "set-url", "origin", upstream}
+	if err := execGitCmd(ctx, args, gitCmdConfig{dir: workingDir}); err != nil {
+		return errors.Wrap(err, "git remote set-url")
+	}
+	return nil
+}
+
+// submoduleUpdate checks out the submodules of a working clone,
+// recursively. Relative submodule URLs are resolved against the
+// given upstream.
+func submoduleUpdate(ctx context.Context, workingDir, upstream string, env []string) error {
+	if err := setOrigin(ctx, workingDir, upstream); err != nil {
+		return err
+	}
+	args := []string{"submodule", "update", "--init", "--recursive"}
+	env = append([]string{skipSmudgeEnv}, env...)
+	if err := execGitCmd(ctx, args, gitCmdConfig{dir: workingDir, env: env}); err != nil {
+		return errors.Wrap(err, "git submodule update")
+	}
+	return nil
+}
+
+// lfsPull fetches the Git LFS objects of the checked out files in a
+// working clone from the given upstream, and replaces the pointers
+// with them. If recursive is true, this is done for the submodules
+// as well.
+func lfsPull(ctx context.Context, workingDir, upstream string, recursive bool, env []string) error {
+	if err := setOrigin(ctx, workingDir, upstream); err != nil {
+		return err
+	}
+	if err := execGitCmd(ctx, []string{"lfs", "pull"}, gitCmdConfig{dir: workingDir, env: env}); err != nil {
+		return errors.Wrap(err, "git lfs pull")
+	}
+	if recursive {
+		args := []string{"submodule", "foreach", "--recursive", "git lfs pull"}
+		if err := execGitCmd(ctx, args, gitCmdConfig{dir: workingDir, env: env}); err != nil {
+			return errors.Wrap(err, "git lfs pull (submodules)")
+		}
+	}
+	return nil
+}
+
+// fetch updates refs from the upstream.
+func fetch(ctx context.Context, workingDir, upstream string, shallow bool, env []string, refspec ...string) error {
+	args := []string{"fetch", "--tags"}
+	if shallow {
+		args = append(args, "--depth", "1")
+	}
+	args = append(append(args, upstream), refspec...)
+	// In git <=2.20 the error started with an uppercase, in 2.21 this
+	// was changed to be consistent with all other die() and error()
+	// messages, cast to lowercase to support both versions.
+	// Ref: https://github.com/git/git/commit/0b9c3afdbfb62936337efc52b4007a446939b96b
+	if err := execGitCmd(ctx, args, gitCmdConfig{dir: workingDir, env: env}); err != nil &&
+		!strings.Contains(strings.ToLower(err.Error()), "couldn't find remote ref") {
+		return errors.Wrap(err, fmt.Sprintf("git fetch --tags %s %s", upstream, refspec))
+	}
+	return nil
+}
+
+func refExists(ctx context.Context, workingDir, ref string) (bool, error) {
+	args := []string{"rev-list", ref, "--"}
+	if err := execGitCmd(ctx, args, gitCmdConfig{dir: workingDir}); err != nil {
+		if strings.Contains(err.Error(), "bad revision") {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// commitExists returns true if the given revision resolves to a commit
+// that is available in the repository.
+func commitExists(ctx context.Context, workingDir, rev string) (bool, error) {
+	args := []string{"cat-file", "-e", rev + "^{commit}"}
+	if err := execGitCmd(ctx, args, gitCmdConfig{dir: workingDir}); err != nil {
+		if ctx.Err() != nil {
+			return false, ctx.Err()
+		}
+		return false, nil
+	}
+	return true, nil
+}
+
+// Get the commit hash for a reference
+func refRevision(ctx context.Context, workingDir, ref string) (string, error) {
+	out := &bytes.Buffer{}
+	args := []string{"rev-list", "--max-count", "1", ref, "--"}
+	if err := execGitCmd(ctx, args, gitCmdConfig{dir: workingDir, out: out}); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out.String()), nil
+}
+
+// Return the revisions and one-line log commit messages
+func onelinelog(ctx context.Context, workingDir, refspec string, subdirs []string, firstParent bool) ([]Commit, error) {
+	out := &bytes.Buffer{}
+	args := []string{"log", "--pretty=format:%GK|%G?|%H|%s"}
+
+	if firstParent {
+		args = append(args, "--first-parent")
+	}
+
+	args = append(args, refspec, "--")
+
+	if len(subdirs) > 0 {
+		args = append(args, subdirs...)
+	}
+
+	if err := execGitCmd(ctx, args, gitCmdConfig{dir: workingDir, out: out}); err != nil {
+		return nil, err
+	}
+
+	return splitLog(out.String())
+}
+
+func splitLog(s string) ([]Commit, error) {
+	lines := splitList(s)
+	commits := make([]Commit, len(lines))
+	for i, m := range lines {
+		parts := strings.SplitN(m, "|", 4)
+		commits[i].Signature = Signature{
+			Key:    parts[0],
+			Status: parts[1],
+		}
+		commits[i].Revision = parts[2]
+		commits[i].Message = parts[3]
+	}
+	return commits, nil
+}
+
+func splitList(s string) []string {
+	if strings.TrimSpace(s) == "" {
+		return []string{}
+	}
+	outStr := strings.TrimSuffix(s, "\n")
+	return strings.Split(outStr, "\n")
+}
+
+// treeHash returns the hash of the tree object at the given path for
+// the given revision.
+func treeHash(ctx context.Context, workingDir, rev, path string) (string, error) {
+	out := &bytes.Buffer{}
+	args := []string{"rev-parse", rev + ":" + path}
+	if err := execGitCmd(ctx, args, gitCmdConfig{dir: workingDir, out: out}); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out.String()), nil
+}
+
+// tags returns the names of all tags.
+func tags(ctx context.Context, workingDir string) ([]string, error) {
+	out := &bytes.Buffer{}
+	args := []string{"tag", "--list"}
+	if err := execGitCmd(ctx, args, gitCmdConfig{dir: workingDir, out: out}); err != nil {
+		return nil, err
+	}
+	return splitList(out.String()), nil
+}
+
+func changed(ctx context.Context, workingDir, ref string, subPaths []string) ([]string, error) {
+	out := &bytes.Buffer{}
+	// This uses --diff-filter to only look at changes for file _in
+	// the working dir_; i.e, we do not report on things that no
+	// longer appear.
+	args := []string{"diff", "--name-only", "--diff-filter=ACMRT", ref}
+	args = append(args, "--")
+	if len(subPaths) > 0 {
+		args = append(args, subPaths...)
+	}
+
+	if err := execGitCmd(ctx, args, gitCmdConfig{dir: workingDir, out: out}); err != nil {
+		return nil, err
+	}
+	return splitList(out.String()), nil
+}
+
+type threadSafeBuffer struct {
+	buf bytes.Buffer
+	mu  sync.Mutex
+}
+
+func (b *threadSafeBuffer) Write(p []byte) (n int, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *threadSafeBuffer) Read(p []byte) (n int, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Read(p)
+}
+
+func (b *threadSafeBuffer) Bytes() []byte {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Bytes()
+}
+
+func (b *threadSafeBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// execGitCmd runs a `git` command with the supplied arguments.
+func execGitCmd(ctx context.Context, args []string, config gitCmdConfig) error {
+	c := exec.CommandContext(ctx, "git", args...)
+
+	if config.dir != "" {
+		c.Dir = config.dir
+	}
+	c.Env = append(env(), config.env...)
+	stdOutAndStdErr := &threadSafeBuffer{}
+	c.Stdout = stdOutAndStdErr
+	c.Stderr = stdOutAndStdErr
+	if config.out != nil {
+		c.Stdout = io.MultiWriter(c.Stdout, config.out)
+	}
+
+	err := c.Run()
+	if err != nil {
+		if len(stdOutAndStdErr.Bytes()) > 0 {
+			err = errors.New(stdOutAndStdErr.String())
+			msg := findErrorMessage(stdOutAndStdErr)
+			if msg != "" {
+				err = fmt.Errorf("%s, full output:\n %s", msg, err.Error())
+			}
+		}
+	}
+
+	if ctx.Err() == context.DeadlineExceeded {
+		return errors.Wrap(ctx.Err(), fmt.Sprintf("running git command: %s %v", "git", args))
+	} else if ctx.Err() == context.Canceled {
+		return errors.Wrap(ctx.Err(), fmt.Sprintf("context was unexpectedly cancelled when running git command: %s %v", "git", args))
+	}
+	return err
+}
+
+func env() []string {
+	env := []string{"GIT_TERMINAL_PROMPT=0"}
+
+	// include allowed env vars from os
+	for _, k := range allowedEnvVars {
+		if v, ok := os.LookupEnv(k); ok {
+			env = append(env, k+"="+v)
+		}
+	}
+
+	return env
+}
+
+func findErrorMessage(output io.Reader) string {
+	sc := bufio.NewScanner(output)
+	for sc.Scan() {
+		switch {
+		case strings.HasPrefix(sc.Text(), "fatal: "):
+			return sc.Text()
+		case strings.HasPrefix(sc.Text(), "ERROR fatal: "): // Saw this error on ubuntu systems
+			return sc.Text()
+		case strings.HasPrefix(sc.Text(), "error:"):
+			return strings.TrimPrefix(sc.Text(), "error: ")
+		}
+	}
+	return ""
+}
@@ -0,0 +1,484 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultInterval = 5 * time.Minute
+	defaultTimeout  = 20 * time.Second
+)
+
+var (
+	ErrNoConfig   = errors.New("git repo does not have valid config")
+	ErrNotCloned  = errors.New("git repo has not been cloned yet")
+	ErrClonedOnly = errors.New("git repo has been cloned but not yet fetched from")
+)
+
+type NotReadyError struct {
+	underlying error
+}
+
+func (err NotReadyError) Unwrap() error { return err.underlying }
+
+func (err NotReadyError) Error() string {
+	return "git repo not ready: " + err.underlying.Error()
+}
+
+// GitRepoStatus represents the progress made synchronising with a git
+// repo. These are given below in expected order, but the status may
+// go backwards if e.g., a deploy key is deleted.
+type GitRepoStatus string
+
+const (
+	RepoNoConfig    GitRepoStatus = "unconfigured" // configuration is empty
+	RepoNew         GitRepoStatus = "new"          // no attempt made to clone it yet
+	RepoCloned      GitRepoStatus = "cloned"       // has been read (cloned)
+	RepoReady       GitRepoStatus = "ready"        // has been fetched from, so ready to sync
+	RepoUnreachable GitRepoStatus = "unreachable"  // git repo is unreachable due to incorrect URL or DNS resolve failure
+)
+
+// Repo is a read-only mirror of a git repository, which keeps itself
+// up-to-date with the upstream.
+type Repo struct {
+	// As supplied to constructor
+	origin      Remote
+	interval    time.Duration
+	timeout     time.Duration
+	env         []string
+	credentials Credentials
+	sshKeys     SSHKeys
+	shallow     bool
+
+	// State
+	mu        sync.RWMutex
+	status    GitRepoStatus
+	err       error
+	dir       string
+	lastFetch time.Time
+
+	notify chan struct{}
+	C      chan struct{}
+}
+
+type Option interface {
+	apply(*Repo)
+}
+
+type optionFunc func(*Repo)
+
+func (f optionFunc) apply(r *Repo) {
+	f(r)
+}
+
+type PollInterval time.Duration
+
+func (p PollInterval) apply(r *Repo) {
+	r.interval = time.Duration(p)
+}
+
+type Timeout time.Duration
+
+func (t Timeout) apply(r *Repo) {
+	r.timeout = time.Duration(t)
+}
+
+// Env holds additional environment variables (in the form of
+// `key=value`) for the git commands that talk to the upstream, e.g.
+// `GIT_SSH_COMMAND`. This makes it possible to use different
+// credentials for each repo.
+type Env []string
+
+func (e Env) apply(r *Repo) {
+	r.env = append(r.env, e...)
+}
+
+type IsShallow bool
+
+func (s IsShallow) apply(r *Repo) {
+	r.shallow = bool(s)
+}
+
+// Shallow makes the repo fetch only the latest commit of every ref
+// from the upstream, instead of the full history. This saves disk
+// space and time for large repositories, but the history of the refs
+// (e.g. `CommitsBetween`) is not available.
+var Shallow IsShallow = true
+
+// NewRepo constructs a repo mirror which will sync itself.
+func NewRepo(origin Remote, opts ...Option) *Repo {
+	status := RepoNew
+	if origin.URL == "" {
+		status = RepoNoConfig
+	}
+	r := &Repo{
+		origin:   origin,
+		status:   status,
+		interval: defaultInterval,
+		timeout:  defaultTimeout,
+		err:      ErrNotCloned,
+		notify:   make(chan struct{}, 1), // `1` so that Notify doesn't block
+		C:        make(chan struct{}, 1), // `1` so we don't block on completing a refresh
+	}
+	for _, opt := range opts {
+		opt.apply(r)
+	}
+	return r
+}
+
+// Origin returns the Remote with which the Repo was constructed.
+func (r *Repo) Origin() Remote {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.origin
+}
+
+// Shallow returns `true` if the repo only holds the latest commit of
+// every ref, `false` otherwise.
+func (r *Repo) Shallow() bool {
+	return r.shallow
+}
+
+// Dir returns the local directory into which the repo has been
+// cloned, if it has been cloned.
+func (r *Repo) Dir() string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.dir
+}
+
+// Clean removes the mirrored repo. Syncing may continue with a new
+// directory, so you may need to stop that first.
+func (r *Repo) Clean() {
+	r.mu.Lock()
+	if r.dir != "" {
+		os.RemoveAll(r.dir)
+	}
+	r.dir = ""
+	r.status = RepoNew
+	r.mu.Unlock()
+}
+
+// Status reports that readiness status of this Git repo: whether it
+// has been cloned and fetched from, and if not, the error stopping it
+// getting to the next state.
+func (r *Repo) Status() (GitRepoStatus, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.status, r.err
+}
+
+func (r *Repo) setUnready(s GitRepoStatus, err error) {
+	metricGitReady.Set(MetricRepoUnready)
+	r.mu.Lock()
+	r.status = s
+	r.err = err
+	r.mu.Unlock()
+	// Signal the listeners, so that they are able to report the
+	// failure.
+	r.signal()
+}
+
+func (r *Repo) setReady() {
+	metricGitReady.Set(MetricRepoReady)
+	r.mu.Lock()
+	r.status = RepoReady
+	r.err = nil
+	r.mu.Unlock()
+}
+
+// Notify tells the repo that it should fetch from the origin as soon
+// as possible. It does not block.
+func (r *Repo) Notify() {
+	select {
+	case r.notify <- struct{}{}:
+		// duly notified
+	default:
+		// notification already pending
+	}
+}
+
+// refreshed indicates that the repo has successfully fetched from upstream.
+func (r *Repo) refreshed() {
+	r.signal()
+}
+
+// signal tells the listeners the status of the repo may have changed.
+// It does not block.
+func (r *Repo) signal() {
+	select {
+	case r.C <- struct{}{}:
+	default:
+	}
+}
+
+// errorIfNotReady returns the appropriate error if the repo is not
+// ready, and `nil` otherwise.
+func (r *Repo) errorIfNotReady() error {
+	switch r.status {
+	case RepoReady:
+		return nil
+	case RepoNoConfig:
+		return ErrNoConfig
+	default:
+		return NotReadyError{r.err}
+	}
+}
+
+// LastFetch returns the time of the last successful fetch from the
+// upstream, or the zero time if the repo has not been fetched from.
+func (r *Repo) LastFetch() time.Time {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.lastFetch
+}
+
+// Revision returns the revision (SHA1) of the ref passed in
+func (r *Repo) Revision(ctx context.Context, ref string) (string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if err := r.errorIfNotReady(); err != nil {
+		return "", err
+	}
+	return refRevision(ctx, r.dir, ref)
+}
+
+// TreeHash returns the hash of the tree at the given path for the
+// given revision, which only changes if anything under the path
+// changes.
+func (r *Repo) TreeHash(ctx context.Context, rev, path string) (string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if err := r.errorIfNotReady(); err != nil {
+		return "", err
+	}
+	return treeHash(ctx, r.dir, rev, path)
+}
+
+// Tags returns the names of all tags in the repo.
+func (r *Repo) Tags(ctx context.Context) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if err := r.errorIfNotReady(); err != nil {
+		return nil, err
+	}
+	return tags(ctx, r.dir)
+}
+
+func (r *Repo) CommitsBefore(ctx context.Context, ref string, firstParent bool, paths ...string) ([]Commit, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if err := r.errorIfNotReady(); err != nil {
+		return nil, err
+	}
+	return onelinelog(ctx, r.dir, ref, paths, firstParent)
+}
+
+func (r *Repo) CommitsBetween(ctx context.Context, ref1, ref2 string, firstParent bool, paths ...string) ([]Commit, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if err := r.errorIfNotReady(); err != nil {
+		return nil, err
+	}
+	return onelinelog(ctx, r.dir, ref1+".."+ref2, paths, firstParent)
+}
+
+// step attempts to advance the repo state machine, and returns `true`
+// if it has made progress, `false` otherwise.
+func (r *Repo) step(bg context.Context) bool {
+	r.mu.RLock()
+	url := r.origin.URL
+	status := r.status
+	r.mu.RUnlock()
+
+	switch status {
+
+	case RepoNoConfig:
+		// this is not going to change in the lifetime of this
+		// process, so just exit.
+		return false
+
+	case RepoNew, RepoUnreachable:
+		rootdir, err := ioutil.TempDir(os.TempDir(), "flux-gitclone")
+		if err != nil {
+			panic(err)
+		}
+
+		ctx, cancel := context.WithTimeout(bg, r.timeout)
+		var dir string
+		env, cleanup, err := r.remoteEnv(ctx)
+		if err == nil {
+			start := time.Now()
+			dir, err = mirror(ctx, rootdir, url, r.shallow, env)
+			observeFetch(url, start, err)
+			cleanup()
+		}
+		cancel()
+		if err == nil {
+			r.mu.Lock()
+			r.dir = dir
+			ctx, cancel := context.WithTimeout(bg, r.timeout)
+			err = r.fetch(ctx)
+			cancel()
+			r.mu.Unlock()
+		}
+		if err == nil {
+			r.setUnready(RepoCloned, ErrClonedOnly)
+			return true
+		}
+		os.RemoveAll(rootdir)
+		if strings.Contains(strings.ToLower(err.Error()), "could not resolve hostname") {
+			r.setUnready(RepoUnreachable, err)
+			return false
+		}
+		r.setUnready(RepoNew, err)
+		return false
+
+	case RepoCloned:
+		r.setReady()
+		// Treat every transition to ready as a refresh, so
+		// that any listeners can respond in the same way.
+		r.refreshed()
+		return true
+
+	case RepoReady:
+		return false
+	}
+
+	return false
+}
+
+// Ready tries to advance the cloning process along as far as
+// possible, and returns an error if it is not able to get to a ready
+// state.
+func (r *Repo) Ready(ctx context.Context) error {
+	for r.step(ctx) {
+		// keep going!
+	}
+	_, err := r.Status()
+	return err
+}
+
+// Start begins synchronising the repo by cloning it, then fetching
+// the required tags and so on.
+func (r *Repo) Start(shutdown <-chan struct{}, done *sync.WaitGroup) error {
+	defer done.Done()
+
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+		advanced := r.step(ctx)
+		cancel()
+
+		if advanced {
+			continue
+		}
+
+		status, _ := r.Status()
+		if status == RepoReady {
+			if err := r.refreshLoop(shutdown); err != nil {
+				r.setUnready(RepoNew, err)
+				continue // with new status, skipping timer
+			}
+		} else if status == RepoNoConfig {
+			return nil
+		}
+
+		tryAgain := time.NewTimer(10 * time.Second)
+		select {
+		case <-shutdown:
+			if !tryAgain.Stop() {
+				<-tryAgain.C
+			}
+			return nil
+		case <-tryAgain.C:
+			continue
+		}
+	}
+}
+
+func (r *Repo) Refresh(ctx context.Context) error {
+	// the lock here and below is difficult to avoid; possibly we
+	// could clone to another repo and pull there, then swap when complete.
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.errorIfNotReady(); err != nil {
+		return err
+	}
+	if err := r.fetch(ctx); err != nil {
+		return err
+	}
+	r.refreshed()
+	return nil
+}
+
+func (r *Repo) refreshLoop(shutdown <-chan struct{}) error {
+	gitPoll := time.NewTimer(r.interval)
+	for {
+		select {
+		case <-shutdown:
+			if !gitPoll.Stop() {
+				<-gitPoll.C
+			}
+			return nil
+		case <-gitPoll.C:
+			r.Notify()
+		case <-r.notify:
+			if !gitPoll.Stop() {
+				select {
+				case <-gitPoll.C:
+				default:
+				}
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+			err := r.Refresh(ctx)
+			cancel()
+			if err != nil {
+				return err
+			}
+			gitPoll.Reset(r.interval)
+		}
+	}
+}
+
+// fetch gets updated refs, and associated objects, from the upstream.
+// It must be called with the lock held.
+func (r *Repo) fetch(ctx context.Context) error {
+	env, cleanup, err := r.remoteEnv(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	start := time.Now()
+	err = fetch(ctx, r.dir, "origin", r.shallow, env)
+	observeFetch(r.origin.URL, start, err)
+	if err != nil {
+		return err
+	}
+	r.lastFetch = time.Now()
+	return nil
+}
+
+// workingClone makes a non-bare clone, at `ref` (probably a branch),
+// and returns the filesystem path to it.
+func (r *Repo) workingClone(ctx context.Context, ref string) (string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if err := r.errorIfNotReady(); err != nil {
+		return "", err
+	}
+	working, err := ioutil.TempDir(os.TempDir(), "flux-working")
+	if err != nil {
+		return "", err
+	}
+	path, err := clone(ctx, working, r.dir, ref)
+	if err != nil {
+		os.RemoveAll(working)
+	}
+	return path, err
+}
@@ -0,0 +1,7 @@
+package git
+
+// Remote points at a git repo somewhere.
+type Remote struct {
+	// URL is where we clone from
+	URL string `json:"url"`
+}
@@ -9,14 +9,14 @@ type Client interface {
 	UpgradeFromPath(chartPath string, releaseName string, values []byte, opts UpgradeOptions) (*Release, error)
 	History(releaseName string, opts HistoryOptions) ([]*Release, error)
 	Rollback(releaseName string, opts RollbackOptions) (*Release, error)
+	MarkFailed(releaseName string, opts MarkFailedOptions) error
 	Test(releaseName string, opts TestOptions) error
 	DependencyUpdate(chartPath string) error
 	RepositoryIndex() error
-	RepositoryAdd(name, url, username, password, certFile, keyFile, caFile string) error
-	RepositoryRemove(name string) error
+	RepositoryFetchIndex(url string, opts RepositoryOptions) (*RepositoryIndex, error)
 	RepositoryImport(path string) error
 	Pull(ref, version, dest string) (string, error)
-	PullWithRepoURL(repoURL, name, version, dest string) (string, error)
+	PullWithRepoURL(repoURL, name, version, dest string, opts PullOptions) (string, error)
 	Uninstall(releaseName string, opts UninstallOptions) error
 	GetChartRevision(chartPath string) (string, error)
 	Version() string
@@ -42,3 +42,15 @@ func (cs *Clients) Load(version string) (Client, bool) {
 	}
 	return c, true
 }
+
+// Range calls f sequentially for each version and client in the
+// storage. If f returns false, range stops the iteration.
+func (cs *Clients) Range(f func(version string, client Client) bool) {
+	cs.sm.Range(func(k, v interface{}) bool {
+		c, ok := v.(Client)
+		if !ok {
+			return true
+		}
+		return f(k.(string), c)
+	})
+}
@@ -0,0 +1,54 @@
+package helm
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	LabelRepository = "repository"
+	LabelSuccess    = "success"
+)
+
+var (
+	indexDownloadDuration = prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+		Namespace: "flux",
+		Subsystem: "helm_operator",
+		Name:      "repository_index_download_duration_seconds",
+		Help:      "Duration of downloading the index of a Helm repository in seconds.",
+		Buckets:   []float64{0.1, 0.5, 1, 5, 10, 30, 60},
+	}, []string{LabelRepository, LabelSuccess})
+)
+
+// ObserveIndexDownload records the duration of downloading the index
+// of the Helm repository at the given URL.
+func ObserveIndexDownload(repoURL string, start time.Time, err error) {
+	indexDownloadDuration.With(
+		LabelRepository, RedactRepoURL(repoURL),
+		LabelSuccess, fmt.Sprint(err == nil),
+	).Observe(time.Since(start).Seconds())
+}
+
+// RedactRepoURL returns the given repository URL without user
+// information, query string and fragment, and with a trailing slash
+// like `v1.RepoChartSource.CleanRepoURL`, so that it can be used as a
+// label value without exposing credentials.
+func RedactRepoURL(repoURL string) string {
+	u, err := url.Parse(repoURL)
+	if err != nil {
+		// The URL may hold credentials in a form we can not parse,
+		// do not risk exposing them.
+		return ""
+	}
+	u.User = nil
+	u.RawQuery = ""
+	u.Fragment = ""
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/"
+	u.RawPath = ""
+	return u.String()
+}
@@ -1,14 +1,20 @@
 package helm
 
-import "time"
+import (
+	"context"
+	"time"
+
+	"github.com/fluxcd/helm-operator/pkg/objectstorage"
+)
 
 // GetOptions holds the options available for Helm get
 // operations, the version implementation _must_ implement all
 // fields supported by that version but can (silently) ignore
 // unsupported set values.
 type GetOptions struct {
-	Namespace string
-	Version   int
+	Namespace     string
+	Version       int
+	StorageDriver string
 }
 
 // StatusOptions holds the options available for Helm status
@@ -16,8 +22,9 @@ type GetOptions struct {
 // fields supported by that version but can (silently) ignore
 // unsupported set values.
 type StatusOptions struct {
-	Namespace string
-	Version   int
+	Namespace     string
+	Version       int
+	StorageDriver string
 }
 
 // UpgradeOptions holds the options available for Helm upgrade
@@ -25,21 +32,25 @@ type StatusOptions struct {
 // fields supported by that version but can (silently) ignore
 // unsupported set values.
 type UpgradeOptions struct {
-	Namespace    string
-	Timeout      time.Duration
-	Wait         bool
-	Install      bool
-	DisableHooks bool
-	DryRun       bool
-	ClientOnly   bool
-	Force        bool
-	ResetValues  bool
-	SkipCRDs     bool
-	ReuseValues  bool
-	Recreate     bool
-	MaxHistory   int
-	Atomic       bool
+	Namespace         string
+	Timeout           time.Duration
+	Wait              bool
+	Install           bool
+	DisableHooks      bool
+	DryRun            bool
+	ClientOnly        bool
+	Force             bool
+	ResetValues       bool
+	SkipCRDs          bool
+	ReuseValues       bool
+	Recreate          bool
+	MaxHistory        int
+	Atomic            bool
 	DisableValidation bool
+	StorageDriver     string
+	// Context cancels the operation when done, which marks the
+	// release as failed instead of leaving it in a pending state.
+	Context context.Context
 }
 
 // RollbackOptions holds the options available for Helm rollback
@@ -47,14 +58,26 @@ type UpgradeOptions struct {
 // fields supported by that version but can (silently) ignore
 // unsupported set values.
 type RollbackOptions struct {
-	Namespace    string
-	Version      int
-	Timeout      time.Duration
-	Wait         bool
-	DisableHooks bool
-	DryRun       bool
-	Recreate     bool
-	Force        bool
+	Namespace     string
+	Version       int
+	Timeout       time.Duration
+	Wait          bool
+	DisableHooks  bool
+	DryRun        bool
+	Recreate      bool
+	Force         bool
+	StorageDriver string
+}
+
+// MarkFailedOptions holds the options available for marking a Helm
+// release revision as failed, the version implementation _must_
+// implement all fields supported by that version but can (silently)
+// ignore unsupported set values.
+type MarkFailedOptions struct {
+	Namespace     string
+	Version       int
+	Description   string
+	StorageDriver string
 }
 
 // TestOptions holds the options available for Helm test
@@ -62,9 +85,10 @@ type RollbackOptions struct {
 // fields supported by that version but can (silently) ignore
 // unsupported set values.
 type TestOptions struct {
-	Namespace string
-	Cleanup   bool
-	Timeout   time.Duration
+	Namespace     string
+	Cleanup       bool
+	Timeout       time.Duration
+	StorageDriver string
 }
 
 // UninstallOptions holds the options available for Helm uninstall
@@ -72,11 +96,12 @@ type TestOptions struct {
 // fields supported by that version but can (silently) ignore
 // unsupported set values.
 type UninstallOptions struct {
-	Namespace    string
-	DisableHooks bool
-	DryRun       bool
-	KeepHistory  bool
-	Timeout      time.Duration
+	Namespace     string
+	DisableHooks  bool
+	DryRun        bool
+	KeepHistory   bool
+	Timeout       time.Duration
+	StorageDriver string
 }
 
 // HistoryOption holds the options available for Helm history
@@ -85,5 +110,77 @@ type UninstallOptions struct {
 // unsupported set values.
 type HistoryOptions struct {
 	Namespace string
-	Max       int
+	// Max is the maximum number of revisions to return, zero means
+	// all revisions.
+	Max           int
+	StorageDriver string
+}
+
+// PullOptions holds the options available for Helm pull
+// operations, the version implementation _must_ implement all
+// fields supported by that version but can (silently) ignore
+// unsupported set values. When any of the credentials is set, they
+// are used instead of the credentials of an imported repository.
+type PullOptions struct {
+	Username string
+	Password string
+	CertFile string
+	KeyFile  string
+	CAFile   string
+	Token    string
+	// Endpoint and Region are the endpoint of the S3 compatible
+	// service and the region of the bucket, for repositories
+	// hosted in object storage.
+	Endpoint string
+	Region   string
+	// Provenance also downloads the provenance file of the chart,
+	// to `<chart>.prov` next to the chart, without verifying it.
+	Provenance bool
+}
+
+// HasCredentials returns true if any of the credentials is set.
+func (o PullOptions) HasCredentials() bool {
+	return o.Username != "" || o.Password != "" || o.CertFile != "" ||
+		o.KeyFile != "" || o.CAFile != "" || o.Token != ""
+}
+
+// ObjectStorageOptions returns the options for repositories hosted
+// in object storage, with the username and password as the access
+// key ID and secret access key.
+func (o PullOptions) ObjectStorageOptions() objectstorage.Options {
+	return objectstorage.Options{
+		AccessKeyID:     o.Username,
+		SecretAccessKey: o.Password,
+		Endpoint:        o.Endpoint,
+		Region:          o.Region,
+	}
+}
+
+// RepositoryOptions holds the options available for Helm repository
+// add operations, the version implementation _must_ implement all
+// fields supported by that version but can (silently) ignore
+// unsupported set values.
+type RepositoryOptions struct {
+	Username string
+	Password string
+	CertFile string
+	KeyFile  string
+	CAFile   string
+	// Endpoint and Region are the endpoint of the S3 compatible
+	// service and the region of the bucket, for repositories
+	// hosted in object storage.
+	Endpoint string
+	Region   string
+}
+
+// ObjectStorageOptions returns the options for repositories hosted
+// in object storage, with the username and password as the access
+// key ID and secret access key.
+func (o RepositoryOptions) ObjectStorageOptions() objectstorage.Options {
+	return objectstorage.Options{
+		AccessKeyID:     o.Username,
+		SecretAccessKey: o.Password,
+		Endpoint:        o.Endpoint,
+		Region:          o.Region,
+	}
 }
@@ -0,0 +1,56 @@
+package helm
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// RepositoryIndex describes the index of a chart repository
+type RepositoryIndex struct {
+	Generated time.Time
+	Charts    int
+}
+
+// DigestMismatchError is returned when the digest of a pulled chart
+// does not match the digest recorded in the index of the repository,
+// e.g. because the download was interrupted.
+type DigestMismatchError struct {
+	Chart    string
+	Expected string
+	Actual   string
+}
+
+func (err DigestMismatchError) Error() string {
+	return fmt.Sprintf("digest of chart %s (%s) does not match the digest in the repository index (%s)",
+		err.Chart, err.Actual, err.Expected)
+}
+
+// VerifyChartDigest compares the SHA-256 digest of the chart at the
+// given path with the given digest from the repository index. If the
+// digest does not match, the chart is removed. An empty digest is
+// ignored, as repositories are not required to record it.
+func VerifyChartDigest(path, digest string) error {
+	if digest == "" {
+		return nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	h := sha256.New()
+	_, err = io.Copy(h, f)
+	f.Close()
+	if err != nil {
+		return err
+	}
+	if actual := hex.EncodeToString(h.Sum(nil)); actual != digest {
+		os.Remove(path)
+		return DigestMismatchError{Chart: filepath.Base(path), Expected: digest, Actual: actual}
+	}
+	return nil
+}
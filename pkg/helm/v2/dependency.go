@@ -3,16 +3,21 @@ package v2
 import (
 	"k8s.io/helm/pkg/downloader"
 
+	"github.com/fluxcd/helm-operator/pkg/objectstorage"
 	"github.com/fluxcd/helm-operator/pkg/utils"
 )
 
 func (h *HelmV2) DependencyUpdate(chartPath string) error {
+	repositoryConfigLock.RLock()
+	repositories := configuredRepositories()
+	repositoryConfigLock.RUnlock()
+
 	out := utils.NewLogWriter(h.logger)
 	man := downloader.Manager{
 		Out:       out,
 		ChartPath: chartPath,
 		HelmHome:  helmHome(),
-		Getters:   getterProviders(),
+		Getters:   getterProvidersWithOptions(objectstorage.Options{}, repositories),
 	}
 	return man.Update()
 }
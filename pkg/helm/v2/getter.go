@@ -0,0 +1,46 @@
+package v2
+
+import (
+	"bytes"
+	"context"
+	"strings"
+
+	"k8s.io/helm/pkg/getter"
+	"k8s.io/helm/pkg/repo"
+
+	"github.com/fluxcd/helm-operator/pkg/objectstorage"
+)
+
+// objectStorageGetter is a getter.Getter for the `s3://` and `gs://`
+// URLs of chart repositories hosted in object storage buckets.
+type objectStorageGetter struct {
+	opts         objectstorage.Options
+	repositories []*repo.Entry
+}
+
+// newObjectStorageGetter returns a getter.Constructor for a getter
+// that uses the given options. Without credentials in the options,
+// the username and password of the given repository the URL belongs
+// to are used instead, as Helm does not expose them to getters other
+// than its own. The repositories are a snapshot of the repository
+// configuration taken by the caller, the getter never reads the
+// configuration itself as it is called by Helm both with and without
+// the repository configuration lock held.
+func newObjectStorageGetter(opts objectstorage.Options, repositories []*repo.Entry) getter.Constructor {
+	return func(_, _, _, _ string) (getter.Getter, error) {
+		return &objectStorageGetter{opts: opts, repositories: repositories}, nil
+	}
+}
+
+func (g *objectStorageGetter) Get(href string) (*bytes.Buffer, error) {
+	opts := g.opts
+	if !opts.HasCredentials() {
+		for _, entry := range g.repositories {
+			if strings.HasPrefix(href, strings.TrimSuffix(entry.URL, "/")+"/") {
+				opts.AccessKeyID, opts.SecretAccessKey = entry.Username, entry.Password
+				break
+			}
+		}
+	}
+	return objectstorage.Get(context.Background(), href, opts)
+}
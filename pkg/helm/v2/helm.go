@@ -16,9 +16,11 @@ import (
 	helmv2 "k8s.io/helm/pkg/helm"
 	"k8s.io/helm/pkg/helm/environment"
 	"k8s.io/helm/pkg/helm/helmpath"
+	"k8s.io/helm/pkg/repo"
 	"k8s.io/helm/pkg/tlsutil"
 
 	"github.com/fluxcd/helm-operator/pkg/helm"
+	"github.com/fluxcd/helm-operator/pkg/objectstorage"
 )
 
 const VERSION = "v2"
@@ -132,9 +134,17 @@ func tillerHost(kubeClient *kubernetes.Clientset, opts TillerOptions) (string, e
 	return fmt.Sprintf("%s:%s", opts.Host, opts.Port), nil
 }
 
-func getterProviders() getter.Providers {
-	return getter.All(environment.EnvSettings{
+// getterProvidersWithOptions returns the getter providers, with the
+// getter for object storage using the given options, and the
+// credentials of the given repositories if the options hold none. The
+// getters of plugins take precedence over the object storage getter,
+// so that installed plugins for the same schemes keep working.
+func getterProvidersWithOptions(opts objectstorage.Options, repositories []*repo.Entry) getter.Providers {
+	return append(getter.All(environment.EnvSettings{
 		Home: helmHome(),
+	}), getter.Provider{
+		Schemes: objectstorage.Schemes,
+		New:     newObjectStorageGetter(opts, repositories),
 	})
 }
 
@@ -0,0 +1,13 @@
+package v2
+
+import (
+	"errors"
+
+	"github.com/fluxcd/helm-operator/pkg/helm"
+)
+
+// MarkFailed is not supported for Helm 2, as Tiller does not allow
+// updating the status of a release revision.
+func (h *HelmV2) MarkFailed(releaseName string, opts helm.MarkFailedOptions) error {
+	return errors.New("marking a release as failed is not supported for Helm 2")
+}
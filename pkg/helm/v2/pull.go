@@ -1,18 +1,30 @@
 package v2
 
 import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/url"
 	"os"
+	"path"
 	"path/filepath"
 	"sync"
 
 	"k8s.io/helm/pkg/downloader"
+	"k8s.io/helm/pkg/getter"
 	"k8s.io/helm/pkg/repo"
 	"k8s.io/helm/pkg/urlutil"
 
+	"github.com/fluxcd/helm-operator/pkg/helm"
+	"github.com/fluxcd/helm-operator/pkg/objectstorage"
 	"github.com/fluxcd/helm-operator/pkg/utils"
 )
 
 func (h *HelmV2) Pull(ref, version, dest string) (string, error) {
+	return h.pull(ref, version, dest, downloader.VerifyNever)
+}
+
+func (h *HelmV2) pull(ref, version, dest string, verify downloader.VerificationStrategy) (string, error) {
 	repositoryConfigLock.RLock()
 	defer repositoryConfigLock.RUnlock()
 
@@ -20,14 +32,18 @@ func (h *HelmV2) Pull(ref, version, dest string) (string, error) {
 	c := downloader.ChartDownloader{
 		Out:      out,
 		HelmHome: helmHome(),
-		Verify:   downloader.VerifyNever,
-		Getters:  getterProviders(),
+		Verify:   verify,
+		Getters:  getterProvidersWithOptions(objectstorage.Options{}, configuredRepositories()),
 	}
 	d, _, err := c.DownloadTo(ref, version, dest)
 	return d, err
 }
 
-func (h *HelmV2) PullWithRepoURL(repoURL, name, version, dest string) (string, error) {
+func (h *HelmV2) PullWithRepoURL(repoURL, name, version, dest string, opts helm.PullOptions) (string, error) {
+	if opts.HasCredentials() || opts.Endpoint != "" || opts.Region != "" {
+		return h.pullWithCredentials(repoURL, name, version, dest, opts)
+	}
+
 	// This first attempts to look up the repository name by the given
 	// `repoURL`, if found the repository name and given chart name
 	// are used to construct a `chartRef` Helm understands.
@@ -46,23 +62,28 @@ func (h *HelmV2) PullWithRepoURL(repoURL, name, version, dest string) (string, e
 
 	// Here we attempt to find an entry for the repository. If found the
 	// entry's name is used to construct a `chartRef` Helm understands.
-	var chartRef string
+	var chartRef, indexFile string
 	for _, entry := range repoFile.Repositories {
 		if urlutil.Equal(repoURL, entry.URL) {
 			chartRef = entry.Name + "/" + name
+			indexFile = entry.Cache
+			if !filepath.IsAbs(indexFile) {
+				indexFile = filepath.Join(repositoryCache, indexFile)
+			}
 			// Ensure we have the repository index as this is
 			// later used by Helm.
-			if r, err := repo.NewChartRepository(entry, getterProviders()); err == nil {
-				r.DownloadIndexFile(repositoryCache)
+			if r, err := newChartRepository(entry); err == nil {
+				downloadIndexFile(r, repositoryCache)
 			}
 			break
 		}
 	}
 
+	var cv *repo.ChartVersion
 	if chartRef == "" {
 		// We were unable to find an entry so we need to make a request
 		// to the repository to get the absolute URL of the chart.
-		chartRef, err = repo.FindChartInRepoURL(repoURL, name, version, "", "", "", getterProviders())
+		cv, chartRef, err = findChartInRepoURL(repoURL, name, version, opts)
 		if err != nil {
 			return "", err
 		}
@@ -77,14 +98,151 @@ func (h *HelmV2) PullWithRepoURL(repoURL, name, version, dest string) (string, e
 		}
 	}
 
-	return h.Pull(chartRef, version, dest)
+	d, err := h.pull(chartRef, version, dest, verifyStrategy(opts))
+	if err != nil {
+		return d, err
+	}
+	if cv == nil {
+		// The chart was pulled from a configured repository, Helm
+		// did resolve it using the cached index of the repository.
+		index, err := repo.LoadIndexFile(indexFile)
+		if err != nil {
+			return d, err
+		}
+		if cv, err = index.Get(name, version); err != nil {
+			return d, err
+		}
+	}
+	return d, helm.VerifyChartDigest(d, cv.Digest)
+}
+
+// pullWithCredentials resolves the absolute URL of the chart by
+// making a request to the given `repoURL`, and downloads the chart
+// from this URL to the given destination, using the credentials from
+// the given options for both requests.
+func (h *HelmV2) pullWithCredentials(repoURL, name, version, dest string, opts helm.PullOptions) (string, error) {
+	if opts.Token != "" {
+		return "", errors.New("bearer token authentication is not supported by Helm v2")
+	}
+
+	cv, chartURL, err := findChartInRepoURL(repoURL, name, version, opts)
+	if err != nil {
+		return "", err
+	}
+	u, err := url.Parse(chartURL)
+	if err != nil {
+		return "", err
+	}
+
+	g, err := newGetterWithCredentials(chartURL, opts)
+	if err != nil {
+		return "", err
+	}
+	data, err := g.Get(chartURL)
+	if err != nil {
+		return "", err
+	}
+
+	destfile := filepath.Join(dest, path.Base(u.Path))
+	if err := ioutil.WriteFile(destfile, data.Bytes(), 0644); err != nil {
+		return "", err
+	}
+	if opts.Provenance {
+		// A missing provenance file is left for the caller to detect,
+		// as the downloader does as well.
+		if prov, err := g.Get(chartURL + ".prov"); err == nil {
+			if err := ioutil.WriteFile(destfile+".prov", prov.Bytes(), 0644); err != nil {
+				return "", err
+			}
+		}
+	}
+	return destfile, helm.VerifyChartDigest(destfile, cv.Digest)
+}
+
+// newGetterWithCredentials returns a getter for the scheme of the
+// given URL, using the credentials from the given options.
+func newGetterWithCredentials(href string, opts helm.PullOptions) (getter.Getter, error) {
+	u, err := url.Parse(href)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme == "http" || u.Scheme == "https" {
+		g, err := getter.NewHTTPGetter(href, opts.CertFile, opts.KeyFile, opts.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		g.SetCredentials(opts.Username, opts.Password)
+		return g, nil
+	}
+	constructor, err := getterProvidersWithOptions(opts.ObjectStorageOptions(), nil).ByScheme(u.Scheme)
+	if err != nil {
+		return nil, err
+	}
+	return constructor(href, opts.CertFile, opts.KeyFile, opts.CAFile)
+}
+
+// findChartInRepoURL finds the chart in the repository index at the
+// given `repoURL`, without adding the repository to the repository
+// configuration. It returns the index entry of the chart and the
+// absolute URL to the chart. The credentials from the given options
+// are used to fetch the index.
+func findChartInRepoURL(repoURL, name, version string, opts helm.PullOptions) (*repo.ChartVersion, string, error) {
+	tmpIndexFile, err := ioutil.TempFile("", "repository-index-")
+	if err != nil {
+		return nil, "", err
+	}
+	tmpIndexFile.Close()
+	defer os.Remove(tmpIndexFile.Name())
+
+	r, err := repo.NewChartRepository(&repo.Entry{
+		URL:      repoURL,
+		Cache:    tmpIndexFile.Name(),
+		Username: opts.Username,
+		Password: opts.Password,
+		CertFile: opts.CertFile,
+		KeyFile:  opts.KeyFile,
+		CAFile:   opts.CAFile,
+	}, getterProvidersWithOptions(opts.ObjectStorageOptions(), nil))
+	if err != nil {
+		return nil, "", err
+	}
+	if err := downloadIndexFile(r, ""); err != nil {
+		return nil, "", fmt.Errorf("looks like %q is not a valid chart repository or cannot be reached: %w", repoURL, err)
+	}
+	index, err := repo.LoadIndexFile(tmpIndexFile.Name())
+	if err != nil {
+		return nil, "", err
+	}
+
+	cv, err := index.Get(name, version)
+	if err != nil {
+		return nil, "", fmt.Errorf("chart %q version %q not found in %s repository", name, version, repoURL)
+	}
+	if len(cv.URLs) == 0 {
+		return nil, "", fmt.Errorf("chart %q version %q has no downloadable URLs", name, version)
+	}
+	chartURL, err := repo.ResolveReferenceURL(repoURL, cv.URLs[0])
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to make chart URL absolute: %w", err)
+	}
+	return cv, chartURL, nil
+}
+
+// verifyStrategy returns the strategy for the downloader to fetch
+// the provenance file if requested by the given options. The chart
+// is never verified by the downloader, this is left to the caller.
+func verifyStrategy(opts helm.PullOptions) downloader.VerificationStrategy {
+	if opts.Provenance {
+		return downloader.VerifyLater
+	}
+	return downloader.VerifyNever
 }
 
 func downloadMissingRepositoryIndexes(repositories []*repo.Entry) error {
 
 	var wg sync.WaitGroup
 	for _, c := range repositories {
-		r, err := repo.NewChartRepository(c, getterProviders())
+		r, err := newChartRepository(c)
 		if err != nil {
 			return err
 		}
@@ -95,7 +253,7 @@ func downloadMissingRepositoryIndexes(repositories []*repo.Entry) error {
 				f = filepath.Join(repositoryCache, f)
 			}
 			if _, err := os.Stat(f); os.IsNotExist(err) {
-				r.DownloadIndexFile(repositoryCache)
+				downloadIndexFile(r, repositoryCache)
 			}
 			wg.Done()
 		}(r)
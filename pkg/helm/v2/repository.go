@@ -1,13 +1,17 @@
 package v2
 
 import (
-	"fmt"
+	"io/ioutil"
 	"os"
 	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 
 	"k8s.io/helm/pkg/repo"
+
+	"github.com/fluxcd/helm-operator/pkg/helm"
+	"github.com/fluxcd/helm-operator/pkg/objectstorage"
 )
 
 var repositoryConfigLock sync.RWMutex
@@ -22,13 +26,13 @@ func (h *HelmV2) RepositoryIndex() error {
 
 	var wg sync.WaitGroup
 	for _, c := range f.Repositories {
-		r, err := repo.NewChartRepository(c, getterProviders())
+		r, err := newChartRepository(c)
 		if err != nil {
 			return err
 		}
 		wg.Add(1)
 		go func(r *repo.ChartRepository) {
-			if err := r.DownloadIndexFile(repositoryCache); err != nil {
+			if err := downloadIndexFile(r, repositoryCache); err != nil {
 				h.logger.Log("error", "unable to get an update from the chart repository", "url", r.Config.URL, "err", err)
 			}
 			wg.Done()
@@ -38,51 +42,37 @@ func (h *HelmV2) RepositoryIndex() error {
 	return nil
 }
 
-func (h *HelmV2) RepositoryAdd(name, url, username, password, certFile, keyFile, caFile string) error {
-	repositoryConfigLock.Lock()
-	defer repositoryConfigLock.Unlock()
-
-	f, err := loadRepositoryConfig()
+// RepositoryFetchIndex fetches the index of the chart repository at
+// the given URL with the credentials from the given options, without
+// adding it to the repository configuration.
+func (h *HelmV2) RepositoryFetchIndex(url string, opts helm.RepositoryOptions) (*helm.RepositoryIndex, error) {
+	tmpIndexFile, err := ioutil.TempFile("", "repository-index-")
 	if err != nil {
-		return err
-	}
-	if f.Has(name) {
-		return fmt.Errorf("chart repository with name '%s' already exists", name)
+		return nil, err
 	}
+	tmpIndexFile.Close()
+	defer os.Remove(tmpIndexFile.Name())
 
-	c := &repo.Entry{
-		Name:     name,
+	r, err := repo.NewChartRepository(&repo.Entry{
 		URL:      url,
-		Username: username,
-		Password: password,
-		CertFile: certFile,
-		KeyFile:  keyFile,
-		CAFile:   caFile,
-	}
-	f.Add(c)
-
-	r, err := repo.NewChartRepository(c, getterProviders())
+		Cache:    tmpIndexFile.Name(),
+		Username: opts.Username,
+		Password: opts.Password,
+		CertFile: opts.CertFile,
+		KeyFile:  opts.KeyFile,
+		CAFile:   opts.CAFile,
+	}, getterProvidersWithOptions(opts.ObjectStorageOptions(), nil))
 	if err != nil {
-		return err
+		return nil, err
 	}
-	if err = r.DownloadIndexFile(repositoryCache); err != nil {
-		return err
+	if err := downloadIndexFile(r, ""); err != nil {
+		return nil, err
 	}
-
-	return f.WriteFile(repositoryConfig, 0644)
-}
-
-func (h *HelmV2) RepositoryRemove(name string) error {
-	repositoryConfigLock.Lock()
-	defer repositoryConfigLock.Unlock()
-
-	f, err := repo.LoadRepositoriesFile(repositoryConfig)
+	index, err := repo.LoadIndexFile(tmpIndexFile.Name())
 	if err != nil {
-		return err
+		return nil, err
 	}
-	f.Remove(name)
-
-	return f.WriteFile(repositoryConfig, 0644)
+	return &helm.RepositoryIndex{Generated: index.Generated, Charts: len(index.Entries)}, nil
 }
 
 func (h *HelmV2) RepositoryImport(path string) error {
@@ -104,12 +94,12 @@ func (h *HelmV2) RepositoryImport(path string) error {
 			h.logger.Log("error", "repository with name already exists", "name", c.Name, "url", c.URL)
 			continue
 		}
-		r, err := repo.NewChartRepository(c, getterProviders())
+		r, err := newChartRepository(c)
 		if err != nil {
 			h.logger.Log("error", err, "name", c.Name, "url", c.URL)
 			continue
 		}
-		if err := r.DownloadIndexFile(repositoryCache); err != nil {
+		if err := downloadIndexFile(r, repositoryCache); err != nil {
 			h.logger.Log("error", err, "name", c.Name, "url", c.URL)
 			continue
 		}
@@ -121,6 +111,27 @@ func (h *HelmV2) RepositoryImport(path string) error {
 	return t.WriteFile(repositoryConfig, 0644)
 }
 
+// newChartRepository constructs a new `repo.ChartRepository` for the
+// given `repo.Entry`, with the getter for object storage using the
+// credentials of the entry.
+func newChartRepository(e *repo.Entry) (*repo.ChartRepository, error) {
+	return repo.NewChartRepository(e, getterProvidersWithOptions(objectstorage.Options{
+		AccessKeyID:     e.Username,
+		SecretAccessKey: e.Password,
+	}, nil))
+}
+
+// configuredRepositories returns a snapshot of the repositories in
+// the repository configuration, for the getter for object storage.
+// The caller must hold the repository configuration lock.
+func configuredRepositories() []*repo.Entry {
+	f, err := loadRepositoryConfig()
+	if err != nil || f == nil {
+		return nil
+	}
+	return f.Repositories
+}
+
 func loadRepositoryConfig() (*repo.RepoFile, error) {
 	r, err := repo.LoadRepositoriesFile(repositoryConfig)
 	if err != nil && !os.IsNotExist(errors.Cause(err)) {
@@ -128,3 +139,12 @@ func loadRepositoryConfig() (*repo.RepoFile, error) {
 	}
 	return r, nil
 }
+
+// downloadIndexFile downloads the index of the given repository to
+// the given cache path, and records the duration of the download.
+func downloadIndexFile(r *repo.ChartRepository, cachePath string) error {
+	start := time.Now()
+	err := r.DownloadIndexFile(cachePath)
+	helm.ObserveIndexDownload(r.Config.URL, start, err)
+	return err
+}
@@ -8,6 +8,7 @@ import (
 
 	"helm.sh/helm/v3/pkg/downloader"
 
+	"github.com/fluxcd/helm-operator/pkg/objectstorage"
 	"github.com/fluxcd/helm-operator/pkg/utils"
 )
 
@@ -17,13 +18,17 @@ func (h *HelmV3) DependencyUpdate(chartPath string) error {
 	// a safe guard time offset to not touch any files in
 	// use.
 	garbageCollect(repositoryCache, time.Second * 300)
+	repositoryConfigLock.RLock()
+	repositories := configuredRepositories()
+	repositoryConfigLock.RUnlock()
+
 	out := utils.NewLogWriter(h.logger)
 	man := &downloader.Manager{
 		Out:              out,
 		ChartPath:        chartPath,
 		RepositoryConfig: repositoryConfig,
 		RepositoryCache:  repositoryCache,
-		Getters:          getterProviders(),
+		Getters:          getterProvidersWithOptions(objectstorage.Options{}, repositories),
 	}
 	return man.Update()
 }
@@ -0,0 +1,109 @@
+package v3
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"helm.sh/helm/v3/pkg/getter"
+	"helm.sh/helm/v3/pkg/repo"
+	"k8s.io/helm/pkg/tlsutil"
+
+	"github.com/fluxcd/helm-operator/pkg/objectstorage"
+)
+
+// bearerTokenGetter is a getter.Getter for HTTP(S) URLs that
+// authenticates using a bearer token, as the HTTP getter of Helm
+// only supports basic auth.
+type bearerTokenGetter struct {
+	client *http.Client
+	host   string
+	token  string
+}
+
+// newBearerTokenGetter returns a getter.Constructor for a getter
+// that authenticates with the given token to the host of the given
+// repository URL, and uses the given certificate, key and CA files
+// for TLS (if set).
+func newBearerTokenGetter(repoURL, token, certFile, keyFile, caFile string) getter.Constructor {
+	return func(...getter.Option) (getter.Getter, error) {
+		u, err := url.Parse(repoURL)
+		if err != nil {
+			return nil, err
+		}
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		if certFile != "" || keyFile != "" || caFile != "" {
+			tlsConfig, err := tlsutil.NewClientTLS(certFile, keyFile, caFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create TLS configuration: %w", err)
+			}
+			transport.TLSClientConfig = tlsConfig
+		}
+		return &bearerTokenGetter{client: &http.Client{Transport: transport}, host: u.Host, token: token}, nil
+	}
+}
+
+// Get performs a GET request to the given URL, and returns the body
+// of the response. The bearer token is only sent if the host of the
+// URL equals to the host of the repository, so that it is not leaked
+// to other services.
+func (g *bearerTokenGetter) Get(href string, _ ...getter.Option) (*bytes.Buffer, error) {
+	req, err := http.NewRequest(http.MethodGet, href, nil)
+	if err != nil {
+		return nil, err
+	}
+	if req.URL.Host == g.host {
+		req.Header.Set("Authorization", "Bearer "+g.token)
+	}
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch %s : %s", href, resp.Status)
+	}
+
+	buf := bytes.NewBuffer(nil)
+	_, err = io.Copy(buf, resp.Body)
+	return buf, err
+}
+
+// objectStorageGetter is a getter.Getter for the `s3://` and `gs://`
+// URLs of chart repositories hosted in object storage buckets.
+type objectStorageGetter struct {
+	opts         objectstorage.Options
+	repositories []*repo.Entry
+}
+
+// newObjectStorageGetter returns a getter.Constructor for a getter
+// that uses the given options. Without credentials in the options,
+// the username and password of the given repository the URL belongs
+// to are used instead, as Helm does not expose them to getters other
+// than its own. The repositories are a snapshot of the repository
+// configuration taken by the caller, the getter never reads the
+// configuration itself as it is called by Helm both with and without
+// the repository configuration lock held.
+func newObjectStorageGetter(opts objectstorage.Options, repositories []*repo.Entry) getter.Constructor {
+	return func(...getter.Option) (getter.Getter, error) {
+		return &objectStorageGetter{opts: opts, repositories: repositories}, nil
+	}
+}
+
+func (g *objectStorageGetter) Get(href string, _ ...getter.Option) (*bytes.Buffer, error) {
+	opts := g.opts
+	if !opts.HasCredentials() {
+		for _, entry := range g.repositories {
+			if strings.HasPrefix(href, strings.TrimSuffix(entry.URL, "/")+"/") {
+				opts.AccessKeyID, opts.SecretAccessKey = entry.Username, entry.Password
+				break
+			}
+		}
+	}
+	return objectstorage.Get(context.Background(), href, opts)
+}
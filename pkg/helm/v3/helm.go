@@ -2,6 +2,7 @@ package v3
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/go-kit/kit/log"
 
@@ -17,10 +18,12 @@ import (
 	"helm.sh/helm/v3/pkg/getter"
 	"helm.sh/helm/v3/pkg/helmpath"
 	"helm.sh/helm/v3/pkg/kube"
+	"helm.sh/helm/v3/pkg/repo"
 	"helm.sh/helm/v3/pkg/storage"
 	"helm.sh/helm/v3/pkg/storage/driver"
 
 	"github.com/fluxcd/helm-operator/pkg/helm"
+	"github.com/fluxcd/helm-operator/pkg/objectstorage"
 )
 
 const VERSION = "v3"
@@ -34,26 +37,65 @@ var (
 type HelmOptions struct {
 	Driver    string
 	Namespace string
+	// SQLConnectionString is the connection string for the SQL
+	// storage driver.
+	SQLConnectionString string
+	// PluginsDir is the directory plugins are loaded from. As the
+	// getters are shared by all clients, it applies to all of them.
+	PluginsDir string
 }
 
 type HelmV3 struct {
 	kubeConfig *rest.Config
 	logger     log.Logger
+
+	// driver is the storage driver used for releases that do not
+	// specify one.
+	driver              string
+	sqlConnectionString string
+	// sqlDrivers holds the SQL storage drivers per namespace, so
+	// that the database connection is reused. The lock is held while
+	// a driver is created, as the driver can not be closed and a
+	// concurrently created driver would leak its connection pool.
+	sqlDriversMu sync.Mutex
+	sqlDrivers   map[string]*driver.SQL
 }
 
 type infoLogFunc func(string, ...interface{})
 
-// New creates a new HelmV3 client
-func New(logger log.Logger, kubeConfig *rest.Config) helm.Client {
+// New creates a new HelmV3 client, using the storage driver from the
+// given options for releases that do not specify one.
+func New(logger log.Logger, kubeConfig *rest.Config, opts HelmOptions) helm.Client {
 	// Add CRDs to the scheme. They are missing by default but required
 	// by Helm v3.
 	if err := apiextv1beta1.AddToScheme(scheme.Scheme); err != nil {
 		// This should never happen.
 		panic(err)
 	}
+	if opts.PluginsDir != "" {
+		pluginsDir = opts.PluginsDir
+	}
 	return &HelmV3{
-		kubeConfig: kubeConfig,
-		logger:     logger,
+		kubeConfig:          kubeConfig,
+		logger:              logger,
+		driver:              opts.Driver,
+		sqlConnectionString: opts.SQLConnectionString,
+		sqlDrivers:          make(map[string]*driver.SQL),
+	}
+}
+
+// ValidateStorageDriver returns an error if the given storage driver
+// is not supported. The 'memory' driver is not supported, as the
+// release information would not survive between syncs, which would
+// result in an install on every sync.
+func ValidateStorageDriver(d string) error {
+	switch d {
+	case "secret", "secrets", "configmap", "configmaps", "sql", "":
+		return nil
+	case "memory":
+		return fmt.Errorf("storage driver 'memory' is not supported, as the release information would be lost between syncs")
+	default:
+		return fmt.Errorf("unsupported storage driver '%s'", d)
 	}
 }
 
@@ -70,9 +112,13 @@ func (h *HelmV3) infoLogFunc(namespace string, releaseName string) infoLogFunc {
 	}
 }
 
-func newActionConfig(config *rest.Config, logFunc infoLogFunc, namespace, driver string) (*action.Configuration, error) {
+// newActionConfig returns a new action configuration for the given
+// namespace and release, that stores the release information using
+// the given storage driver, or the default of the client if empty.
+func (h *HelmV3) newActionConfig(namespace, releaseName, driver string) (*action.Configuration, error) {
+	logFunc := h.infoLogFunc(namespace, releaseName)
 
-	restClientGetter := newConfigFlags(config, namespace)
+	restClientGetter := newConfigFlags(h.kubeConfig, namespace)
 	kubeClient := &kube.Client{
 		Factory: util.NewFactory(restClientGetter),
 		Log:     logFunc,
@@ -82,7 +128,10 @@ func newActionConfig(config *rest.Config, logFunc infoLogFunc, namespace, driver
 		return nil, err
 	}
 
-	store, err := newStorageDriver(client, logFunc, namespace, driver)
+	if driver == "" {
+		driver = h.driver
+	}
+	store, err := h.newStorageDriver(client, logFunc, namespace, driver)
 	if err != nil {
 		return nil, err
 	}
@@ -104,7 +153,10 @@ func newConfigFlags(config *rest.Config, namespace string) *genericclioptions.Co
 	}
 }
 
-func newStorageDriver(client *kubernetes.Clientset, logFunc infoLogFunc, namespace, d string) (*storage.Storage, error) {
+func (h *HelmV3) newStorageDriver(client *kubernetes.Clientset, logFunc infoLogFunc, namespace, d string) (*storage.Storage, error) {
+	if err := ValidateStorageDriver(d); err != nil {
+		return nil, err
+	}
 	switch d {
 	case "secret", "secrets", "":
 		s := driver.NewSecrets(client.CoreV1().Secrets(namespace))
@@ -114,18 +166,44 @@ func newStorageDriver(client *kubernetes.Clientset, logFunc infoLogFunc, namespa
 		c := driver.NewConfigMaps(client.CoreV1().ConfigMaps(namespace))
 		c.Log = logFunc
 		return storage.Init(c), nil
-	case "memory":
-		m := driver.NewMemory()
-		return storage.Init(m), nil
+	case "sql":
+		h.sqlDriversMu.Lock()
+		defer h.sqlDriversMu.Unlock()
+		if s, ok := h.sqlDrivers[namespace]; ok {
+			return storage.Init(s), nil
+		}
+		if h.sqlConnectionString == "" {
+			return nil, fmt.Errorf("no connection string configured for storage driver 'sql'")
+		}
+		// The SQL driver logs with the namespace only, as it is
+		// shared by all releases in the namespace.
+		s, err := driver.NewSQL(h.sqlConnectionString, h.infoLogFunc(namespace, ""), namespace)
+		if err != nil {
+			return nil, err
+		}
+		h.sqlDrivers[namespace] = s
+		return storage.Init(s), nil
 	default:
 		return nil, fmt.Errorf("unsupported storage driver '%s'", d)
 	}
 }
 
 func getterProviders() getter.Providers {
-	return getter.All(&cli.EnvSettings{
+	return getterProvidersWithOptions(objectstorage.Options{}, nil)
+}
+
+// getterProvidersWithOptions returns the getter providers, with the
+// getter for object storage using the given options, and the
+// credentials of the given repositories if the options hold none. The
+// getters of plugins take precedence over the object storage getter,
+// so that installed plugins for the same schemes keep working.
+func getterProvidersWithOptions(opts objectstorage.Options, repositories []*repo.Entry) getter.Providers {
+	return append(getter.All(&cli.EnvSettings{
 		RepositoryConfig: repositoryConfig,
 		RepositoryCache:  repositoryCache,
 		PluginsDirectory: pluginsDir,
+	}), getter.Provider{
+		Schemes: objectstorage.Schemes,
+		New:     newObjectStorageGetter(opts, repositories),
 	})
 }
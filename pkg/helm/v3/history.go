@@ -8,7 +8,7 @@ import (
 )
 
 func (h *HelmV3) History(releaseName string, opts helm.HistoryOptions) ([]*helm.Release, error) {
-	cfg, err := newActionConfig(h.kubeConfig, h.infoLogFunc(opts.Namespace, releaseName), opts.Namespace, "")
+	cfg, err := h.newActionConfig(opts.Namespace, releaseName, opts.StorageDriver)
 	if err != nil {
 		return nil, err
 	}
@@ -23,8 +23,12 @@ func (h *HelmV3) History(releaseName string, opts helm.HistoryOptions) ([]*helm.
 
 	releaseutil.Reverse(hist, releaseutil.SortByRevision)
 
+	max := len(hist)
+	if history.Max > 0 {
+		max = min(max, history.Max)
+	}
 	var rels []*helm.Release
-	for i := 0; i < min(len(hist), history.Max); i++ {
+	for i := 0; i < max; i++ {
 		rels = append(rels, releaseToGenericRelease(hist[i]))
 	}
 	return rels, nil
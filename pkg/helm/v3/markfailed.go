@@ -0,0 +1,24 @@
+package v3
+
+import (
+	"helm.sh/helm/v3/pkg/release"
+
+	"github.com/fluxcd/helm-operator/pkg/helm"
+)
+
+// MarkFailed sets the status of the given revision of the release
+// to failed in the release storage, without touching any of the
+// resources of the release.
+func (h *HelmV3) MarkFailed(releaseName string, opts helm.MarkFailedOptions) error {
+	cfg, err := h.newActionConfig(opts.Namespace, releaseName, opts.StorageDriver)
+	if err != nil {
+		return err
+	}
+
+	rel, err := cfg.Releases.Get(releaseName, opts.Version)
+	if err != nil {
+		return err
+	}
+	rel.SetStatus(release.StatusFailed, opts.Description)
+	return cfg.Releases.Update(rel)
+}
@@ -1,6 +1,8 @@
 package v3
 
 import (
+	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"sync"
@@ -8,29 +10,40 @@ import (
 	"k8s.io/helm/pkg/urlutil"
 
 	"helm.sh/helm/v3/pkg/downloader"
+	"helm.sh/helm/v3/pkg/getter"
 	"helm.sh/helm/v3/pkg/helmpath"
 	"helm.sh/helm/v3/pkg/repo"
 
+	"github.com/fluxcd/helm-operator/pkg/helm"
+	"github.com/fluxcd/helm-operator/pkg/objectstorage"
 	"github.com/fluxcd/helm-operator/pkg/utils"
 )
 
 func (h *HelmV3) Pull(ref, version, dest string) (string, error) {
+	return h.pull(ref, version, dest, downloader.VerifyNever)
+}
+
+func (h *HelmV3) pull(ref, version, dest string, verify downloader.VerificationStrategy) (string, error) {
 	repositoryConfigLock.RLock()
 	defer repositoryConfigLock.RUnlock()
 
 	out := utils.NewLogWriter(h.logger)
 	c := downloader.ChartDownloader{
 		Out:              out,
-		Verify:           downloader.VerifyNever,
+		Verify:           verify,
 		RepositoryConfig: repositoryConfig,
 		RepositoryCache:  repositoryCache,
-		Getters:          getterProviders(),
+		Getters:          getterProvidersWithOptions(objectstorage.Options{}, configuredRepositories()),
 	}
 	d, _, err := c.DownloadTo(ref, version, dest)
 	return d, err
 }
 
-func (h *HelmV3) PullWithRepoURL(repoURL, name, version, dest string) (string, error) {
+func (h *HelmV3) PullWithRepoURL(repoURL, name, version, dest string, opts helm.PullOptions) (string, error) {
+	if opts.HasCredentials() || opts.Endpoint != "" || opts.Region != "" {
+		return h.pullWithCredentials(repoURL, name, version, dest, opts)
+	}
+
 	// This first attempts to look up the repository name by the given
 	// `repoURL`, if found the repository name and given chart name
 	// are used to construct a `chartRef` Helm understands.
@@ -49,23 +62,25 @@ func (h *HelmV3) PullWithRepoURL(repoURL, name, version, dest string) (string, e
 
 	// Here we attempt to find an entry for the repository. If found the
 	// entry's name is used to construct a `chartRef` Helm understands.
-	var chartRef string
+	var chartRef, indexFile string
 	for _, entry := range repoFile.Repositories {
 		if urlutil.Equal(repoURL, entry.URL) {
 			chartRef = entry.Name + "/" + name
+			indexFile = filepath.Join(repositoryCache, helmpath.CacheIndexFile(entry.Name))
 			// Ensure we have the repository index as this is
 			// later used by Helm.
 			if r, err := newChartRepository(entry); err == nil {
-				r.DownloadIndexFile()
+				downloadIndexFile(r)
 			}
 			break
 		}
 	}
 
+	var cv *repo.ChartVersion
 	if chartRef == "" {
 		// We were unable to find an entry so we need to make a request
 		// to the repository to get the absolute URL of the chart.
-		chartRef, err = repo.FindChartInRepoURL(repoURL, name, version, "", "", "", getterProviders())
+		cv, chartRef, err = findChartInRepoURL(repoURL, name, version, opts, getterProviders())
 		if err != nil {
 			return "", err
 		}
@@ -79,7 +94,125 @@ func (h *HelmV3) PullWithRepoURL(repoURL, name, version, dest string) (string, e
 		}
 	}
 
-	return h.Pull(chartRef, version, dest)
+	d, err := h.pull(chartRef, version, dest, verifyStrategy(opts))
+	if err != nil {
+		return d, err
+	}
+	if cv == nil {
+		// The chart was pulled from a configured repository, Helm
+		// did resolve it using the cached index of the repository.
+		index, err := repo.LoadIndexFile(indexFile)
+		if err != nil {
+			return d, err
+		}
+		if cv, err = index.Get(name, version); err != nil {
+			return d, err
+		}
+	}
+	return d, helm.VerifyChartDigest(d, cv.Digest)
+}
+
+// pullWithCredentials resolves the absolute URL of the chart by
+// making a request to the given `repoURL`, and pulls the chart from
+// this URL, using the credentials from the given options for both
+// requests.
+func (h *HelmV3) pullWithCredentials(repoURL, name, version, dest string, opts helm.PullOptions) (string, error) {
+	getters := getterProvidersWithOptions(opts.ObjectStorageOptions(), nil)
+	if opts.Token != "" {
+		getters = append(getter.Providers{{
+			Schemes: []string{"http", "https"},
+			New:     newBearerTokenGetter(repoURL, opts.Token, opts.CertFile, opts.KeyFile, opts.CAFile),
+		}}, getters...)
+	}
+
+	cv, chartURL, err := findChartInRepoURL(repoURL, name, version, opts, getters)
+	if err != nil {
+		return "", err
+	}
+
+	repositoryConfigLock.RLock()
+	defer repositoryConfigLock.RUnlock()
+
+	c := downloader.ChartDownloader{
+		Out:              utils.NewLogWriter(h.logger),
+		Verify:           verifyStrategy(opts),
+		RepositoryConfig: repositoryConfig,
+		RepositoryCache:  repositoryCache,
+		Getters:          getters,
+	}
+	if opts.Username != "" || opts.Password != "" {
+		c.Options = append(c.Options, getter.WithBasicAuth(opts.Username, opts.Password))
+	}
+	if opts.CertFile != "" || opts.KeyFile != "" || opts.CAFile != "" {
+		c.Options = append(c.Options, getter.WithTLSClientConfig(opts.CertFile, opts.KeyFile, opts.CAFile))
+	}
+	d, _, err := c.DownloadTo(chartURL, version, dest)
+	if err != nil {
+		return d, err
+	}
+	return d, helm.VerifyChartDigest(d, cv.Digest)
+}
+
+// findChartInRepoURL finds the chart in the repository index at the
+// given `repoURL`, without adding the repository to the repository
+// configuration. It returns the index entry of the chart and the
+// absolute URL to the chart. The credentials from the given options
+// are used to fetch the index.
+func findChartInRepoURL(repoURL, name, version string, opts helm.PullOptions,
+	getters getter.Providers) (*repo.ChartVersion, string, error) {
+
+	// We use our own temporary directory for the index, as Helm
+	// would otherwise leave it behind in the repository cache.
+	tmpDir, err := ioutil.TempDir("", "repository-index-")
+	if err != nil {
+		return nil, "", err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	r, err := repo.NewChartRepository(&repo.Entry{
+		Name:     "index",
+		URL:      repoURL,
+		Username: opts.Username,
+		Password: opts.Password,
+		CertFile: opts.CertFile,
+		KeyFile:  opts.KeyFile,
+		CAFile:   opts.CAFile,
+	}, getters)
+	if err != nil {
+		return nil, "", err
+	}
+	r.CachePath = tmpDir
+	indexFile, err := downloadIndexFile(r)
+	if err != nil {
+		return nil, "", fmt.Errorf("looks like %q is not a valid chart repository or cannot be reached: %w", repoURL, err)
+	}
+	index, err := repo.LoadIndexFile(indexFile)
+	if err != nil {
+		return nil, "", err
+	}
+
+	cv, err := index.Get(name, version)
+	if err != nil {
+		return nil, "", fmt.Errorf("chart %q version %q not found in %s repository", name, version, repoURL)
+	}
+	if len(cv.URLs) == 0 {
+		return nil, "", fmt.Errorf("chart %q version %q has no downloadable URLs", name, version)
+	}
+	chartURL, err := repo.ResolveReferenceURL(repoURL, cv.URLs[0])
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to make chart URL absolute: %w", err)
+	}
+	return cv, chartURL, nil
+}
+
+// verifyStrategy returns the strategy for the downloader to fetch
+// the provenance file if requested by the given options. The chart
+// is never verified by the downloader, this is left to the caller.
+func verifyStrategy(opts helm.PullOptions) downloader.VerificationStrategy {
+	if opts.Provenance {
+		return downloader.VerifyLater
+	}
+	return downloader.VerifyNever
 }
 
 func downloadMissingRepositoryIndexes(repositories []*repo.Entry) error {
@@ -93,7 +226,7 @@ func downloadMissingRepositoryIndexes(repositories []*repo.Entry) error {
 		go func(r *repo.ChartRepository) {
 			f := filepath.Join(r.CachePath, helmpath.CacheIndexFile(r.Config.Name))
 			if _, err := os.Stat(f); os.IsNotExist(err) {
-				r.DownloadIndexFile()
+				downloadIndexFile(r)
 			}
 			wg.Done()
 		}(r)
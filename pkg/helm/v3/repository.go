@@ -1,11 +1,16 @@
 package v3
 
 import (
+	"io/ioutil"
 	"os"
 	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 	"helm.sh/helm/v3/pkg/repo"
+
+	"github.com/fluxcd/helm-operator/pkg/helm"
+	"github.com/fluxcd/helm-operator/pkg/objectstorage"
 )
 
 var repositoryConfigLock sync.RWMutex
@@ -26,7 +31,7 @@ func (h *HelmV3) RepositoryIndex() error {
 		}
 		wg.Add(1)
 		go func(r *repo.ChartRepository) {
-			if _, err := r.DownloadIndexFile(); err != nil {
+			if _, err := downloadIndexFile(r); err != nil {
 				h.logger.Log("error", "unable to get an update from the chart repository", "url", r.Config.URL, "err", err)
 			}
 			wg.Done()
@@ -36,52 +41,38 @@ func (h *HelmV3) RepositoryIndex() error {
 	return nil
 }
 
-func (h *HelmV3) RepositoryAdd(name, url, username, password, certFile, keyFile, caFile string) error {
-	repositoryConfigLock.Lock()
-	defer repositoryConfigLock.Unlock()
-
-	f, err := loadRepositoryConfig()
+// RepositoryFetchIndex fetches the index of the chart repository at
+// the given URL with the credentials from the given options, without
+// adding it to the repository configuration.
+func (h *HelmV3) RepositoryFetchIndex(url string, opts helm.RepositoryOptions) (*helm.RepositoryIndex, error) {
+	tmpDir, err := ioutil.TempDir("", "repository-index-")
 	if err != nil {
-		return err
+		return nil, err
 	}
+	defer os.RemoveAll(tmpDir)
 
-	c := &repo.Entry{
-		Name:     name,
+	r, err := repo.NewChartRepository(&repo.Entry{
+		Name:     "index",
 		URL:      url,
-		Username: username,
-		Password: password,
-		CertFile: certFile,
-		KeyFile:  keyFile,
-		CAFile:   caFile,
-	}
-	f.Add(c)
-
-	if f.Has(name) {
-		return errors.New("chart repository with name '%s' already exists")
-	}
-
-	r, err := newChartRepository(c)
+		Username: opts.Username,
+		Password: opts.Password,
+		CertFile: opts.CertFile,
+		KeyFile:  opts.KeyFile,
+		CAFile:   opts.CAFile,
+	}, getterProvidersWithOptions(opts.ObjectStorageOptions(), nil))
 	if err != nil {
-		return err
+		return nil, err
 	}
-	if _, err = r.DownloadIndexFile(); err != nil {
-		return err
+	r.CachePath = tmpDir
+	indexPath, err := downloadIndexFile(r)
+	if err != nil {
+		return nil, err
 	}
-
-	return f.WriteFile(repositoryConfig, 0644)
-}
-
-func (h *HelmV3) RepositoryRemove(name string) error {
-	repositoryConfigLock.Lock()
-	defer repositoryConfigLock.Unlock()
-
-	f, err := repo.LoadFile(repositoryConfig)
+	index, err := repo.LoadIndexFile(indexPath)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	f.Remove(name)
-
-	return f.WriteFile(repositoryConfig, 0644)
+	return &helm.RepositoryIndex{Generated: index.Generated, Charts: len(index.Entries)}, nil
 }
 
 func (h *HelmV3) RepositoryImport(path string) error {
@@ -108,7 +99,7 @@ func (h *HelmV3) RepositoryImport(path string) error {
 			h.logger.Log("error", err, "name", c.Name, "url", c.URL)
 			continue
 		}
-		if _, err := r.DownloadIndexFile(); err != nil {
+		if _, err := downloadIndexFile(r); err != nil {
 			h.logger.Log("error", err, "name", c.Name, "url", c.URL)
 			continue
 		}
@@ -124,7 +115,10 @@ func (h *HelmV3) RepositoryImport(path string) error {
 // of the cache path and getters while duplicating as less
 // code as possible.
 func newChartRepository(e *repo.Entry) (*repo.ChartRepository, error) {
-	cr, err := repo.NewChartRepository(e, getterProviders())
+	cr, err := repo.NewChartRepository(e, getterProvidersWithOptions(objectstorage.Options{
+		AccessKeyID:     e.Username,
+		SecretAccessKey: e.Password,
+	}, nil))
 	if err != nil {
 		return nil, err
 	}
@@ -132,6 +126,26 @@ func newChartRepository(e *repo.Entry) (*repo.ChartRepository, error) {
 	return cr, err
 }
 
+// downloadIndexFile downloads the index of the given repository,
+// and records the duration of the download.
+func downloadIndexFile(r *repo.ChartRepository) (string, error) {
+	start := time.Now()
+	path, err := r.DownloadIndexFile()
+	helm.ObserveIndexDownload(r.Config.URL, start, err)
+	return path, err
+}
+
+// configuredRepositories returns a snapshot of the repositories in
+// the repository configuration, for the getter for object storage.
+// The caller must hold the repository configuration lock.
+func configuredRepositories() []*repo.Entry {
+	f, err := loadRepositoryConfig()
+	if err != nil || f == nil {
+		return nil
+	}
+	return f.Repositories
+}
+
 func loadRepositoryConfig() (*repo.File, error) {
 	r, err := repo.LoadFile(repositoryConfig)
 	if err != nil && !os.IsNotExist(errors.Cause(err)) {
@@ -7,7 +7,7 @@ import (
 )
 
 func (h *HelmV3) Uninstall(releaseName string, opts helm.UninstallOptions) error {
-	cfg, err := newActionConfig(h.kubeConfig, h.infoLogFunc(opts.Namespace, releaseName), opts.Namespace, "")
+	cfg, err := h.newActionConfig(opts.Namespace, releaseName, opts.StorageDriver)
 	if err != nil {
 		return err
 	}
@@ -1,6 +1,8 @@
 package v3
 
 import (
+	"context"
+
 	"helm.sh/helm/v3/pkg/action"
 	"helm.sh/helm/v3/pkg/chart/loader"
 	"helm.sh/helm/v3/pkg/chartutil"
@@ -12,7 +14,7 @@ import (
 func (h *HelmV3) UpgradeFromPath(chartPath string, releaseName string, values []byte,
 	opts helm.UpgradeOptions) (*helm.Release, error) {
 
-	cfg, err := newActionConfig(h.kubeConfig, h.infoLogFunc(opts.Namespace, releaseName), opts.Namespace, "")
+	cfg, err := h.newActionConfig(opts.Namespace, releaseName, opts.StorageDriver)
 	if err != nil {
 		return nil, err
 	}
@@ -30,15 +32,20 @@ func (h *HelmV3) UpgradeFromPath(chartPath string, releaseName string, values []
 		return nil, err
 	}
 
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
 	var res *release.Release
 	if opts.Install {
 		install := action.NewInstall(cfg)
 		installOptions(opts).configure(install, releaseName)
-		res, err = install.Run(chartRequested, val.AsMap())
+		res, err = install.RunWithContext(ctx, chartRequested, val.AsMap())
 	} else {
 		upgrade := action.NewUpgrade(cfg)
 		upgradeOptions(opts).configure(upgrade)
-		res, err = upgrade.Run(releaseName, chartRequested, val.AsMap())
+		res, err = upgrade.RunWithContext(ctx, releaseName, chartRequested, val.AsMap())
 	}
 
 	if err != nil {
@@ -0,0 +1,152 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-kit/kit/log"
+	"github.com/gorilla/mux"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	helmfluxv1 "github.com/fluxcd/helm-operator/pkg/apis/helm.fluxcd.io/v1"
+	transport "github.com/fluxcd/helm-operator/pkg/http"
+)
+
+// authenticator authenticates (and optionally authorizes) API requests
+// using the bearer token of the request, by submitting a TokenReview
+// and SubjectAccessReview to the Kubernetes API.
+type authenticator struct {
+	client    kubernetes.Interface
+	authorize bool
+	// prefix is the path prefix stripped from the requests before
+	// they reach the middleware, and is restored to review the access
+	// to non-resource URLs.
+	prefix string
+	logger log.Logger
+}
+
+// middleware returns a mux middleware that rejects requests that
+// can not be authenticated with a HTTP 401 status header, and
+// requests that are not authorized with a HTTP 403 status header.
+// Webhooks are exempt, as their payloads are verified using the
+// webhook secret instead.
+func (a *authenticator) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if route := mux.CurrentRoute(r); route != nil && route.GetName() == transport.Webhook {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		token := bearerToken(r)
+		if token == "" {
+			http.Error(w, "missing bearer token", http.StatusUnauthorized)
+			return
+		}
+
+		user, ok, err := a.authenticate(r.Context(), token)
+		if err != nil {
+			a.logger.Log("error", fmt.Sprintf("failed to review token: %v", err))
+			http.Error(w, "failed to authenticate request", http.StatusInternalServerError)
+			return
+		}
+		if !ok {
+			http.Error(w, "invalid bearer token", http.StatusUnauthorized)
+			return
+		}
+
+		if a.authorize {
+			allowed, err := a.authorized(r.Context(), user, r)
+			if err != nil {
+				a.logger.Log("error", fmt.Sprintf("failed to review access of %s: %v", user.Username, err))
+				http.Error(w, "failed to authorize request", http.StatusInternalServerError)
+				return
+			}
+			if !allowed {
+				http.Error(w, fmt.Sprintf("%s is not allowed to access this endpoint", user.Username), http.StatusForbidden)
+				return
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// authenticate reviews the given token, and returns the user it
+// belongs to and a boolean indicating if it was authenticated.
+func (a *authenticator) authenticate(ctx context.Context, token string) (authenticationv1.UserInfo, bool, error) {
+	review, err := a.client.AuthenticationV1().TokenReviews().Create(ctx, &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{Token: token},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return authenticationv1.UserInfo{}, false, err
+	}
+	if review.Status.Error != "" {
+		a.logger.Log("warn", fmt.Sprintf("token review returned an error: %s", review.Status.Error))
+	}
+	return review.Status.User, review.Status.Authenticated, nil
+}
+
+// authorized reviews if the given user is allowed to access the
+// endpoint matched for the given request.
+func (a *authenticator) authorized(ctx context.Context, user authenticationv1.UserInfo, r *http.Request) (bool, error) {
+	spec := authorizationv1.SubjectAccessReviewSpec{
+		User:   user.Username,
+		UID:    user.UID,
+		Groups: user.Groups,
+	}
+	if len(user.Extra) > 0 {
+		spec.Extra = make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+		for k, v := range user.Extra {
+			spec.Extra[k] = authorizationv1.ExtraValue(v)
+		}
+	}
+	spec.ResourceAttributes, spec.NonResourceAttributes = accessAttributes(r, a.prefix)
+
+	review, err := a.client.AuthorizationV1().SubjectAccessReviews().Create(ctx, &authorizationv1.SubjectAccessReview{
+		Spec: spec,
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return false, err
+	}
+	return review.Status.Allowed, nil
+}
+
+// accessAttributes returns the attributes the access to the endpoint
+// matched for the given request is reviewed against. Syncing a single
+// HelmRelease is reviewed as the creation of the 'sync' subresource
+// of that HelmRelease, so that access can be granted per namespace;
+// all other endpoints are reviewed as non-resource URLs under the
+// given prefix.
+func accessAttributes(r *http.Request, prefix string) (*authorizationv1.ResourceAttributes, *authorizationv1.NonResourceAttributes) {
+	if route := mux.CurrentRoute(r); route != nil && route.GetName() == transport.SyncRelease {
+		vars := mux.Vars(r)
+		return &authorizationv1.ResourceAttributes{
+			Namespace:   vars["namespace"],
+			Verb:        "create",
+			Group:       helmfluxv1.SchemeGroupVersion.Group,
+			Version:     helmfluxv1.SchemeGroupVersion.Version,
+			Resource:    "helmreleases",
+			Subresource: "sync",
+			Name:        vars["name"],
+		}, nil
+	}
+	return nil, &authorizationv1.NonResourceAttributes{
+		Path: prefix + r.URL.Path,
+		Verb: strings.ToLower(r.Method),
+	}
+}
+
+// bearerToken returns the bearer token from the Authorization header
+// of the given request, or an empty string.
+func bearerToken(r *http.Request) string {
+	parts := strings.SplitN(r.Header.Get("Authorization"), " ", 2)
+	if len(parts) != 2 || !strings.EqualFold(parts[0], "bearer") {
+		return ""
+	}
+	return strings.TrimSpace(parts[1])
+}
@@ -0,0 +1,101 @@
+package daemon
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+	"github.com/stretchr/testify/assert"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	transport "github.com/fluxcd/helm-operator/pkg/http"
+)
+
+func TestAuthenticator_Middleware(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	var reviewed *authorizationv1.SubjectAccessReviewSpec
+	client.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+		review.Status.Authenticated = review.Spec.Token == "valid"
+		review.Status.User = authenticationv1.UserInfo{Username: "jane", Groups: []string{"dev"}}
+		return true, review, nil
+	})
+	client.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		reviewed = &review.Spec
+		review.Status.Allowed = review.Spec.ResourceAttributes != nil && review.Spec.ResourceAttributes.Namespace == "dev"
+		return true, review, nil
+	})
+
+	router := transport.NewRouter()
+	router.Use((&authenticator{client: client, authorize: true, prefix: "/api", logger: log.NewNopLogger()}).middleware)
+	ok := func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) }
+	router.Get(transport.SyncGit).HandlerFunc(ok)
+	router.Get(transport.SyncRelease).HandlerFunc(ok)
+
+	for _, tt := range []struct {
+		name  string
+		path  string
+		token string
+		code  int
+	}{
+		{"no token", "/v1/releases/dev/podinfo/sync", "", http.StatusUnauthorized},
+		{"invalid token", "/v1/releases/dev/podinfo/sync", "invalid", http.StatusUnauthorized},
+		{"allowed", "/v1/releases/dev/podinfo/sync", "valid", http.StatusOK},
+		{"forbidden namespace", "/v1/releases/prod/podinfo/sync", "valid", http.StatusForbidden},
+		{"forbidden non-resource", "/v1/sync-git", "valid", http.StatusForbidden},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", tt.path, nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+			assert.Equal(t, tt.code, rec.Code)
+		})
+	}
+
+	if assert.NotNil(t, reviewed) {
+		assert.Equal(t, "jane", reviewed.User)
+		assert.Equal(t, &authorizationv1.NonResourceAttributes{Path: "/api/v1/sync-git", Verb: "post"}, reviewed.NonResourceAttributes)
+	}
+}
+
+func TestAuthenticator_MiddlewarePprof(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	var reviewed *authorizationv1.SubjectAccessReviewSpec
+	client.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+		review.Status.Authenticated = review.Spec.Token == "valid"
+		review.Status.User = authenticationv1.UserInfo{Username: "jane"}
+		return true, review, nil
+	})
+	client.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		reviewed = &review.Spec
+		review.Status.Allowed = true
+		return true, review, nil
+	})
+
+	handler := (&authenticator{client: client, authorize: true, logger: log.NewNopLogger()}).middleware(pprofHandler())
+
+	req := httptest.NewRequest("GET", "/debug/pprof/", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	req = httptest.NewRequest("GET", "/debug/pprof/", nil)
+	req.Header.Set("Authorization", "Bearer valid")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	if assert.NotNil(t, reviewed) {
+		assert.Equal(t, &authorizationv1.NonResourceAttributes{Path: "/debug/pprof/", Verb: "get"}, reviewed.NonResourceAttributes)
+	}
+}
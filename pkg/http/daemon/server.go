@@ -2,91 +2,291 @@ package daemon
 
 import (
 	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
-	_ "net/http/pprof"
-	"sync/atomic"
+	"net/http/pprof"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/fluxcd/helm-operator/pkg/api"
 	transport "github.com/fluxcd/helm-operator/pkg/http"
+	"github.com/fluxcd/helm-operator/pkg/jobs"
 	"github.com/go-kit/kit/log"
 	"github.com/gorilla/mux"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
 )
 
+// Config holds the configuration of the HTTP server(s).
+type Config struct {
+	// ListenAddr is the address the API (and, unless MetricsListenAddr
+	// is set, metrics) endpoints are served on.
+	ListenAddr string
+	// MetricsListenAddr is the address the metrics endpoint is served
+	// on; if empty, it is served on ListenAddr.
+	MetricsListenAddr string
+	// TLSCertFile and TLSKeyFile are the paths to the certificate and
+	// key used to serve HTTPS; if empty, plain HTTP is served.
+	TLSCertFile string
+	TLSKeyFile  string
+	// Authentication enables bearer token authentication of API
+	// requests, validated using the TokenReview API.
+	Authentication bool
+	// Authorization enables authorization of API requests using the
+	// SubjectAccessReview API; it implies Authentication.
+	Authorization bool
+	// WebhookSecret refers to the Secret holding the secret webhook
+	// payloads are verified against; if empty, webhooks are disabled.
+	WebhookSecret types.NamespacedName
+}
+
 // ListenAndServe starts a HTTP server instrumented with Prometheus metrics,
-// health and API endpoints on the specified address.
-func ListenAndServe(listenAddr string, apiServer api.Server, logger log.Logger, stopCh <-chan struct{}) {
-	mux := http.DefaultServeMux
+// health and API endpoints on the configured address(es).
+func ListenAndServe(cfg Config, apiServer api.Server, kubeClient kubernetes.Interface, logger log.Logger, stopCh <-chan struct{}) {
+	var tlsConfig *tls.Config
+	if cfg.TLSCertFile != "" || cfg.TLSKeyFile != "" {
+		reloader, err := newCertificateReloader(cfg.TLSCertFile, cfg.TLSKeyFile)
+		if err != nil {
+			logger.Log("error", fmt.Sprintf("HTTP server failed to start: %v", err))
+			return
+		}
+		tlsConfig = &tls.Config{
+			GetCertificate: reloader.GetCertificate,
+			MinVersion:     tls.VersionTLS12,
+		}
+	}
+
+	metricsMux := http.NewServeMux()
+	apiMux := metricsMux
+	if cfg.MetricsListenAddr != "" {
+		apiMux = http.NewServeMux()
+	}
+
+	// the API and the pprof handlers are protected by the same
+	// authentication (and authorization) when enabled
+	auth := cfg.Authentication || cfg.Authorization
 
 	// setup metrics and health endpoints
-	mux.Handle("/metrics", promhttp.Handler())
-	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+	healthz := func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte("OK"))
-	})
+	}
+	metricsMux.Handle("/metrics", promhttp.Handler())
+	metricsMux.HandleFunc("/healthz", healthz)
+	if apiMux != metricsMux {
+		apiMux.HandleFunc("/healthz", healthz)
+	}
 
-	// setup api endpoints
-	handler := NewHandler(apiServer, transport.NewRouter())
-	mux.Handle("/api/", http.StripPrefix("/api", handler))
+	// setup pprof endpoints, these are served next to the metrics
+	var debug http.Handler = pprofHandler()
+	if auth {
+		debug = (&authenticator{client: kubeClient, authorize: cfg.Authorization, logger: logger}).middleware(debug)
+	}
+	metricsMux.Handle("/debug/pprof/", debug)
 
-	srv := &http.Server{
-		Addr:         listenAddr,
-		Handler:      mux,
-		ReadTimeout:  5 * time.Second,
-		WriteTimeout: 1 * time.Minute,
-		IdleTimeout:  15 * time.Second,
+	// setup api endpoints
+	router := transport.NewRouter()
+	if auth {
+		router.Use((&authenticator{client: kubeClient, authorize: cfg.Authorization, prefix: "/api", logger: logger}).middleware)
+	}
+	var webhookSecret WebhookSecretFunc
+	if cfg.WebhookSecret.Name != "" {
+		webhookSecret = kubeWebhookSecret(kubeClient, cfg.WebhookSecret, stopCh)
 	}
+	handler := NewHandler(apiServer, router, webhookSecret)
+	apiMux.Handle("/api/", http.StripPrefix("/api", handler))
 
-	logger.Log("info", fmt.Sprintf("starting HTTP server on %s", listenAddr))
+	servers := []*http.Server{newServer(cfg.ListenAddr, apiMux, tlsConfig)}
+	if cfg.MetricsListenAddr != "" {
+		servers = append(servers, newServer(cfg.MetricsListenAddr, metricsMux, tlsConfig))
+	}
 
-	// run server in background
-	go func() {
-		if err := srv.ListenAndServe(); err != http.ErrServerClosed {
-			logger.Log("error", fmt.Sprintf("HTTP server crashed %v", err))
-		}
-	}()
+	// run servers in background
+	for _, srv := range servers {
+		logger.Log("info", fmt.Sprintf("starting HTTP server on %s", srv.Addr))
+		go func(srv *http.Server) {
+			var err error
+			if srv.TLSConfig != nil {
+				err = srv.ListenAndServeTLS("", "")
+			} else {
+				err = srv.ListenAndServe()
+			}
+			if err != http.ErrServerClosed {
+				logger.Log("error", fmt.Sprintf("HTTP server on %s crashed %v", srv.Addr, err))
+			}
+		}(srv)
+	}
 
 	// wait for close signal and attempt graceful shutdown
 	<-stopCh
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	if err := srv.Shutdown(ctx); err != nil {
-		logger.Log("warn", fmt.Sprintf("HTTP server graceful shutdown failed %v", err))
-	} else {
-		logger.Log("info", "HTTP server stopped")
+	for _, srv := range servers {
+		if err := srv.Shutdown(ctx); err != nil {
+			logger.Log("warn", fmt.Sprintf("HTTP server on %s graceful shutdown failed %v", srv.Addr, err))
+		} else {
+			logger.Log("info", fmt.Sprintf("HTTP server on %s stopped", srv.Addr))
+		}
+	}
+}
+
+// pprofHandler returns a handler serving the pprof endpoints under
+// /debug/pprof/.
+func pprofHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	return mux
+}
+
+// newServer returns a HTTP server for the given address and handler,
+// serving HTTPS if a TLS configuration is given.
+func newServer(addr string, handler http.Handler, tlsConfig *tls.Config) *http.Server {
+	return &http.Server{
+		Addr:         addr,
+		Handler:      handler,
+		TLSConfig:    tlsConfig,
+		ReadTimeout:  5 * time.Second,
+		WriteTimeout: 1 * time.Minute,
+		IdleTimeout:  15 * time.Second,
 	}
 }
 
-// NewHandler registers handlers on the given router.
-func NewHandler(s api.Server, r *mux.Router) http.Handler {
-	handle := &APIServer{server: s}
+// NewHandler registers handlers on the given router. Webhooks are
+// only served if a webhookSecret is given.
+func NewHandler(s api.Server, r *mux.Router, webhookSecret WebhookSecretFunc) http.Handler {
+	handle := &APIServer{
+		server:        s,
+		jobs:          jobs.NewStore(jobs.DefaultMaxJobs, jobs.DefaultRetention),
+		webhookSecret: webhookSecret,
+	}
 	r.Get(transport.SyncGit).HandlerFunc(handle.SyncGit)
+	r.Get(transport.SyncGitJob).HandlerFunc(handle.SyncGitJob)
+	r.Get(transport.SyncRelease).HandlerFunc(handle.SyncRelease)
+	r.Get(transport.JobStatus).HandlerFunc(handle.JobStatus)
+	r.Get(transport.Webhook).HandlerFunc(handle.Webhook)
 	return r
 }
 
 type APIServer struct {
-	server     api.Server
-	syncingGit uint32
+	server        api.Server
+	jobs          *jobs.Store
+	webhookSecret WebhookSecretFunc
+
+	syncingGitMu sync.Mutex
+	syncingGit   jobs.ID
 }
 
 // SyncGit starts a goroutine in the background to sync all git mirrors
 // _if there is not one running at time of request_. It writes back a
-// HTTP 200 status header and 'OK' body to inform the request was
-// successful.
-// TODO(hidde): in the future we may want to give users the option to
-// request the status after it has been started. The Flux (daemon) API
-// achieves this by working with jobs whos IDs can be tracked.
+// HTTP 200 status header and 'OK' as the response body.
 func (s *APIServer) SyncGit(w http.ResponseWriter, r *http.Request) {
-	if atomic.CompareAndSwapUint32(&s.syncingGit, 0, 1) {
-		go func() {
-			s.server.SyncMirrors()
-			atomic.StoreUint32(&s.syncingGit, 0)
-		}()
-	}
-
+	s.syncGit()
 	w.WriteHeader(http.StatusOK)
 	w.Write([]byte("OK"))
 }
+
+// SyncGitJob does the same as SyncGit, but writes back a HTTP 202
+// status header and the (already running) job in the body, so that
+// the status of the sync can be requested using the ID of the job.
+func (s *APIServer) SyncGitJob(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusAccepted, s.syncGit())
+}
+
+// syncGit starts the sync of all git mirrors in the background if
+// there is not one running already, and returns the job tracking it.
+func (s *APIServer) syncGit() jobs.Job {
+	s.syncingGitMu.Lock()
+	defer s.syncingGitMu.Unlock()
+
+	if j, ok := s.jobs.Get(s.syncingGit); ok && !j.Finished() {
+		return j
+	}
+
+	j := s.jobs.New(syncGitJob)
+	s.syncingGit = j.ID
+	go func() {
+		s.jobs.Start(j.ID)
+		s.jobs.Finish(j.ID, s.server.SyncMirrors())
+	}()
+	return j
+}
+
+// SyncRelease refreshes the git mirror of the HelmRelease in the
+// request path (if it has a git chart source), and schedules the
+// HelmRelease for an immediate sync. An upgrade can be forced by
+// setting the 'force' query parameter to 'true'. It writes back a
+// HTTP 202 status header and the job tracking the sync in the body
+// if the sync was scheduled; no job is created if the HelmRelease
+// does not exist or the sync could not be scheduled.
+func (s *APIServer) SyncRelease(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var force bool
+	if v := r.URL.Query().Get("force"); v != "" {
+		var err error
+		if force, err = strconv.ParseBool(v); err != nil {
+			http.Error(w, fmt.Sprintf("invalid value for 'force' parameter: %s", v), http.StatusBadRequest)
+			return
+		}
+	}
+
+	namespace, name := vars["namespace"], vars["name"]
+	var j jobs.Job
+	err := s.server.SyncRelease(namespace, name, api.SyncReleaseOptions{
+		ForceUpgrade: force,
+		NewObserver: func() api.SyncObserver {
+			j = s.jobs.New(syncReleaseJob, fmt.Sprintf("%s/%s", namespace, name))
+			return s.jobs.Observer(j.ID)
+		},
+	})
+	switch {
+	case errors.Is(err, api.ErrReleaseNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusAccepted, j)
+}
+
+// JobStatus writes back the job with the ID in the request path, or
+// a HTTP 404 status header if no such job exists (anymore).
+func (s *APIServer) JobStatus(w http.ResponseWriter, r *http.Request) {
+	j, ok := s.jobs.Get(jobs.ID(mux.Vars(r)["id"]))
+	if !ok {
+		http.Error(w, "job not found", http.StatusNotFound)
+		return
+	}
+	writeJSON(w, http.StatusOK, j)
+}
+
+const (
+	syncGitJob     = "sync-git"
+	syncReleaseJob = "sync-release"
+	webhookJob     = "webhook"
+)
+
+// writeJSON writes the given status code and the JSON encoding of v
+// to the response.
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(b)
+}
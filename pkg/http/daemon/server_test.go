@@ -0,0 +1,105 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fluxcd/helm-operator/pkg/api"
+	transport "github.com/fluxcd/helm-operator/pkg/http"
+	"github.com/fluxcd/helm-operator/pkg/jobs"
+)
+
+func newTestHandler(server api.Server) http.Handler {
+	return NewHandler(server, transport.NewRouter(), func(context.Context) ([]byte, error) {
+		return nil, nil
+	})
+}
+
+func TestAPIServer_SyncGit(t *testing.T) {
+	handler := newTestHandler(&fakeServer{})
+
+	req := httptest.NewRequest("POST", "/v1/sync-git", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "OK", rec.Body.String())
+
+	req = httptest.NewRequest("POST", "/v2/sync-git", nil)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	var j jobs.Job
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &j))
+	assert.Equal(t, syncGitJob, j.Type)
+	assert.NotEmpty(t, j.ID)
+}
+
+func TestAPIServer_SyncRelease(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		path string
+		err  error
+		code int
+		job  bool
+	}{
+		{
+			name: "scheduled",
+			path: "/v1/releases/default/podinfo/sync",
+			code: http.StatusAccepted,
+			job:  true,
+		},
+		{
+			name: "forced",
+			path: "/v1/releases/default/podinfo/sync?force=true",
+			code: http.StatusAccepted,
+			job:  true,
+		},
+		{
+			name: "invalid force",
+			path: "/v1/releases/default/podinfo/sync?force=maybe",
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "not found",
+			path: "/v1/releases/default/podinfo/sync",
+			err:  api.ErrReleaseNotFound,
+			code: http.StatusNotFound,
+		},
+		{
+			name: "mirror refresh failed",
+			path: "/v1/releases/default/podinfo/sync",
+			err:  errors.New("failed syncing git mirror: authentication required"),
+			code: http.StatusInternalServerError,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := newTestHandler(&fakeServer{syncReleaseErr: tt.err})
+
+			req := httptest.NewRequest("POST", tt.path, nil)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			assert.Equal(t, tt.code, rec.Code)
+
+			var j jobs.Job
+			err := json.Unmarshal(rec.Body.Bytes(), &j)
+			if !tt.job {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, syncReleaseJob, j.Type)
+			assert.Equal(t, []string{"default/podinfo"}, j.Releases)
+
+			req = httptest.NewRequest("GET", "/v1/jobs/"+string(j.ID), nil)
+			rec = httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			assert.Equal(t, http.StatusOK, rec.Code)
+		})
+	}
+}
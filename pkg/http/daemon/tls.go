@@ -0,0 +1,69 @@
+package daemon
+
+import (
+	"crypto/tls"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// certificateReloader loads a TLS certificate and key pair from disk,
+// and reloads it when either of the files is modified, so that
+// rotated certificates are picked up without a restart.
+type certificateReloader struct {
+	certFile string
+	keyFile  string
+
+	mu      sync.Mutex
+	cert    *tls.Certificate
+	certMod time.Time
+	keyMod  time.Time
+}
+
+// newCertificateReloader returns a certificateReloader for the given
+// files, after verifying the key pair can be loaded.
+func newCertificateReloader(certFile, keyFile string) (*certificateReloader, error) {
+	r := &certificateReloader{certFile: certFile, keyFile: keyFile}
+	if _, err := r.GetCertificate(nil); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// GetCertificate returns the current certificate, reloading it first
+// if the files on disk have been modified since it was last loaded.
+// It satisfies the signature of tls.Config.GetCertificate.
+func (r *certificateReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	certInfo, err := os.Stat(r.certFile)
+	if err != nil {
+		return r.fallback(err)
+	}
+	keyInfo, err := os.Stat(r.keyFile)
+	if err != nil {
+		return r.fallback(err)
+	}
+	if r.cert != nil && certInfo.ModTime().Equal(r.certMod) && keyInfo.ModTime().Equal(r.keyMod) {
+		return r.cert, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return r.fallback(err)
+	}
+	r.cert, r.certMod, r.keyMod = &cert, certInfo.ModTime(), keyInfo.ModTime()
+	return r.cert, nil
+}
+
+// fallback returns the previously loaded certificate if there is
+// one, so that a rotation in progress does not interrupt the server,
+// or the given error.
+func (r *certificateReloader) fallback(err error) (*tls.Certificate, error) {
+	if r.cert != nil {
+		return r.cert, nil
+	}
+	return nil, fmt.Errorf("failed to load TLS certificate: %w", err)
+}
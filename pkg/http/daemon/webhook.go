@@ -0,0 +1,271 @@
+package daemon
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+)
+
+// maxWebhookPayload is the maximum size of a webhook payload that is
+// read, in bytes.
+const maxWebhookPayload = 1 << 20
+
+// webhookSecretResync is the interval at which the cached webhook
+// Secret is resynced.
+const webhookSecretResync = 10 * time.Minute
+
+// webhookSecretKey is the key of the webhook secret in the Secret
+// referred to in the configuration.
+const webhookSecretKey = "token"
+
+// WebhookSecretFunc returns the secret webhook payloads are verified
+// against.
+type WebhookSecretFunc func(ctx context.Context) ([]byte, error)
+
+// kubeWebhookSecret returns a WebhookSecretFunc that reads the
+// secret from the given Secret, so that it can be rotated without a
+// restart. The Secret is watched and served from a cache until the
+// given stop channel is closed, so that webhook requests do not
+// result in requests to the Kubernetes API.
+func kubeWebhookSecret(client kubernetes.Interface, ref types.NamespacedName, stopCh <-chan struct{}) WebhookSecretFunc {
+	factory := informers.NewSharedInformerFactoryWithOptions(client, webhookSecretResync,
+		informers.WithNamespace(ref.Namespace),
+		informers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+			opts.FieldSelector = fields.OneTermEqualSelector("metadata.name", ref.Name).String()
+		}))
+	lister := factory.Core().V1().Secrets().Lister()
+	factory.Start(stopCh)
+
+	return func(ctx context.Context) ([]byte, error) {
+		secret, err := lister.Secrets(ref.Namespace).Get(ref.Name)
+		if err != nil {
+			return nil, err
+		}
+		token, ok := secret.Data[webhookSecretKey]
+		if !ok || len(token) == 0 {
+			return nil, fmt.Errorf("could not find %s key in secret %s", webhookSecretKey, ref)
+		}
+		return token, nil
+	}
+}
+
+// webhookProvider knows how to verify and interpret the webhook
+// payloads of a git hosting provider.
+type webhookProvider struct {
+	// verify returns true if the payload is signed with the secret.
+	verify func(h http.Header, body, secret []byte) bool
+	// push returns true if the payload is a push event.
+	push func(h http.Header) bool
+	// urls returns the URLs of the repository the payload is for.
+	urls func(body []byte) ([]string, error)
+}
+
+var webhookProviders = map[string]webhookProvider{
+	"github": {
+		verify: func(h http.Header, body, secret []byte) bool {
+			return validSignature(h.Get("X-Hub-Signature-256"), body, secret)
+		},
+		push: func(h http.Header) bool {
+			return h.Get("X-GitHub-Event") == "push"
+		},
+		urls: func(body []byte) ([]string, error) {
+			var p struct {
+				Repository struct {
+					CloneURL string `json:"clone_url"`
+					SSHURL   string `json:"ssh_url"`
+					GitURL   string `json:"git_url"`
+					HTMLURL  string `json:"html_url"`
+				} `json:"repository"`
+			}
+			if err := json.Unmarshal(body, &p); err != nil {
+				return nil, err
+			}
+			r := p.Repository
+			return nonEmpty(r.CloneURL, r.SSHURL, r.GitURL, r.HTMLURL), nil
+		},
+	},
+	"gitlab": {
+		verify: func(h http.Header, body, secret []byte) bool {
+			return subtle.ConstantTimeCompare([]byte(h.Get("X-Gitlab-Token")), secret) == 1
+		},
+		push: func(h http.Header) bool {
+			e := h.Get("X-Gitlab-Event")
+			return e == "Push Hook" || e == "Tag Push Hook"
+		},
+		urls: func(body []byte) ([]string, error) {
+			type repository struct {
+				GitHTTPURL string `json:"git_http_url"`
+				GitSSHURL  string `json:"git_ssh_url"`
+				WebURL     string `json:"web_url"`
+			}
+			var p struct {
+				Project    repository `json:"project"`
+				Repository repository `json:"repository"`
+			}
+			if err := json.Unmarshal(body, &p); err != nil {
+				return nil, err
+			}
+			return nonEmpty(p.Project.GitHTTPURL, p.Project.GitSSHURL, p.Project.WebURL,
+				p.Repository.GitHTTPURL, p.Repository.GitSSHURL), nil
+		},
+	},
+	"bitbucket": {
+		verify: func(h http.Header, body, secret []byte) bool {
+			return validSignature(h.Get("X-Hub-Signature"), body, secret)
+		},
+		push: func(h http.Header) bool {
+			// Bitbucket Cloud and Bitbucket Server respectively
+			e := h.Get("X-Event-Key")
+			return e == "repo:push" || e == "repo:refs_changed"
+		},
+		urls: func(body []byte) ([]string, error) {
+			// Bitbucket Cloud has a single object per link, Bitbucket
+			// Server a list of objects.
+			var p struct {
+				Repository struct {
+					Links map[string]json.RawMessage `json:"links"`
+				} `json:"repository"`
+			}
+			if err := json.Unmarshal(body, &p); err != nil {
+				return nil, err
+			}
+			type link struct {
+				Href string `json:"href"`
+			}
+			var urls []string
+			for _, raw := range p.Repository.Links {
+				var l link
+				if err := json.Unmarshal(raw, &l); err == nil {
+					urls = append(urls, l.Href)
+					continue
+				}
+				var ls []link
+				if err := json.Unmarshal(raw, &ls); err == nil {
+					for _, l := range ls {
+						urls = append(urls, l.Href)
+					}
+				}
+			}
+			return nonEmpty(urls...), nil
+		},
+	},
+	"generic": {
+		verify: func(h http.Header, body, secret []byte) bool {
+			return validSignature(h.Get("X-Signature"), body, secret)
+		},
+		push: func(h http.Header) bool {
+			return true
+		},
+		urls: func(body []byte) ([]string, error) {
+			var p struct {
+				URL string `json:"url"`
+			}
+			if err := json.Unmarshal(body, &p); err != nil {
+				return nil, err
+			}
+			return nonEmpty(p.URL), nil
+		},
+	},
+}
+
+// Webhook verifies the payload of a git hosting provider webhook, and
+// starts a goroutine in the background to sync the git mirrors of
+// the repository in the payload if it is a push event. It writes back
+// a HTTP 202 status header and the job of the sync in the body, or a
+// HTTP 200 status header if the event was ignored.
+func (s *APIServer) Webhook(w http.ResponseWriter, r *http.Request) {
+	provider, ok := webhookProviders[mux.Vars(r)["provider"]]
+	if !ok || s.webhookSecret == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	// The payload is verified before any other work is done, so
+	// that unauthenticated requests are cheap to reject.
+	body, err := ioutil.ReadAll(io.LimitReader(r.Body, maxWebhookPayload+1))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to read payload: %s", err), http.StatusBadRequest)
+		return
+	}
+	if len(body) > maxWebhookPayload {
+		http.Error(w, "payload too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	secret, err := s.webhookSecret(r.Context())
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to get webhook secret: %s", err), http.StatusInternalServerError)
+		return
+	}
+	if !provider.verify(r.Header, body, secret) {
+		http.Error(w, "invalid webhook signature", http.StatusUnauthorized)
+		return
+	}
+
+	if !provider.push(r.Header) {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	urls, err := provider.urls(body)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to parse payload: %s", err), http.StatusBadRequest)
+		return
+	}
+	if len(urls) == 0 {
+		http.Error(w, "no repository found in payload", http.StatusBadRequest)
+		return
+	}
+
+	j := s.jobs.New(webhookJob)
+	go func() {
+		s.jobs.Start(j.ID)
+		s.jobs.Finish(j.ID, s.server.SyncMirrorsForRemote(urls...))
+	}()
+
+	writeJSON(w, http.StatusAccepted, j)
+}
+
+// validSignature returns true if the given signature header, in the
+// form of `sha256=<hex>`, is the HMAC-SHA256 of the body with the
+// given secret.
+func validSignature(header string, body, secret []byte) bool {
+	const prefix = "sha256="
+	if !strings.HasPrefix(header, prefix) {
+		return false
+	}
+	sig, err := hex.DecodeString(strings.TrimPrefix(header, prefix))
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return hmac.Equal(sig, mac.Sum(nil))
+}
+
+// nonEmpty returns the given strings without the empty ones.
+func nonEmpty(s ...string) []string {
+	var r []string
+	for _, v := range s {
+		if v != "" {
+			r = append(r, v)
+		}
+	}
+	return r
+}
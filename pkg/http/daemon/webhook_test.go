@@ -0,0 +1,165 @@
+package daemon
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/fluxcd/helm-operator/pkg/api"
+	transport "github.com/fluxcd/helm-operator/pkg/http"
+)
+
+type fakeServer struct {
+	remotes        chan []string
+	syncReleaseErr error
+}
+
+func (s *fakeServer) SyncMirrors() error {
+	return nil
+}
+
+func (s *fakeServer) SyncMirrorsForRemote(urls ...string) error {
+	s.remotes <- urls
+	return nil
+}
+
+func (s *fakeServer) SyncRelease(namespace, name string, opts api.SyncReleaseOptions) error {
+	if s.syncReleaseErr != nil {
+		return s.syncReleaseErr
+	}
+	if opts.NewObserver != nil {
+		opts.NewObserver().SyncStarted()
+	}
+	return nil
+}
+
+func sign(body, secret []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestAPIServer_Webhook(t *testing.T) {
+	secret := []byte("s3cr3t")
+	server := &fakeServer{remotes: make(chan []string, 1)}
+	handler := NewHandler(server, transport.NewRouter(), func(context.Context) ([]byte, error) {
+		return secret, nil
+	})
+
+	github := []byte(`{"repository":{"clone_url":"https://github.com/org/repo.git","ssh_url":"git@github.com:org/repo.git"}}`)
+	bitbucket := []byte(`{"repository":{"links":{"clone":[{"href":"ssh://git@bitbucket.example.com:7999/org/repo.git","name":"ssh"}]}}}`)
+
+	for _, tt := range []struct {
+		name    string
+		path    string
+		header  http.Header
+		body    []byte
+		code    int
+		remotes []string
+	}{
+		{
+			name:    "github push",
+			path:    "/v1/webhooks/github",
+			header:  http.Header{"X-Github-Event": {"push"}, "X-Hub-Signature-256": {sign(github, secret)}},
+			body:    github,
+			code:    http.StatusAccepted,
+			remotes: []string{"https://github.com/org/repo.git", "git@github.com:org/repo.git"},
+		},
+		{
+			name:   "github ping",
+			path:   "/v1/webhooks/github",
+			header: http.Header{"X-Github-Event": {"ping"}, "X-Hub-Signature-256": {sign(github, secret)}},
+			body:   github,
+			code:   http.StatusOK,
+		},
+		{
+			name:   "github invalid signature",
+			path:   "/v1/webhooks/github",
+			header: http.Header{"X-Github-Event": {"push"}, "X-Hub-Signature-256": {sign(github, []byte("wrong"))}},
+			body:   github,
+			code:   http.StatusUnauthorized,
+		},
+		{
+			name:   "gitlab invalid token",
+			path:   "/v1/webhooks/gitlab",
+			header: http.Header{"X-Gitlab-Event": {"Push Hook"}, "X-Gitlab-Token": {"wrong"}},
+			body:   []byte(`{}`),
+			code:   http.StatusUnauthorized,
+		},
+		{
+			name:    "bitbucket server push",
+			path:    "/v1/webhooks/bitbucket",
+			header:  http.Header{"X-Event-Key": {"repo:refs_changed"}, "X-Hub-Signature": {sign(bitbucket, secret)}},
+			body:    bitbucket,
+			code:    http.StatusAccepted,
+			remotes: []string{"ssh://git@bitbucket.example.com:7999/org/repo.git"},
+		},
+		{
+			name:   "payload too large",
+			path:   "/v1/webhooks/generic",
+			header: http.Header{"X-Signature": {"sha256=00"}},
+			body:   bytes.Repeat([]byte(" "), maxWebhookPayload+1),
+			code:   http.StatusRequestEntityTooLarge,
+		},
+		{
+			name: "unknown provider",
+			path: "/v1/webhooks/unknown",
+			body: []byte(`{}`),
+			code: http.StatusNotFound,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", tt.path, bytes.NewReader(tt.body))
+			for k, v := range tt.header {
+				req.Header[k] = v
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			assert.Equal(t, tt.code, rec.Code)
+
+			if tt.remotes == nil {
+				return
+			}
+			select {
+			case remotes := <-server.remotes:
+				assert.Equal(t, tt.remotes, remotes)
+			case <-time.After(time.Second):
+				t.Fatal("timed out waiting for mirror sync")
+			}
+		})
+	}
+}
+
+func TestKubeWebhookSecret(t *testing.T) {
+	client := fake.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "flux", Name: "webhook"},
+		Data:       map[string][]byte{webhookSecretKey: []byte("s3cr3t")},
+	})
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	secret := kubeWebhookSecret(client, types.NamespacedName{Namespace: "flux", Name: "webhook"}, stopCh)
+
+	var token []byte
+	assert.Eventually(t, func() bool {
+		var err error
+		token, err = secret(context.Background())
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []byte("s3cr3t"), token)
+
+	for _, action := range client.Actions() {
+		assert.NotEqual(t, "get", action.GetVerb(), "secret should be read from the cache")
+	}
+}
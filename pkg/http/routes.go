@@ -1,5 +1,9 @@
 package http
 
 const (
-	SyncGit = "SyncGit"
+	SyncGit     = "SyncGit"
+	SyncGitJob  = "SyncGitJob"
+	SyncRelease = "SyncRelease"
+	JobStatus   = "JobStatus"
+	Webhook     = "Webhook"
 )
@@ -9,5 +9,9 @@ import (
 func NewRouter() *mux.Router {
 	r := mux.NewRouter()
 	r.NewRoute().Name(SyncGit).Methods("POST").Path("/v1/sync-git")
+	r.NewRoute().Name(SyncGitJob).Methods("POST").Path("/v2/sync-git")
+	r.NewRoute().Name(SyncRelease).Methods("POST").Path("/v1/releases/{namespace}/{name}/sync")
+	r.NewRoute().Name(JobStatus).Methods("GET").Path("/v1/jobs/{id}")
+	r.NewRoute().Name(Webhook).Methods("POST").Path("/v1/webhooks/{provider}")
 	return r
 }
@@ -24,23 +24,23 @@ var templates = func() http.FileSystem {
 		"/crds.yaml.tmpl": &vfsgen۰CompressedFileInfo{
 			name:             "crds.yaml.tmpl",
 			modTime:          time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC),
-			uncompressedSize: 18812,
+			uncompressedSize: 33458,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x3c\x6b\x73\xdb\xb8\x76\xdf\xfd\x2b\x4e\xd3\x0f\x4a\x66\x2c\x7a\x37\xe9\xd3\x33\x77\xba\x5b\xdb\xd9\xa4\x9b\x87\xc7\x76\xd2\x0f\x3b\x3b\x31\x44\x1e\x4a\xb8\x06\x01\x5e\x00\x94\xed\x76\xfa\xdf\x3b\x07\x0f\x8a\x92\x00\x4a\x56\x92\xd9\xf6\xce\xe5\x87\x4c\x4c\x1c\x1c\xe2\xbc\x1f\x00\x34\x9d\x4e\x8f\x58\xcb\x3f\xa3\x36\x5c\xc9\x53\x60\x2d\xc7\x07\x8b\x92\xfe\x32\xc5\xdd\xbf\x98\x82\xab\x93\xe5\x8f\x47\x77\x5c\x56\xa7\x70\xd6\x19\xab\x9a\x2b\x34\xaa\xd3\x25\x9e\x63\xcd\x25\xb7\x5c\xc9\xa3\x06\x2d\xab\x98\x65\xa7\x47\x00\x4c\x4a\x65\x19\xbd\x36\xf4\x27\x40\xa9\xa4\xd5\x4a\x08\xd4\xd3\x39\xca\xe2\xae\x9b\xe1\xac\xe3\xa2\x42\xed\x90\xc7\x4f\x2f\x7f\x28\x5e\x16\xff\x78\x04\x50\x6a\x74\xd3\x6f\x78\x83\xc6\xb2\xa6\x3d\x05\xd9\x09\x71\x04\x20\x59\x83\xa7\xb0\x40\xd1\x68\x14\xc8\x0c\x9a\x82\xfe\x28\x6a\xd1\x3d\x94\x55\xc1\xd5\x91\x69\xb1\xa4\xaf\xce\xb5\xea\x5a\x0f\x3a\x18\xf5\x18\xc2\xb2\x3c\x49\x6f\x50\x34\x57\x1e\x99\x7b\x2b\xb8\xb1\xbf\x6e\x8e\xbc\xe3\xc6\xba\xd1\x56\x74\x9a\x89\xf5\x25\xb8\x01\xb3\x50\xda\x7e\x58\x21\x9f\xc2\x42\xf7\xff\x09\x20\x5c\xce\x3b\xc1\xf4\xda\xec\x23\x00\x53\xaa\x16\x4f\xc1\x4d\x6e\x59\x89\xd5\x11\x40\x60\x8a\x43\x36\x05\x56\x55\x8e\xcd\x4c\x5c\x6a\x2e\x2d\xea\x33\x25\xba\x46\xf6\x9f\xaa\xd0\x94\x9a\xb7\xd6\xb1\x31\x2c\x19\xb8\x01\xbb\x40\x47\x30\xa8\xda\xfd\x9f\x28\x82\xf0\xe1\x63\x60\x06\xe6\x7c\x89\x12\x66\x8f\x6e\xa4\x70\xd8\x00\xfe\x6c\x94\xbc\x64\x76\x71\x0a\x85\xb1\xcc\x76\xa6\x08\x53\x68\x85\x01\xc6\x0b\x62\xc8\x37\x00\xfb\x48\x64\x18\xab\xb9\x9c\xa7\x16\x76\xb9\x18\x2c\xab\xec\xb4\x46\x69\xe3\x6a\xa0\x75\x83\x33\xe4\x72\x0e\x2d\xea\x5a\xe9\x06\x2b\xa8\x95\xee\x17\x1e\x3e\x96\x5f\xa5\x43\xb1\xb6\xbe\xcb\xc5\xfe\xab\x0b\xe8\xaf\x1d\xae\xb8\x4a\x8f\xf9\x1b\xb1\xcf\xa3\x4e\x31\x70\x6d\x24\xb1\xd0\x6d\x94\xa5\x92\x5e\x25\xcc\x6f\xff\xf6\xfc\xa7\x82\xe6\xfc\xe9\x4f\xcf\x02\xba\xea\xd9\x8b\xdf\x8b\x06\x8d\x61\xf3\x75\x7e\xbc\x5f\x7b\xb7\x8b\x23\x67\x9b\x66\x48\x5c\x61\x60\xfb\x3f\x35\xb6\x1a\x0d\x4a\x4b\x42\x73\xec\x42\xbd\x44\xed\x20\xe0\x7e\x81\x32\x7c\x08\xc0\x2e\xb8\x01\x35\xfb\x33\x96\x16\xee\x99\xf1\x16\x8e\x55\x01\x6f\x2d\x21\x95\xca\xc2\xbc\x63\x9a\x49\x8b\x58\x81\x55\x30\x23\x64\x16\xb8\x84\x05\x6b\x5b\x94\x66\x3a\xc3\x5a\x69\xec\x31\x2a\x5d\xa1\x06\x56\x6a\x65\x0c\x18\x6c\x99\x66\x16\x41\xb5\xa8\xbd\xe7\x29\xe0\x4c\x70\x94\xd6\x40\xc3\x1e\xdd\x07\x08\x9f\x5b\xc7\x92\x89\x0e\xe3\xa7\x7b\x1a\x9c\xd9\xf9\x87\x4b\xb8\x7a\x7d\xf6\xea\xd5\xab\x7f\x25\x05\x6c\x80\xc9\x8a\x40\xb9\x84\x4f\x37\x67\x09\x31\x47\xe7\x57\x6c\x39\xae\x35\xee\xff\xbc\xc1\xf9\x8a\x59\xff\xc2\x0f\x2f\x7f\xf4\x5e\xa2\x5c\x60\xc3\x4e\x03\xa4\x6a\x51\xfe\x7c\xf9\xf6\xf3\xab\xeb\xb5\xd7\xb0\x2e\xa9\x81\x79\x04\x19\x3d\xb6\x48\x6c\xec\xa9\x03\xb6\xa6\xbd\x45\x8f\xa7\xd5\xc4\x33\xcb\xa3\xdf\xf2\xcf\x20\x22\x0c\xde\x6e\x7c\x75\x42\x0b\xf3\x50\x50\x51\x28\x40\x6f\x34\xc1\x77\x61\x15\x68\xf1\xe6\x33\xe4\xb5\x63\xd2\x1a\x62\x20\x20\x26\x83\x8e\x14\x70\xed\x34\xc9\x90\x5b\xed\x44\x45\x11\x64\x89\x9a\xbc\x45\xa9\xe6\x92\xff\x57\x8f\xdb\x10\x95\xf4\x51\xc1\x2c\x06\x1f\xbd\x7a\x9c\xaf\x94\x4c\x78\x91\x1f\x3b\x41\x92\x3a\x68\x74\x9a\xd8\xc9\x01\x3e\x07\x62\x0a\x78\xaf\x34\x02\x97\xb5\x3a\x85\x85\xb5\xad\x39\x3d\x39\x99\x73\x1b\x23\x61\xa9\x9a\xa6\x93\xdc\x3e\x9e\xb8\xa0\xc6\x67\x9d\x55\xda\x9c\x54\xb8\x44\x71\x62\xf8\x7c\xca\x74\xb9\xe0\x16\x4b\xdb\x69\x3c\x61\x2d\x9f\xba\xa5\x4b\xaf\x93\x4d\xf5\xf7\x3a\xc4\x4e\x33\x59\x5b\xeb\x96\x2d\xfa\xc7\x85\xa8\x11\x09\x50\xa0\xf2\x12\xf7\x53\x3d\x15\xdb\x86\x79\x75\x71\x7d\x03\xf1\xd3\x4e\x18\x9b\xdc\xf7\xb6\xd9\x4f\x34\x2b\x11\x10\xc3\xb8\xac\xc9\xae\x49\x88\xb5\x56\x8d\xc3\x89\xb2\x6a\x15\x97\xd6\xbb\x73\x67\x6c\x1b\x48\x4d\x37\x6b\xb8\x25\xb9\xff\xa5\x43\x63\x49\x56\x05\x9c\xb9\xf4\x80\x0c\xbc\x6b\xab\xe0\x04\x24\x9c\xb1\x06\xc5\x19\x69\xe6\xf7\x16\x00\x71\xda\x4c\x89\xb1\xfb\x89\x60\x98\xd9\x6c\x02\x7b\xae\x0d\x06\x62\xf6\xb1\x7a\xd2\xf6\x45\x4f\xb9\x60\xda\x6e\xbe\x1c\x9b\xd0\x4f\xba\xec\x84\xb8\xc6\x52\x63\x62\xfa\x96\x8e\x9c\xad\xcf\x80\x85\x12\x95\xb7\x53\x8d\x35\x6a\x94\x25\x46\x1b\x62\x9d\x5d\x10\xa7\xca\x94\x7d\xf6\x24\x7a\x34\x14\x99\x59\x59\xa2\x31\x51\xc7\x82\x7f\x69\x95\xe1\x56\xe9\x47\xe8\xdc\xc8\x9b\x9b\x9b\xcb\x6b\x98\x31\xc3\x4b\x87\xbf\xc8\xa0\xfd\xf0\xf1\x06\xde\xbe\xbf\x7c\x77\xf1\xfe\xe2\xc3\xcd\xc5\xf9\xdf\x25\xc1\xc6\x79\x03\xd1\x95\x66\xc6\xb2\x22\x5e\x3d\xa4\xa9\x5c\x63\x95\x46\x31\x75\xe8\x93\x43\x19\x7d\x88\xcf\x9c\xef\x23\xaa\x5f\xb8\x85\x4f\x57\xef\x62\xf2\x41\xff\x0d\x99\x07\x8d\xac\x58\x7b\x0c\x58\xcc\x0b\xb8\x9d\x73\xfb\xd3\x9c\xdb\x45\x37\x2b\x4a\xd5\x9c\x2a\x3d\x3f\x21\xa0\xdb\xe3\x0c\x03\x6e\xc9\x9e\xbc\x39\x85\x39\x27\xab\x39\xa0\x34\xdc\x1a\xb3\xf0\xe3\x3f\xe1\x03\x6b\x5a\x81\x0e\xf1\xcb\x97\x2f\x5f\xf6\x90\xc5\x9c\xdb\xdb\xb4\x14\x77\xb0\x37\x2f\x9b\x35\x2e\x50\x96\x99\x4d\x5e\x9d\xfe\xc3\x97\x7b\x6e\x17\xaa\xb3\x5f\x28\x64\x30\xc1\x99\xc9\x91\xec\x18\xa5\xb1\xe2\x06\x9e\x93\xca\xde\x52\xea\x0d\x5d\x3b\xd7\xac\x42\xf8\xad\x16\x6c\x6e\x7e\xa7\x34\x6f\x26\xf0\xc4\xc1\xdd\xbe\x38\x88\xb8\x96\x52\x81\xdd\xc4\x51\xc6\x10\x89\xa3\x29\xd1\xf4\x3c\x5d\x1a\x05\xb3\x7c\xd9\x1b\xe4\x4a\xe4\x19\xf2\xb4\x52\xf6\xa0\xe5\x6a\xac\xf7\x58\xed\x15\xd6\x71\xb1\xa4\x81\x33\xcd\x64\xb9\x80\xe7\x4a\x83\xb2\x0b\xd4\x2b\x0f\xf2\x82\x56\xdc\x0d\xf3\x8a\xf5\xe7\x1c\x6b\xd6\x09\x17\x01\x60\xd2\x30\x63\x51\x4f\x9c\xc6\x39\xda\x95\xac\xf9\xbc\xd3\x58\x51\x1a\x41\x70\x41\xdf\xeb\x03\x49\x8b\x4c\xdb\x8b\xc2\x56\xa5\x4d\x6e\xc3\x9d\x45\x9b\x8b\x21\x89\x8a\x58\x2d\xd1\xa2\x99\x3a\xd9\x99\xc2\x58\xa5\xd9\x1c\x8b\xb9\x52\x73\x81\xac\xe5\x94\xa5\x37\xb7\x19\x8e\x38\x5d\x0c\xb8\x02\x82\x81\xc9\x1d\x66\x60\xde\x35\x5f\xed\x25\xda\xeb\x08\x3b\x08\x08\xeb\xfe\x3f\xe9\xe9\x33\xd4\x6c\xfb\x28\x78\xae\xa8\x14\x70\xee\xff\x45\x01\x37\x24\x67\x8d\x15\xa1\x67\xc2\xc0\x3d\x17\x82\x72\x00\x56\x55\x83\xc4\x7b\x03\xa9\x22\xf3\xf6\x01\xe4\x17\x6e\x49\x36\xbe\x08\x70\x9f\x6b\xb8\xd6\x4a\x93\xe0\x8c\x65\x9a\xf2\x88\x3f\x26\x64\x84\x96\x02\x15\xee\xff\x07\x03\x8f\xb9\xe3\xed\x39\xb6\x9f\x5c\xaa\xb5\x8f\x5a\x0c\xe1\xbd\x94\x2c\xd2\x3f\x8b\x58\x5c\x91\xd1\x2a\x87\x17\x74\x27\x65\x9e\x2d\x13\xe7\x6a\x2b\x6c\x43\xa2\x37\x89\xd2\xe3\xd2\x58\x26\x04\x65\x07\x4a\x07\x5f\x1c\x93\x08\x67\x0a\x39\x67\xbe\x72\x94\x15\xb6\x28\x2b\x94\x25\x47\x03\x5f\x9a\xce\xd8\x2f\xa4\x4d\xb1\xd8\xf1\x6d\x03\xee\xdc\x8d\xe9\xca\x12\x73\xda\xe1\xb9\x37\x53\x4a\x20\x4b\x25\x3c\xcb\x54\x11\x94\x64\x5c\x2c\x84\x82\x23\xb1\x4c\xcf\xd1\x62\x35\x8c\x5a\x01\x99\xf7\x24\x19\x12\xff\xb9\xf8\xa1\xf8\xf1\x00\xe3\x1f\x51\x83\x8a\x1b\x8a\x6e\x1f\x43\x25\xc9\x04\xaf\x9c\x7d\x6f\x13\xb5\x46\xd0\x79\x66\x5a\xec\xe6\x19\xaa\xf0\x5d\x14\x08\x20\x54\x7b\x04\x98\xc4\xfa\xb9\x01\x94\xb5\xd2\x65\x4a\x14\x63\x62\x70\x73\x3e\xf9\x80\xbd\x63\xc9\xaf\x09\xd4\x2b\x6d\xc3\xf4\x9d\x57\x81\x61\xd9\x4b\xfa\x70\x3b\x9d\x3a\x94\xb7\x31\x0b\x30\x29\x76\xdf\xb8\x52\x87\xe0\x62\xae\x1c\x6a\x27\xaf\xcb\xf4\x52\xab\x6e\xbe\x80\x0a\x05\x5a\x4a\x1d\x7c\x4b\x03\x78\x0d\x12\xb1\x7a\x2a\x95\x64\x2b\xc9\x8a\x7b\x8b\xc8\xc9\x9b\x15\x68\xd4\xb6\xa0\x59\x14\xba\x1c\xb9\x94\x3e\x38\x05\x2c\xe0\x6d\x0d\x52\x25\x3d\x43\xd7\xb6\x82\x63\x75\xec\xcb\x67\x75\x8f\xc6\xc2\x17\x94\x24\xf4\xa0\xb6\x01\xed\x97\xde\x5b\x47\xad\x2e\xc0\xe9\x43\x02\xeb\x70\x71\xbe\xa2\x06\xa6\xf1\x14\x9e\x2d\x5f\x3e\x3b\x86\x67\xcb\x57\xcf\x26\x5b\xb3\x50\x76\xcd\x36\xd1\x53\x58\xbe\x4c\xbd\x7c\x95\xe1\x6b\xd2\x30\x1a\xf6\xf0\x86\x9b\x74\x2e\xb0\xc6\xd5\xf7\x3d\x60\xe4\x69\xc3\x1e\x78\xd3\x35\xc0\x1a\xd5\x49\x4b\xac\xd5\xb8\xe4\xae\x29\x4b\xfc\xbd\x43\x6c\x13\xf4\x0f\x1b\x96\x7d\xb3\x25\x08\x61\xc0\x72\x6e\x63\xaa\xe3\x90\xfd\xf8\x43\x4e\x5b\xb8\xb4\x38\x47\xbd\x31\x3a\xe8\xc7\xee\xa0\xeb\x6a\x05\x99\xca\xa9\x6f\x32\x4b\x1d\xd5\x17\x6e\x7b\x85\x98\xa3\xa4\x98\x80\x15\xcc\x1e\x81\xd5\x35\x7f\x88\x8e\xbc\x0f\x8a\x21\x97\x4d\x26\x6a\xc1\xa6\x08\x36\xc7\x80\xa4\x58\xc9\xcd\xdb\xcf\x4e\xbd\x76\xd2\xdf\x43\xee\x72\x0c\x0e\x69\x66\xa9\x41\x95\x43\x5a\xde\x8b\x2e\x70\xb1\x77\xf6\xde\xcf\x87\x30\x17\xba\xd8\xc4\x90\x64\x6c\x09\xee\xa7\x80\x0f\xca\x02\x3e\xb4\x82\x97\xdc\x8a\x47\x30\x68\x43\xdf\xc6\x07\xb0\xdb\x9a\x09\x83\xb7\x80\x7f\xe9\x28\x73\xa2\x37\x56\x77\x98\x4a\x2c\xab\x0e\x57\x6b\x2c\x05\xd3\xbe\x9a\x90\xcc\x76\xba\x97\x79\x0c\xe3\x4f\x73\x50\x5a\x09\x31\x63\xe5\xdd\x0e\x7e\x93\x42\x45\xd0\x48\x89\x59\xc5\xe3\x74\x0f\x32\x3e\xe3\xb9\x5a\x88\x63\x6f\x94\xba\xcb\xe4\x72\xa9\xf8\xe5\xc0\x77\x89\xbe\xd5\xb8\xdc\x6e\x5f\xc5\x67\xe1\x50\xb8\xbe\x57\xc8\x78\xa0\xea\x74\x54\xf4\x48\xed\x61\x09\x86\xf7\xb7\x7b\x90\x73\xe1\x00\x47\x09\x21\x2e\xc7\xd5\x24\x83\xda\xee\xe5\xb8\x88\xb7\xc7\x6a\x9e\x18\x69\x77\xac\xea\xab\xc2\x6d\x2e\x29\xcf\x04\xe1\x7d\xb8\xd0\xb0\x87\x2b\xb4\x3a\x5b\x33\x6c\x46\x8e\x00\x9c\x8f\x1c\xb1\xdf\xa0\x3d\xe0\x48\x7a\xdb\xe7\xd8\xa1\xf1\xdd\xb0\x3b\x8c\x0e\x65\xc6\x38\x25\xcd\x69\x9a\xc8\xd5\x30\xeb\x02\xc6\x3f\xfd\xc3\x08\xd5\xe9\x80\x02\xce\xa9\x7a\x9e\xee\x55\x35\x07\xf6\xef\xd6\x80\x88\x75\xda\xaa\xca\xe4\x8a\x61\xd2\x5c\x5e\x03\xa3\x08\x53\x92\x9e\x17\x5e\x23\x82\x0b\x35\xd0\xaa\x8a\x74\xc2\xba\x3a\xf9\x20\x99\x12\xeb\xf7\x6b\x08\x58\xfd\xb8\xd3\xcc\xf6\x13\x28\xab\x2d\x6a\x60\x5f\xe9\x22\x2c\x6f\x50\x75\xfb\xf4\x0f\x6f\x3c\x64\x5f\x83\xf0\xc6\x49\xe1\x9e\xf1\x50\xc5\xcb\x47\xe0\xb2\xe2\x4b\x5e\x75\x4c\xc0\xaf\x7d\x0b\x23\xd7\xa3\x88\xbb\x69\xf0\x5c\xf0\x3b\x84\xff\x50\x33\xef\xcb\x9d\x47\x7c\x11\xbd\xe0\x38\x79\x5f\xaf\x98\xb4\xfe\x3d\xa8\xff\x4f\x16\x13\x93\xbc\x42\x3a\x56\x74\xd2\x72\x01\xcc\x1d\x28\x48\x3d\x97\xaa\x32\xc7\x70\xf9\xf9\xcc\x1c\xbb\x4d\x10\x5e\xa2\x09\x7b\x47\x5c\x3a\xcb\x96\x5d\x33\x43\x4d\x96\x4d\xb0\x6e\xeb\x0a\xce\xb1\x15\xea\xb1\x41\x99\x2d\x5f\xaf\x2d\xb3\x58\x77\xe2\x1a\xad\xeb\x7d\x5d\xa1\x53\xf7\x6b\xb4\x94\x23\x03\x97\xa4\x2a\xc8\xaa\x47\xb7\xed\xdc\x9b\x3d\x51\x33\xde\x79\x89\x04\x32\xe3\xab\x5d\x63\xea\x4e\x1c\xa2\x6c\x23\x55\x24\x15\xfc\x67\x57\xe7\xbb\xf2\xad\xeb\x00\xb6\x4b\x10\xae\x7f\x60\x7d\x53\x28\x57\x32\xaa\x1a\x1c\xaa\xa0\x66\x61\x07\xf3\x55\xec\x1f\xb8\x69\x4f\x4b\x62\x7c\xa2\xf6\x21\xdf\xaf\x59\x37\xa7\x75\x68\x50\x4b\xd4\x9a\x57\xb8\x51\xe1\xaf\x32\xdd\x5a\xa5\xd4\x77\xbb\x1c\xb8\x59\xe5\x90\x83\xd9\xab\xfc\x6e\x2d\x7f\x4e\x33\x66\xe3\x50\x44\x1f\x30\x9f\x94\x46\x5b\x34\x09\xc3\xda\xca\xe7\x08\xec\x1b\xe7\x72\x25\x09\xa7\x6b\xf7\xd9\xbe\xf2\x90\xc7\xee\x3c\x41\x60\xba\xdb\x57\xa2\xaf\xbf\x3c\x86\x0a\x2d\x52\x92\x8d\x7d\x3f\x22\xdf\x4d\xf4\xc9\x83\xa7\x87\xc2\x11\xcc\xd0\xde\x23\x4a\x40\x56\x2e\xfc\x6b\xdd\x49\x70\x67\x9a\x62\x51\x13\x19\x9d\xc1\xfa\x31\x9b\x4c\xc3\x1f\x90\xfd\x11\x09\x07\xc6\x47\x3e\x97\x4a\xe3\x6b\xc6\x45\xa7\xf7\xca\x7b\xde\xae\x4d\xf0\x4b\x2b\x59\x47\x6e\x68\xcb\xda\x67\x3e\x4d\xce\x76\x7a\x5d\xb5\xc0\x6b\xaa\x2c\x6b\xc6\x85\xf1\xdb\x0b\xf7\xdc\xe0\xb0\xd8\x14\x58\xdb\xe8\x25\xfd\x39\x17\xef\x28\xff\x3a\xa3\x2a\xc9\xf2\xfb\x44\xd4\x11\x2f\x9f\xe5\xca\x77\xe3\xc8\x9e\xdc\x18\xfa\x7c\x17\x8a\x43\x06\x36\x86\x31\x61\x09\xe3\xac\x1b\x63\x9b\xeb\x00\xbc\xe6\x02\xfd\xc6\xc9\xae\x38\xf8\x79\x03\x7c\xb0\xcb\x22\x54\xc9\x84\xef\xc2\xf4\xfb\x67\xbe\x53\xed\x41\x53\x42\x3f\xbf\xb8\xbc\xba\x38\xfb\xf9\xe6\xe2\xfc\x18\xc8\xc4\x7c\x47\xe3\xb5\x56\x4d\xe1\x67\xfd\x8a\x8f\x6e\xb3\x4e\x1a\x8b\x2c\x51\xf6\x70\x8b\x4d\xd2\xaa\x77\xed\x8f\x8c\xed\x8e\xec\xdc\x88\xcb\xef\x69\x64\x77\x34\x46\xf7\x33\xfc\x20\xd3\x9a\x6d\x6e\x88\x2e\xf7\x69\x06\x85\x3e\xd0\x4a\x14\xa1\xad\xb3\x67\x48\x7b\x98\x0e\x36\xfd\xdc\x56\x83\x5e\xe2\xb4\x93\x77\x52\xdd\xcb\x69\xcd\x51\x54\xe6\x14\xac\xee\x36\xe9\x5a\xf6\xd2\xda\x5e\xe0\xc1\x82\x71\xbd\x26\xd2\xb0\xec\x7e\x5f\xaa\x35\x33\x3c\xf2\xc1\x82\x2a\xfa\xae\x55\xcd\x5d\x80\xb1\x0b\xd0\x6b\xa7\x3a\xb7\x9f\x70\x52\x2a\x33\xbe\x7b\xc3\x8d\x4c\xd4\x9f\x66\xcd\x43\x6c\xac\xfd\x63\x98\xb0\x19\x01\xcf\x22\x13\xae\x51\x60\x49\x75\x33\xcb\xb9\xdd\xf5\x2f\x17\x81\x1d\x86\x72\xb0\x78\x3e\x8d\xb9\xad\x45\x66\x07\x2e\xc4\xbd\x6b\x29\xc3\xb0\x36\x1b\xbe\xfc\x13\xce\x24\xf8\x83\x90\x2e\x19\x3b\x8e\x0d\x39\x6e\xfd\xee\xb1\x3f\xde\x6a\xb1\x69\x95\x66\x9a\x8b\x47\xe8\x24\x5b\x32\x2e\x5c\xcd\x3b\x82\x7d\x57\x34\xf3\x4f\xfe\x0c\x42\x82\xa5\xc3\x93\x08\x4e\xf6\xc3\xe3\x08\xa1\xfb\x12\xcf\x23\x8c\xd2\xbd\x7e\x82\x21\x7b\x20\x61\x48\xc8\xe8\x76\xea\xf8\x56\x28\xb9\x0e\x5a\xe9\xa8\x4b\xca\x6e\x87\x42\x38\x6c\xf0\x9e\xb5\xde\x63\x1e\x6a\x39\x1e\x0d\x34\xac\x5d\xb3\x99\x6f\x60\x1b\x77\x98\x69\x53\x0c\x09\x1c\x65\xe0\xae\x2d\xed\x27\x20\xd9\xb1\xad\xbd\x27\xa6\x7d\xcc\x7d\x1f\x0d\xdf\xad\x19\xd9\x6d\xf2\x3d\x34\x03\x1f\xfc\x61\xd1\x6b\xa7\xfb\x87\xeb\x86\xec\x31\x45\x33\xfa\xff\xe6\x55\x2f\xd6\x38\x11\x5d\xeb\xa8\x13\x60\xe6\x20\xd7\x3a\x8a\xb3\x77\xbb\x07\xb9\xd6\x51\xd4\xdf\xd4\xed\x76\x7a\x7f\x96\xa7\xcf\x1a\x6d\x28\xcc\x77\x77\xa0\x9d\xce\xf5\xbe\x76\x5a\xc9\x30\xdd\x3c\xd4\x79\x86\x83\x45\x7f\x73\x9c\x7f\x0d\x8e\xf3\xc0\x84\x3d\xdd\xd4\xfd\xca\x86\xae\x6f\xdd\x26\x16\xf9\x15\xcd\xdc\xb5\xb6\x6d\xaa\xdc\x7c\x6a\x23\x77\xa3\x65\x9b\x40\x39\xd6\xc4\xcd\x8b\x3b\x2d\xe4\xa9\x4f\xcc\x8e\xf6\x10\x99\xbf\x5a\x34\x72\xdd\x60\xd0\x70\x0c\xd7\xa4\x4a\x25\x2d\xe3\xd2\xc4\x8b\x52\x5c\xfa\x0a\xdb\x55\xe9\x33\x72\xd9\x4c\xa6\x2e\x6f\xf9\x67\xe4\x6c\x7c\x7f\xbd\x69\x87\x8e\x9c\xf5\x80\xab\xb5\xa8\x19\x55\x66\x21\xc8\x04\x0f\x1b\xfb\xa3\x13\xbf\x54\x4c\x49\x92\xe2\xc8\x31\x2c\x98\x19\xe4\xb3\xf7\x0b\x5e\x2e\xc0\x9f\x46\x45\x6d\x7c\x33\x0b\x25\xd4\x68\xcb\x45\x6a\x7f\xf1\xe0\x7a\x4e\x30\x63\x6f\x34\x93\x86\xc7\x1b\x44\x7b\xb9\xd7\x77\x5b\xd3\x86\x6d\x19\x7f\x4f\xab\x54\x5a\xa3\x69\x89\x55\x23\xce\xa6\xbf\x48\x63\x6c\x14\x67\xb9\x60\x72\x8e\x7d\x10\xef\xa5\x92\x73\xd3\xb1\xbd\x52\x31\x8b\x53\xfa\xfe\x61\x8d\x03\xcf\x0c\x7f\x06\xf1\x49\x8c\x58\x4d\xd9\xc1\x04\xb0\x2a\xcf\x87\x0d\x26\xf8\x2d\xe8\x3f\x80\x09\xe1\x06\xdf\x5e\xd4\x87\x9b\x7d\xfe\x2e\xd0\xa2\x6b\x98\x74\x1e\xc8\x75\x8d\x07\x80\xc1\x1c\xb2\xb4\x57\x68\x7d\xff\xb5\x5e\xb1\xc1\xf6\xda\x75\x0c\xa5\x6a\x5a\x81\x4d\xb8\x59\xa4\x91\x99\x3c\x1f\xf6\x38\xf3\x4a\xd3\xf7\x22\xef\xca\x81\x7a\xea\x66\x9a\x63\x0d\x0d\x2b\x17\x5c\xe2\x8a\x4a\x7c\x68\x05\x93\x63\x37\x57\x56\xa7\xb3\x7a\x19\x4e\xcc\x26\x8d\x07\x53\x93\xf2\x9e\x19\x6a\xae\xd7\x6e\x95\xf6\x8b\x39\x06\x25\x9d\x9e\x3d\x9f\xdc\xe8\x0e\x27\xc7\x30\x79\xcd\x84\xc1\x49\x6e\x43\x11\x60\xf2\xc9\x77\x9f\x26\x99\x4b\x0b\xb9\xc3\x75\xfe\x99\xc2\x33\xfa\xd0\xb3\xfc\xb0\xfb\x7e\x7e\x3c\x7c\xfd\x50\x96\x39\x80\xbd\x72\xc8\xc7\x16\xc7\xd8\xe5\xdb\x40\xde\x25\x8f\x71\xcb\x87\x75\x82\x81\x49\xbc\x2b\xeb\xfe\xef\x36\x27\xfe\x9d\x95\x77\xf4\xd7\x0d\x1a\x8b\xd5\xa1\x2c\x1d\xae\x25\x0b\x14\x17\x92\x05\x88\xab\xcb\x03\xf4\x4b\xce\x82\x78\x3a\xbe\x47\xef\xd6\x0c\xef\x2b\xaf\x0f\x11\xde\x6f\x97\x25\x92\x71\xfe\x6c\xa9\xaa\xb3\x58\x5d\x85\xf3\x97\x3b\x52\x82\x77\xa9\x39\x31\x1e\xc4\x33\x9c\x2b\x0f\xe7\xb6\xfd\xb6\x73\x24\xff\x98\x47\x59\xae\xae\x8d\xce\xd0\x67\x87\x35\xe3\x02\xab\x7c\xa3\x78\x84\xb7\x3e\x37\xc1\xea\x17\x7f\x70\x72\x37\x35\x1f\xb7\x26\xf4\xc7\x8c\x94\x71\x17\x62\x51\xda\x78\x0e\xd3\x11\x16\x26\x24\xa8\x09\xdb\x99\xf9\x03\x80\x87\x6f\x8f\xb8\xbb\xf7\x3b\x48\xf1\xf7\xff\x87\xd9\xaf\xbb\x54\x9d\xb5\x63\x18\xfc\xfd\xda\xb1\x3c\x69\xdb\x93\xb7\xfd\x7d\x02\x9a\xf3\x29\xde\x27\xa0\x3f\x86\x06\xef\xff\x1f\x11\x79\x33\x77\x60\x29\x9c\x34\xb8\x0e\xea\xff\x77\xed\x6f\x13\xac\xdc\x06\x97\xf3\xe8\x37\x86\x5e\x24\x85\xf3\x2a\x9c\x93\x09\x78\x5f\x6c\xc1\xe4\x0e\x42\x8f\xba\x94\xe1\xb0\xc7\x9c\x00\x59\xb1\x28\x31\xd8\x73\x2c\x31\x96\x75\x54\x71\x28\xfb\xc9\xc0\xde\xcc\xc8\xe8\xb4\xe4\x40\xcf\xf9\xc4\x58\x16\xdb\x40\x42\x99\xd1\x8c\x0f\xf5\x83\x2b\x69\x3d\xc5\xc6\xbf\xfe\x74\x36\x33\x80\xdc\xdd\xad\x88\xa7\xae\xa9\xcc\xec\x0f\x5b\x3f\xf1\xac\xf4\xa0\x60\xdb\x6f\x3d\xc9\xdf\xc0\xd8\xfc\xb9\x8b\x98\x4c\x25\x83\x87\x37\xef\x86\x49\x36\x8f\xc7\x28\xdc\xfd\xff\x03\x0e\xa9\xe8\xfd\xbc\x7e\xef\xe8\x57\xbb\x7b\xbf\x70\x4b\xd5\xdc\x82\x78\x37\xb8\x21\xb1\x2a\xee\xc2\x6f\x90\x24\x7b\x85\x55\x50\xfc\xa7\xad\x35\xe8\xcc\x99\xea\xe4\xae\xee\xc6\xd5\x10\xd6\xfd\xb0\x81\x8e\xd7\xf0\x56\x37\x0e\xe2\x49\x6a\xe6\xe3\x59\x2a\xe4\x36\xac\x42\x77\x26\xdf\x39\xd3\x52\xbb\xec\x1c\xab\xad\xc3\x87\x2e\x6a\x75\x1a\x5d\x34\xf3\x67\xdd\x1d\x48\x02\x25\x1b\x74\x20\xfa\xa3\x8e\x4a\xaf\x82\xa7\xaf\x0b\xbf\x5d\xfc\x48\x66\x07\xdb\x29\xc8\xb4\xbf\x8c\x3f\x78\x65\x5a\x2c\x8f\xb2\x88\x7c\x2c\x1c\xec\xca\x86\x5b\x9a\xc3\x37\xdd\xac\xff\x51\x86\xf8\xad\x90\xcc\xc3\x7f\xff\xcf\xd1\x2a\xaf\x67\x65\x89\x94\x53\x7c\xd8\xfc\x09\xa1\x67\x3e\x43\x8e\xbf\x0d\xe4\xfe\x1c\x74\x30\xe0\xb7\xdf\x8f\xfc\x87\xb1\xfa\x1c\x7f\xd7\x87\x5e\xfe\x6f\x00\x00\x00\xff\xff\x8d\xf1\x4f\xfe\x7c\x49\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x7d\xeb\x73\xdb\x38\x92\xf8\x77\xfd\x15\x5d\xf9\x7d\xb0\x53\x65\x31\xaf\xdf\xbd\x5c\xb7\x77\x9b\x75\x92\x49\x6e\x32\x33\x2e\xdb\xc9\x7d\xd8\x9a\x8a\x20\xb2\x25\x61\x4d\x12\x1c\x00\x54\xac\xbd\xba\xff\xfd\xaa\xf1\xe0\x43\x02\x48\x4a\x49\x6a\x5f\x89\x5d\x33\x96\x08\x36\xbb\x1b\xfd\x46\x03\x9c\xcf\xe7\x33\x56\xf1\x8f\x28\x15\x17\xe5\x25\xb0\x8a\xe3\x83\xc6\x92\x3e\xa9\xe4\xfe\x5f\x55\xc2\xc5\x93\xed\xb3\xd9\x3d\x2f\xb3\x4b\xb8\xaa\x95\x16\xc5\x0d\x2a\x51\xcb\x14\x5f\xe1\x8a\x97\x5c\x73\x51\xce\x0a\xd4\x2c\x63\x9a\x5d\xce\x00\x58\x59\x0a\xcd\xe8\x6b\x45\x1f\x01\x52\x51\x6a\x29\xf2\x1c\xe5\x7c\x8d\x65\x72\x5f\x2f\x71\x59\xf3\x3c\x43\x69\x80\xfb\x47\x6f\x9f\x26\xcf\x93\x7f\x9a\x01\xa4\x12\xcd\xed\x77\xbc\x40\xa5\x59\x51\x5d\x42\x59\xe7\xf9\x0c\xa0\x64\x05\x5e\xc2\x06\xf3\x42\x62\x8e\x4c\xa1\x4a\xe8\x43\xb2\xca\xeb\x87\x34\x4b\xb8\x98\xa9\x0a\x53\x7a\xea\x5a\x8a\xba\xba\x84\xbd\xab\x16\x82\x43\xcb\x92\xf4\x16\xf3\xe2\xc6\x02\x33\xdf\xe6\x5c\xe9\x1f\xf7\xaf\xbc\xe7\x4a\x9b\xab\x55\x5e\x4b\x96\xf7\x51\x30\x17\xd4\x46\x48\xfd\x73\x0b\x7c\x0e\x1b\xd9\xfc\xe1\x86\xf0\x72\x5d\xe7\x4c\xf6\xee\x9e\x01\xa8\x54\x54\x78\x09\xe6\xe6\x8a\xa5\x98\xcd\x00\x1c\x53\x0c\xa6\x73\x60\x59\x66\xd8\xcc\xf2\x6b\xc9\x4b\x8d\xf2\x4a\xe4\x75\xe1\xd9\x3b\x87\x0c\x55\x2a\x79\x45\x43\x2e\xc1\xa1\x0c\x5c\x81\xde\xa0\x21\x18\xc4\xca\xfc\x4d\xb4\x82\x7b\xf0\x05\x30\x05\x6b\xbe\xc5\x12\x96\x3b\x43\x6b\x62\xb0\x04\xf8\x93\x12\xe5\x35\xd3\x9b\x4b\x48\x94\x66\xba\x56\x89\xbb\x85\x30\x74\x63\x08\x6a\xf3\x28\xf7\x9d\xde\x11\x19\x4a\x4b\x5e\xae\x43\x88\x5d\x6f\x3a\x68\xa5\xb5\x94\x58\x6a\x8f\x0d\x54\xe6\xe2\x12\x79\xb9\x86\x0a\xe5\x4a\xc8\x02\x33\x58\x09\xd9\x20\xee\x1e\x16\xc7\xb2\xda\xb4\xb8\x58\xfc\xae\x37\xd3\xb1\x73\xe0\x6f\x0d\x2c\x8f\xa5\xa5\xff\x2b\xb1\xcf\x82\x0e\x31\xb0\x77\x25\x80\xe8\x21\xc8\x54\x94\x56\x24\xd4\x1f\xff\xf3\xfc\xf7\x09\xdd\xf3\xbb\xdf\x3d\x72\xe0\xb2\x47\x8f\x7f\x4d\x0a\x54\x8a\xad\xfb\xfc\xf8\xa9\xf7\xdd\x18\x47\xae\xf6\xd5\x90\xb8\xc2\x40\x37\x1f\x25\x56\x12\x15\x96\x9a\x26\x8d\x18\xa4\x50\x6e\x51\x9a\x11\xf0\x79\x83\xa5\x7b\x10\x80\xde\x70\x05\x62\xf9\x27\x4c\x35\x7c\x66\xca\x6a\x38\x66\x09\xbc\xd3\x04\xb4\x14\x1a\xd6\x35\x93\xac\xd4\x88\x19\x68\x01\x4b\x02\xa6\x81\x97\xb0\x61\x55\x85\xa5\x9a\x2f\x71\x25\xa4\x47\x1d\x40\xc8\x0c\x25\xb0\x54\x0a\xa5\x40\x61\xc5\x24\xd3\x08\xa2\x42\x69\x70\x56\x09\x5c\xe5\x1c\x4b\xad\xa0\x60\x3b\xf3\x00\x82\x67\xf0\xd8\xb2\xbc\x46\xff\xe8\x86\x06\xa3\x76\x04\x19\xe8\xa9\x37\x6f\xae\x5e\xbc\x78\xf1\x6f\x24\x80\x05\xb0\x32\xa3\xa1\xbc\x84\x0f\x77\x57\x81\x69\xf6\xc6\x2f\x39\x30\x5c\x6e\xac\xe5\xfe\xcb\x3d\xce\x67\x4c\xdb\x2f\xec\xe5\xed\x33\xf3\x41\xa5\x1b\x2c\x8c\x1d\xa5\x4f\xa2\xc2\xf2\xe5\xf5\xbb\x8f\x2f\x6e\x7b\x5f\x43\x7f\xa6\x3a\xea\xe1\xe6\x68\x57\x21\xb1\xb1\xa1\x0e\x58\x4f\x7a\x3d\x11\x00\x95\x24\x9e\x69\xee\xed\x96\xfd\xe9\x78\x84\xce\xb7\x7b\x4f\x3d\x23\xc4\xec\x28\xc8\xc8\x15\xa0\x55\x1a\x67\xbb\x30\x73\xb4\x58\xf5\xe9\xf2\xda\x4c\x51\x0f\x30\xd0\x20\x56\x3a\x19\x49\xe0\xd6\x48\x92\x02\xb5\x11\x75\x9e\x91\x07\xd9\xa2\x24\x6b\x91\x8a\x75\xc9\xff\xdc\xc0\x56\x44\x25\x3d\x34\x67\x1a\x9d\x8d\x6e\x7f\x8c\xad\x2c\x59\x6e\xa7\xfc\xc2\x4c\x24\x89\x83\x44\x23\x89\x75\xd9\x81\x67\x86\xa8\x04\x7e\x12\x12\x81\x97\x2b\x71\x09\x1b\xad\x2b\x75\xf9\xe4\xc9\x9a\x6b\xef\x09\x53\x51\x14\x75\xc9\xf5\xee\x89\x71\x6a\x7c\x59\x6b\x21\xd5\x93\x0c\xb7\x98\x3f\x51\x7c\x3d\x67\x32\xdd\x70\x8d\xa9\xae\x25\x3e\x61\x15\x9f\x1b\xd4\x4b\x22\x58\x25\x45\xf6\xff\xa4\xf3\x9d\xea\xac\x87\xeb\x81\x2e\xda\x5f\xe3\xa2\x06\x66\x80\x1c\x95\x9d\x71\x7b\xab\x25\xf4\x50\x31\x6f\x5e\xdf\xde\x81\x7f\xb4\x99\x8c\x1e\x50\xf0\xba\xd9\xdc\xa8\xda\x29\x20\x86\xf1\x72\x45\x7a\x4d\xda\xb3\x92\xa2\x30\xd3\x8c\x65\x56\x09\x5e\x92\x52\x21\xa4\x46\xd9\xf6\x80\xaa\x7a\x59\x70\x4d\xf3\xfe\x5b\x8d\x4a\xd3\x5c\x25\x70\x65\xc2\x03\x52\xf0\xba\xca\x9c\x11\x28\xe1\x8a\x15\x98\x5f\x91\x64\x7e\xeb\x09\x20\x4e\xab\x39\x31\x76\xda\x14\x74\x23\x9b\xf6\x1f\x41\xb9\x74\x5c\xeb\x5c\xf0\xd1\x07\xc0\xb0\x7e\xd1\xcf\x92\xa5\xf7\x62\xb5\xda\xff\x7a\x6f\x8a\xef\x36\xe8\x47\x92\x45\x24\x53\xab\xc8\x2a\x81\x44\x2d\x39\x2a\xd2\x9b\x15\xe3\x39\x66\x46\x39\xca\x94\xe7\xdc\xa8\xd7\xfe\x0c\x3b\x1d\xa3\xb9\x8f\x98\x82\x71\x94\xe9\x67\xc9\x14\x86\xbe\xdf\xc3\xfb\x0f\x1d\x5f\x9f\x61\xce\x76\x60\x0d\xb8\xf9\x62\xc5\xa5\x22\x61\xd3\x72\x47\xf8\x33\x47\x41\x10\x2a\xec\xd1\x75\x01\x98\xac\x13\x58\x3c\x7b\xaa\x16\x17\xf0\x79\xc3\xd3\x0d\x64\xa2\x5e\xe6\xc4\x8b\x12\x70\x8b\x72\x47\xf6\x42\x61\x5a\x6b\xbe\xc5\x59\x10\xa6\x79\x62\x2d\xc9\x0d\xac\xac\x77\xa8\xab\x2a\xe7\x98\x5d\x00\xd7\x64\xcd\x58\x9d\xeb\xc6\xb8\xa4\xa2\x5c\xf1\x75\x2d\x0d\x93\xbb\x21\x0f\x40\x70\x4e\x61\x19\x64\xec\x80\x94\xf9\x9f\x82\x3d\x4c\x60\xee\x4f\xec\xc1\xf3\xb6\x60\x0f\xbc\xa8\x8b\x86\xc7\xfa\x33\x62\x79\x28\x1c\x93\x58\xab\x3c\x6f\x5f\x3c\x2d\x16\x93\x38\x13\x01\x7b\xc8\xaf\x86\x33\x05\x7b\x38\x81\x31\x11\x75\xa3\xdf\x74\xc3\xa4\xbe\x3c\x52\x88\xcd\x4d\xd7\x75\x9e\xdf\x62\x2a\x51\x4f\x60\xf9\x55\xff\x0e\xd8\x88\x3c\xb3\x33\x20\x71\x85\x12\xcb\x14\xbd\xb4\xb0\x5a\x6f\x28\x28\x4a\x43\x6e\xce\xff\x53\xe6\xc1\x46\x93\x59\x9a\xa2\x52\xde\x54\x3b\xdd\xac\x84\xe2\x5a\xc8\x5d\x62\x2c\x80\x1b\x4d\xa6\x98\xfc\x0e\xe3\x31\xb0\x8b\x5a\xa1\xa4\x78\x62\x61\x9c\xdd\xa2\x62\x4a\x7d\x16\x32\x5b\x98\x27\xbd\xbd\xbb\xbb\xbe\x25\xe1\xe4\xa9\xc1\xf2\x02\x18\x2c\x91\x49\x94\xb0\xd0\xe2\x1e\xcb\xc5\x45\x04\xae\x01\x96\xa2\xd4\x6f\x78\x8e\x8b\x0b\x58\xdc\xe3\xce\xfc\x69\x1f\x93\x32\xfb\x81\x0c\xa5\x79\xd2\xdd\xfb\xdb\x3d\x3e\x84\x67\x1d\xe0\x8d\x90\x6e\x62\x41\x69\x21\xd9\x1a\x5b\xea\x39\x2a\xa3\x8f\x44\x37\xcb\x95\xf0\xc4\x1b\x36\x2f\xbc\x03\x5a\x0c\xe1\x2c\x71\xcd\x45\xb9\xf0\x21\xfc\xb2\x4e\xef\x51\x87\x71\x19\x16\x99\x26\x50\x8b\x5c\x1b\x91\x60\xfa\x25\x3f\xc8\x25\x66\x97\xc1\xab\x73\x93\x13\x04\x2f\x0d\x88\x3f\xfd\x52\x50\xc2\x27\x09\xb1\x19\xe8\x4d\x07\x3e\xb0\x54\xc3\x0f\x5c\x3b\x00\x24\xbf\x35\x65\x36\x5c\x83\x66\xf7\xa8\xa0\x92\x98\x62\x46\xb2\x1d\x84\x0d\x20\x4c\xb8\xbf\x41\xb8\xc5\xe2\x23\x4a\x90\xac\x5c\xe3\x05\xdc\xb1\xb5\xe1\xfe\x0d\xae\x92\xd9\x09\xac\x5a\x4f\xa2\x86\x30\xff\x70\xf3\xde\x93\x43\x7f\xba\x59\xa6\x2b\xad\x0a\x79\xab\xb6\xe6\xfa\xf7\x6b\xae\x37\xf5\x32\x49\x45\x71\x29\xe4\xfa\x09\x0d\x8a\xca\xfc\x82\xc2\x0f\x1b\xfe\xb9\x7b\x9e\xb4\xf7\x80\x90\xb0\x50\x6a\x63\xaf\xff\x1e\x1f\x58\x51\xe5\x68\x00\x3f\x7f\xfe\xfc\x79\x33\x32\x59\x73\xbd\x38\x89\x09\xf9\x4a\x4d\x60\xc2\xfb\x37\xb7\xf0\x99\xe7\x39\x68\xa4\xff\x6c\x7c\x0e\x44\x89\xb3\x80\x15\xea\x74\xd3\xb0\x84\xc6\x5a\x21\x0a\x05\x07\x4d\x80\xd0\x51\xc0\x1d\x9c\xd3\x3c\x52\x10\x47\xc1\x9c\xc8\xea\x1c\xd5\x63\x93\xdb\x01\x3e\x54\x42\x36\x11\xa6\xb1\xa8\x61\x3a\xc1\x98\x30\xf7\x60\x60\x12\x2d\x5a\x98\x41\xdd\x58\x3d\x6b\x55\x47\xe4\x65\x29\x44\x8e\xac\x9c\x1d\xa3\x98\x3d\x66\x51\x01\x23\x5a\x17\x31\x14\xc0\xa7\xcf\x5c\x6f\x44\xad\x3f\x01\x2b\x81\xe5\x9c\xa9\x98\x78\x18\xa1\x92\x98\x71\x05\xe7\x64\xf2\x16\x54\xd5\x81\xba\x5a\x4b\x96\x21\xfc\x71\x95\xb3\xb5\xfa\x15\x94\x66\xcb\x1c\x9f\x98\x71\x8b\xc7\x27\x09\x82\xe5\xdc\x0d\xae\x26\x50\xf8\x8b\x19\x6b\x9c\xd5\xad\xc9\x33\xc0\xa5\x1b\xed\x24\xd9\x08\x9e\xc1\x95\x71\xd2\x3f\xb1\x2a\x08\x95\x32\x6c\x37\x2b\x17\xc0\x4b\xa5\x91\x65\xc4\x2e\x52\x2d\xf2\x59\x07\x7e\xea\x44\x93\x7a\x8f\xbb\xd8\xa5\x3d\xd2\x7e\xc4\x9d\x9f\xbb\x7b\xdc\xf9\xa9\xab\x58\x7a\xcf\xd6\x98\x39\xda\xce\x17\x89\x5e\xff\x79\xf1\x38\x0a\x92\x92\x42\x73\xe3\xf9\x92\x97\x4c\xee\x1e\x5b\x9f\xe5\xa0\xf9\xf4\xd3\x87\x3e\x44\x7c\xfb\xfd\x10\x50\x45\xfa\x80\xa9\xb6\xe5\x0b\x8a\x0e\x5c\xa4\x6b\xe2\x52\x93\xdb\xd6\x25\x21\xeb\x51\x0d\x8b\xc2\x04\x71\x08\xe7\x85\x71\xb6\x51\x8a\xd8\x23\xcf\xc4\x2e\x3d\xbd\xbd\x00\x51\x22\x88\x55\x14\x22\xc0\xf9\x59\x23\x2f\x67\x17\x70\x66\x25\xe3\x2c\x22\xd0\xf4\x8b\x65\x5d\xc4\x51\x9c\x8f\x8a\x1f\x8d\xb1\x4f\xf9\x12\x46\x0d\x7b\xec\x1e\xa3\x7e\xee\x18\x84\x18\xa3\x92\x6f\xe7\xfc\x69\x4a\xbf\x72\x5c\x50\x51\x11\x77\x36\x4a\x38\x55\xb1\xbc\x66\xd1\x2d\x3e\x8e\x35\xa2\x41\x01\x3c\xa3\x3c\xca\x7f\xdb\xaa\x7c\x10\x32\x80\x14\x22\xc2\xa7\x11\x1e\xc9\x49\x16\xee\x06\x57\x1e\x59\xb2\x45\x4b\xc9\xca\x74\x03\xe7\x14\x45\xea\x0d\xca\x36\x1c\x7f\xec\xe2\x99\xd8\x9c\xbd\xea\xa4\x32\x67\x05\x53\x1a\xe5\xd9\x05\x08\xb9\x9f\xf1\xb9\x94\xc7\x78\x50\x79\x62\x40\xd3\x32\x6d\x12\x85\x95\x08\xc7\x35\x7b\x36\xd7\x07\x36\xbe\x4c\x42\x0b\x2b\xb2\x44\x8d\x6a\x6e\xe6\x4e\x25\x2e\xa2\x4e\xd6\x42\xac\x73\x64\x15\xa7\xca\x71\x11\x0b\x98\x85\x6c\x61\x39\x00\x9d\xb8\xe6\xb4\x28\x46\x79\xc7\x3e\x81\xf0\x26\x08\xe8\x64\x57\xfd\x24\x22\x98\x36\x05\x01\x43\x23\x21\x2d\xbf\x12\x93\x6c\x98\x24\xc8\x9a\xf5\x68\xb6\x14\x01\x79\xee\xa4\x63\x61\xc3\xc8\x97\x55\xf5\xee\xd5\xe2\xa2\xfb\xb1\x54\x9a\xe5\xb9\x49\x78\xde\xbd\x72\x50\x9b\xab\xd7\x92\x6f\x99\xc6\x1f\x71\x17\x9d\x01\x2a\x86\xfc\xc0\xf5\xdb\x7a\x09\x2f\xab\xea\xb1\x77\x56\x8e\x6c\x8a\x9d\x6a\x45\x45\x0a\x02\x2c\xc9\x25\xb3\x35\xe3\x65\x53\xf8\x88\x80\x35\xf1\x16\x28\x01\x92\x16\xe6\xc8\x03\x49\x0a\xeb\x35\x67\xb9\x0d\xc8\x2a\x6e\x1c\x53\x5d\x59\x16\xdd\xde\xbe\xb5\x0c\xaa\x2c\xc6\x11\xb0\xe4\x86\x1d\x82\x0b\x6e\xe0\xe9\x5d\x93\x69\x39\x94\xb9\xea\x60\x4c\x20\x37\x82\x2a\x81\xd1\x04\x96\x86\x2c\xee\x4b\xf1\xb9\xfc\x64\x46\xee\xc3\x3b\xe7\x2b\x70\x35\xca\xc7\x06\x75\x2d\x6b\x45\x5e\xd7\xc5\x28\x11\xb0\x0e\x88\x01\x09\x06\xbc\xb7\x67\x3e\x5c\x3e\x35\x80\x19\xf6\x30\x13\x5d\x94\x59\xf7\xbb\xfc\x76\xce\xe5\x54\x0f\xa2\xb0\xd8\xa2\x9c\xa4\xba\x26\xff\xb3\xa5\x68\x73\x93\xcd\x05\xe1\xdc\x9a\xa8\xff\xf8\xdd\xb3\xe4\x69\xf2\x14\xfe\xfd\x39\xfd\x6f\xf1\xf8\x62\xa0\x7a\xb4\xe1\xeb\x0d\x2a\xca\x41\xd7\x50\x30\x9d\x6e\xbc\x0b\xb6\x10\x9d\x44\x99\x05\x9c\xfd\x34\xd5\x24\xa4\x11\xb0\x04\xa1\x9b\x98\x52\x96\x4a\x7e\x84\x69\x23\x47\xa5\xa0\xe2\x39\xcf\x3c\xfa\x7e\x05\xd6\x5c\xe4\xeb\x52\x48\xcc\x4e\xb3\x80\xf7\xbc\x7a\x85\xd5\x07\x53\xed\x9e\xc2\xca\xee\xf8\x81\xdc\x4e\xdd\xf3\x0a\x64\x5d\x96\x31\xa1\x00\x38\x33\x29\x49\x86\x95\xab\xb5\x9f\xf9\x22\x2c\x29\x0b\xcb\x73\x62\xac\x90\x2e\x67\xe9\x05\x3a\xb1\xa4\xa7\x8d\x0b\x32\xac\xb0\xa4\xda\x00\x15\xa1\x3f\x15\xb5\xd2\x9f\xa8\xa8\xef\x74\xd3\xad\xdc\x92\x0b\x13\xa0\xea\x34\x45\xcc\x4e\x4b\xed\xda\xbc\x73\x0a\xef\x9a\xc1\x03\x8c\x4b\x37\x98\xde\x83\xa8\xf5\x80\x0c\x92\xe3\x68\x9f\xec\x6d\x50\xeb\x4a\xe0\x5c\x62\x5a\x4b\xc5\xb7\x98\xef\xf6\x13\xe2\x31\xde\xb9\x9a\x5e\x0b\xfe\x9b\xe4\xc4\x9a\xad\x27\x70\x8c\x34\xa2\x13\x4e\x91\xce\x0d\x94\x81\xc6\xf4\x6b\x04\xd9\xa8\x8e\x68\xb6\xbe\x96\xb8\xe2\x53\x8a\xdf\x77\x7e\x2c\x85\x81\x04\xb1\xaa\xa8\x53\x80\x92\x5a\x4d\x1a\xdd\xae\x33\xec\x8c\xee\x1a\x13\x12\xad\x80\x1b\xff\xa9\x74\xa0\x88\x65\x80\xb9\x6a\x00\x70\xdd\xb3\x03\x70\x47\xcb\x28\x2c\xcf\xc5\xe7\x58\x29\x45\xb3\xf5\x9a\x66\xb2\xa8\x73\xcd\xab\xdc\x4d\x3d\x79\x3e\x93\x6c\x1d\x46\x71\x95\xc8\x68\xd5\x6b\x6e\x4a\xb5\x11\xa0\xcd\xa0\x67\xc9\xf3\xe4\xc5\x69\x11\xd9\x16\x25\x5f\x4d\x89\x43\x3f\x9a\x81\xde\xb7\xb8\xb6\x0d\x92\x5f\x57\x53\xf0\xeb\xf4\x7c\x5d\x62\x06\xcb\x58\x3e\x40\xd4\x3a\xfd\xb9\xc7\x9d\xf2\x11\x83\x29\x9b\xd3\x8a\xca\x3d\xee\x48\x2a\x6c\xe4\x41\x7a\xd7\x7d\x46\x94\xbb\xd4\x14\xf2\xfa\xe5\x2b\x5f\xba\x74\x0f\xa0\xd8\x91\x4c\x51\x07\xaf\x73\xab\x50\x3f\x5c\xff\x40\xb1\xfd\xed\xed\xdb\xc7\x31\xdb\x46\x26\x6b\x2f\xc2\xee\xe3\xd2\xe1\x80\x79\xc8\x86\x6d\x11\x18\x15\x39\xb6\x58\xb2\x78\x95\x74\xc5\xf3\x06\x9d\x06\x9b\x04\x3e\x94\x66\x2a\xb8\xaf\x08\x58\x3b\x20\x71\x45\x01\xd3\xa9\xe1\x48\xea\xd3\xea\x68\xc8\x7d\x30\xcf\x4d\x26\x4e\xdc\x33\xd3\x62\xac\x76\xa7\x42\xd4\x4d\x84\xa3\x30\x01\xaa\x7a\x99\xf3\xd4\xcc\x72\x18\xfd\x69\x24\x8c\x47\x55\x13\xa4\x7c\x4a\x5c\x34\x12\x1b\x4d\x88\x8f\x26\x64\x38\x03\x59\x4e\x97\xd7\x9d\x65\x25\x6f\xff\x2d\x3b\xa3\x40\xe1\x1f\x8b\xd1\x23\x03\x5c\xb8\x76\x39\x1b\xe5\xbf\x6f\x59\x71\x5e\x4f\x33\xb9\x46\x4a\x20\x3a\x45\x60\x07\xcc\x5a\xe6\x20\x44\x80\x7f\x49\x9e\x26\xcf\x92\xd9\xd1\x1c\x1b\xa0\x23\xe3\x8a\x8a\xc5\xbf\xb8\x9e\x1f\x8a\x46\x99\x0e\x12\xd5\x23\xe8\x55\xe4\x36\xdf\x77\xa9\x28\x3c\x31\xb5\x11\x37\xc4\x06\xba\xb1\xd5\x49\xae\x00\xcb\x95\x90\x69\xc8\x08\x0d\x05\x1d\xe6\x9e\x0f\xb6\xfe\x3d\x82\xf2\x1b\x1a\x6a\x43\xb4\x82\xc9\xfb\xc3\xae\x04\x52\x8a\xc5\x7c\x6e\x40\x2e\x7c\x51\x3d\x28\xec\xc6\x13\x9b\x71\x7e\x39\xd6\x79\x27\x1b\xf2\xd2\x97\x52\xd4\xeb\x0d\xad\x90\xa3\xa6\x4a\xbc\xe9\xd2\x42\xe0\x2b\x28\x11\xb3\x63\xa9\xa4\x90\xda\x89\xd0\x08\x91\x67\x6f\xdb\xa1\x5e\xda\x9c\x64\x51\x40\x49\x57\x89\x4c\x2b\x80\xbe\xe6\x7c\x00\x12\x3a\x0b\xf0\x04\x21\x17\x9f\x29\x39\xfa\x84\x25\x4d\xba\x13\x5b\x07\xf6\x93\x65\xe9\xb2\x95\xea\x04\x3e\xd2\x5c\x07\xa0\x76\x91\x33\x5d\x43\xc6\xfd\x5c\xc2\xa3\xed\xf3\x47\x17\xf0\x68\xfb\xe2\xd1\xd9\x6c\x5a\x4d\x77\x0e\xdb\xe7\xa1\x2f\x5f\xcc\x8e\x50\x0c\xd3\xaa\xb5\x65\xf9\x08\x4f\xdf\xb9\x61\x9e\x9f\xfe\x36\x60\xda\xf5\x82\x74\xea\x64\xbe\x21\x6e\x16\x6f\x7c\xc0\xcc\x84\x78\x4d\x75\xcd\x75\xd1\xf9\x98\xec\x59\xb1\xa0\xa8\x61\xf1\x6c\x13\xe8\x87\x08\x80\x1d\xee\x1d\x71\x5e\x5e\xed\xca\xb4\x41\x3c\x39\x86\x49\x05\x7b\x78\xcb\x55\xb8\x8c\xd8\x63\xd3\x4f\xcd\xc0\xfd\x3e\x11\x56\x88\xba\x34\xf1\x92\xc4\x2d\xa7\xde\x3d\x83\xe9\x3d\x62\xa8\x10\xdf\xed\xbf\xf5\x0c\x1d\x6f\x0c\x79\xf6\x34\x46\x15\x51\xbd\x3e\xc8\x20\x2a\xc9\x85\xe4\x7a\x8c\xa8\x6b\x37\xcc\x93\xe4\x6f\xf3\xc1\x5f\xaf\x0b\xd2\x96\xa5\x7e\xab\xb1\x0e\xf9\x1c\xb1\xea\x65\x85\x89\x6f\xc4\x55\x56\x1a\x98\xad\x41\xc8\xf6\x11\x14\x99\x55\x52\x50\xc5\x91\x62\xdd\x7e\x2f\x6a\xfb\x4f\xee\x81\x21\x65\xed\x40\x31\xc5\x06\x13\x35\x2e\xa9\x4b\xc7\x60\xd7\x36\x39\xab\xb0\x7f\x94\xc8\x54\xd3\xf6\xc4\xc8\x43\x51\x15\xc4\x51\x40\x0d\x67\x87\xcc\xa6\xae\x55\xa6\x0d\xbb\x5f\x3c\x3f\x6a\x2a\x1c\x01\x3f\x07\xa3\x82\xde\x6c\x38\x8e\xc5\x96\x54\xef\x22\x52\x33\x68\xdf\xb8\x6e\x0c\xd8\x1a\x4b\xaa\xcb\x11\xb3\x77\xc0\x56\x2b\xfe\xe0\xc3\xa2\xa6\x5e\xe6\x34\x2c\x00\xb1\xf1\x01\x34\xf6\x28\x0d\xa3\xea\x85\xfe\x68\xcc\xe1\x28\xfd\xcd\xc8\x31\x47\x66\x80\x46\x50\x75\xa6\xd7\x19\x8b\x46\x8b\xc4\xaa\x1f\x9c\x18\xd3\xe1\x53\x5b\xd7\x1f\x4f\x0c\x39\x70\x4f\xf4\xeb\xdc\x65\x02\x3f\x0b\x4d\x55\x89\x9c\xa7\x5c\xe7\x3b\xdf\x3f\xd8\xd4\x65\x16\x2b\x96\x2b\x5c\x00\xfe\x56\x53\x21\x98\xbe\xd1\xb2\xc6\x50\x71\x3a\xab\x9b\x05\xa0\x0c\xd3\x9c\xba\xad\x69\x4d\xa8\x64\xd4\x66\x79\xa0\x4e\x47\x39\x54\xda\x19\x42\xdd\x61\x23\xfc\x26\x81\xf2\x43\xfb\x9d\x90\x07\x3c\x4f\x66\xc7\xc5\xc2\x2e\xee\x7a\x2b\xc4\x7d\x24\x56\x0e\xc5\x5b\x66\xf8\xd8\xd4\x57\x12\xb7\x87\x8d\xb1\xfe\x67\x63\x40\x98\xf5\x78\x57\xc8\x83\xac\x96\x5e\xd0\x3d\xb5\x87\xe4\x8c\xb1\x94\x7e\x6c\x7c\x30\x81\x9c\xd7\x66\xe0\x20\x21\xc4\x65\x8f\x8d\x3a\x0d\x1d\x13\xa1\x4d\xc0\xe6\xc8\xc8\x70\x04\xab\x2f\x0a\x0f\x23\x10\x63\x41\xe3\x14\x2e\x14\xec\xe1\xc6\xf6\xed\x4e\x60\xc5\x4f\xcd\xe0\xb8\x13\x77\xaa\xee\x1b\x3e\x83\x40\xa1\xa7\x9c\xbe\xa5\xbe\x60\xf7\xe8\x0d\xca\x92\x71\xaa\x05\x87\x69\xea\x38\x93\x7f\xfe\xff\xc1\x11\x43\x0e\xc5\x05\x5b\x26\xe4\x9e\x40\xf3\x8d\x67\xff\xb8\x04\x78\xa8\xf3\x4a\x64\x2a\xb6\xa0\x46\x92\xcb\x57\xc0\x28\x64\x4b\x49\xce\x5d\xe9\xce\x99\x50\x05\x95\xa0\x85\x34\xa5\x29\x32\x3b\x6d\x4e\x89\xf5\xbb\x49\xa4\x51\xbb\xf3\x10\x5d\xab\xa6\x16\x3f\x36\xa1\x6c\xa5\x69\xf7\x4b\xa3\x94\xa7\x61\x4e\xfb\x74\x44\x3d\xa5\xd5\x8e\x36\xb4\x98\x32\xa8\xcb\x99\x69\x83\x8f\x16\xf0\x99\x71\xb7\x16\x5b\xd2\x4a\x60\xc6\xb7\x3c\xab\x59\x0e\x3f\x36\x0b\xd1\x41\xd0\xe0\x84\x91\x52\x8f\xf3\x9c\xdf\x23\xfc\x97\x58\x5a\x5b\x6e\x2c\xe2\x63\x6f\x05\x87\xc9\xfb\x72\xc1\x24\xfc\x27\x50\xff\xdf\x8c\xeb\xc1\x89\xf3\xac\xa8\x4b\xcd\x73\x60\x66\xab\x62\xe8\xe7\x5a\x64\xea\x02\xae\x3f\x5e\xa9\x0b\xb3\xbd\x82\xa7\xd4\xe0\x4a\x0b\x52\x05\x2f\x4d\x1b\x77\x59\x17\x4b\x94\xa4\xd9\x34\x96\xfe\xcf\xe0\x15\x56\xb9\xd8\x15\x58\xea\x58\xe5\x92\xf6\x8f\xe1\xaa\xce\x6f\xa9\x5f\x49\x48\xea\x20\x20\x71\xbf\x75\x4b\xc6\xbc\x24\x51\x41\x96\xed\xa8\x1d\x4d\x37\x6a\x4f\xd6\xfe\x30\x04\xf2\xff\x68\xa2\x3d\x81\x8c\xfa\xff\x4c\x9b\xf2\xaa\xce\x4f\x11\xb6\x81\xaa\x07\xad\x63\x5d\xdd\xbc\x0a\x78\xde\xde\x24\xdc\xba\x61\x63\x13\x41\xe0\x8c\x90\xfa\xcd\x58\x07\x60\x81\xd8\x4a\x4f\xf4\x62\xe6\x9a\xd9\x5e\xf8\x65\xb1\x48\xbf\xf2\x10\x85\xae\xd1\xe2\x95\xe4\xc1\xf5\xd2\x3e\x25\xdd\xb1\x5e\xa5\x1c\x00\xd3\x27\x8f\x25\x2d\x03\xd9\xd0\xd7\x55\x09\x0e\x20\xda\x47\x62\x6f\x96\x68\x65\x80\x54\xc2\x94\xae\x5c\xd9\xfd\xfc\xcc\xd6\x27\xa9\x69\xcb\xd6\x86\x0b\x56\xd1\x07\xf5\x5b\x1e\x6e\xdf\xfa\xa5\xa4\x50\xb1\xae\xa8\xbb\xb3\x83\xc3\x8b\xf1\xbc\xaf\x43\x47\x00\x6e\x66\x78\xd3\xcd\x87\x7d\xe2\x13\x8f\x1b\x63\x05\x07\x15\x6e\x0c\x9b\x3b\xe8\x45\xa0\xab\x6c\x0e\xea\xb7\xfc\x98\x54\xc0\x96\x50\x9a\x5d\xb9\x23\x93\x7a\xd7\x1f\x6d\x16\xca\x24\xcf\x50\xf5\xe3\xf8\x36\x79\x09\x2f\xf2\x1c\x26\xdb\x77\x6d\x5a\xd0\xb9\xbb\x0d\xd9\x7b\x29\x51\x00\x62\x20\x39\xf6\x31\x50\x72\x14\x3b\x50\xe9\x31\x1e\x10\xa5\x54\x9c\xfa\xba\xe1\x79\x4a\x21\x65\x5d\x85\x2e\xed\x21\x70\x65\x47\xd2\xb6\x20\x2c\x1d\xd3\x49\xc1\xcd\xd3\x9f\x5f\x40\x86\x1a\x65\x61\x36\x2a\xba\x92\x68\x10\x26\x10\x5f\x6d\xb9\xd0\xd2\x43\x11\x46\xb3\xb7\x06\x19\x55\x99\xe8\x6b\x59\x97\x60\x36\xc0\xfb\x3c\xd5\x33\x3a\x02\xf5\x97\xa8\x9c\x8f\x99\x97\x6f\x11\xd0\x13\x65\x27\x86\x3c\x76\x19\xf4\x8d\xdd\x42\xa5\x26\xa0\xf4\xae\x77\x83\x35\xe1\x29\xab\x15\x02\x3b\x30\xe0\x4b\x9b\xe7\x45\x57\x6c\xc9\x42\x52\xf1\x96\x42\x0f\xc6\x73\x65\xfb\xfe\x3e\x73\x85\xdd\xfa\x41\x8e\x2b\xb3\x7d\x98\x79\xd0\x99\xf5\x7d\x27\xd1\xfb\x57\x1f\x28\xd1\x5c\x7e\x9b\x20\x69\xc0\x71\x47\xb9\xf2\xcd\x38\x32\x91\x1b\x5d\x37\x6e\xfa\xce\x5c\x50\x3d\x04\x31\xa0\x09\xc3\xac\x1b\x62\x9b\x29\xea\xd0\x8e\x28\xbb\xaa\xa7\x46\x58\xf4\x71\x6f\x78\xa7\xfd\x31\x17\x29\xcb\x8d\xdd\x6f\x1b\x5b\x4d\xa5\xc6\x7a\xc0\xa0\xfe\xbe\x7a\x7d\x7d\xf3\xfa\xea\xe5\xdd\xeb\x57\x17\x14\x46\xd0\x0a\x40\x8d\xea\x8d\x14\x45\x62\xef\xfa\x11\x77\xb4\xd4\xeb\x3a\xe6\x0e\x41\x70\x8d\x45\x50\xab\x87\xed\xf4\xf0\x62\xe2\x80\x6b\x19\x5b\x40\x8c\x2e\x1d\x0e\x08\xa7\xbf\xc8\xa4\x64\xfb\x9d\x09\xdb\x29\xf5\x3d\x57\xda\x6b\xa7\xc2\x55\xea\x26\xba\xb4\x87\x79\xa7\x1b\xd7\x34\x45\xc9\x2d\xce\xeb\xd2\xf4\x1e\xce\x57\x1c\xf3\x4c\x5d\x02\x95\xdb\xf6\x6e\xdd\x36\xb3\x75\xf9\xf5\x26\xc6\x94\x0f\x49\x20\x07\x96\xa9\x7b\xd4\xdf\xed\x6f\x6c\xa4\x5a\x36\x89\xa2\xdb\x42\x42\x1d\x0d\xa6\x54\xee\x18\x10\x81\xe9\xc9\x39\xe4\xcf\x34\xbc\xe9\x47\x18\x94\x58\x1e\x1f\xb1\x87\xfb\x2f\xee\x86\x7d\x0f\x78\xe5\x99\x70\x8b\x39\xa6\xd4\x0c\xc6\x62\x66\xb7\xff\x64\x1b\x84\x49\x54\x14\x83\xf9\x3d\xd4\xb4\x22\x60\xea\xf9\xad\x09\x31\xc9\x56\x45\x11\x86\xd6\x51\xf7\xe5\x32\x4f\xd7\x57\x44\x82\x65\x83\xb1\x0b\x5f\x63\xe5\xda\x2e\x3e\xd9\xb3\x50\x34\x16\x95\x90\x4c\xf2\x7c\x07\x75\xc9\xb6\x8c\xe7\x14\x06\xc4\x18\x3a\xc5\x9b\x8d\x6d\x0e\x18\xd9\x22\x60\xba\x59\xba\xfb\x04\x5c\x41\xcd\x6f\x14\x18\x80\x09\x7b\x5b\x0b\xa2\x3b\x05\x26\x99\x8c\x29\x7d\x07\x73\x83\x69\xe4\xe2\xa0\xf9\xe8\x35\xd3\x58\x8b\x79\x39\x9b\xc0\xaa\x80\xe6\x58\x30\x50\xb0\xaa\xa7\x33\x5f\x41\x37\x06\x77\x4f\x4d\x62\xe0\x78\x07\xc8\x64\x20\x23\x4d\xcc\x13\x21\x4d\x51\xf7\x29\x12\x3e\x2e\x19\x03\xfd\x28\xa3\x92\x41\x67\x64\xc9\x92\xe5\x76\x93\xdd\xe9\xb2\x51\x36\x90\xbc\x1a\xfd\xad\x59\xd5\xd7\x3d\x4e\x78\xd3\x3a\x00\x15\xe8\xe0\xa9\x53\x4c\xeb\x20\xcc\xc6\xec\x9e\x64\x5a\x07\x41\x7f\x55\xb3\x5b\xcb\xe9\x2c\x0f\x6f\x02\xda\x13\x98\xe4\xcb\x14\x6e\x5c\x4d\x6a\x99\x9f\xaa\x25\xdd\x70\xf3\x72\x36\x81\xe2\x80\xf1\x74\xfb\x3e\xbe\x1b\xce\xbf\x07\xc3\x79\x62\xc0\x1e\xae\xd3\x7f\x61\x8d\xde\x54\xd8\x43\x15\xf5\x2f\xa8\xcf\xf7\x2a\xf1\x01\xd0\x47\xd7\xe6\xf7\xaa\xf0\x01\x90\x43\x75\xf9\xf8\x74\x87\x27\x79\x6e\x03\xb3\xd9\x84\x29\xa3\x62\x4a\xbd\xa7\x60\xbd\x09\xe9\x14\x1c\xdd\x99\x7a\xee\xf0\x0e\xe5\x4f\xd5\xeb\x94\xab\x81\x2d\xa9\x6a\xc2\xca\x6e\x9d\x32\x99\x4d\xd3\xea\xf6\x2c\xbc\x11\x19\xb9\x6a\x06\xfa\x83\x44\xe8\x50\x3a\x3a\xb4\xce\x39\x19\xb1\xea\xad\x11\x9f\x59\x54\x31\x24\x24\x14\xa2\x5f\xc0\x86\x75\x9b\xc2\x6d\x03\x1a\xd7\x9d\xc6\x5e\xd3\xe7\xe3\xb6\x76\x24\x5f\x2f\x9f\xcb\x99\xd2\x77\x92\x95\xca\xd0\x4d\x45\xa7\xf0\xb8\x3d\x06\xbc\x3f\xb8\xcd\xfb\x97\xf6\x50\xbf\x54\x48\x89\xaa\x22\x56\x0d\x18\x1b\x17\xc7\x13\x1e\x7e\x3a\xbb\x8d\x49\x5c\xb5\xb3\x72\x48\x76\xbf\xbc\x42\x5d\x9a\x73\x7a\xfe\xec\x44\xe3\x47\x48\xd8\xdd\x52\x47\x31\xa2\xbd\x65\x84\x09\xe1\xe5\x17\x87\xdc\x1e\x13\x6c\xd3\xe9\x5f\x80\x09\xee\xb8\xc7\x49\xd4\xbb\x63\x20\x89\x6c\x06\x9b\xba\x60\x74\x60\x14\xcb\x28\xaf\xec\x0e\x74\x01\x47\x04\x22\xc1\xd4\xb6\xfe\xba\x6a\xd9\xa0\x1b\xe9\xba\xa0\xad\x19\x55\x8e\x85\x3b\x86\xce\xb6\xb1\x25\xa7\xd2\x67\x6f\x9f\x44\xde\x8d\x19\x6a\xa9\x5b\x4a\x4e\x5b\x42\x18\x6d\x22\xc4\x96\x4a\x6a\x8a\x62\x65\x6c\x79\xb0\x99\x1a\xdf\xc0\x69\xe7\xf0\x4c\xed\xd3\x78\x32\x35\x21\xeb\x19\xa1\xc6\x19\x4f\xb1\xea\x23\xd3\x59\xe0\xbb\x93\x35\xd2\x8a\xde\x1b\xea\xe6\x3a\x0b\x19\x2b\xb7\x1b\xf0\x83\xad\x3e\xc5\x4f\x6d\x08\x2f\xb7\x79\xdf\xf0\x88\x1e\xf4\x28\x7e\xd9\x3c\x3f\x7e\xdd\x3d\xfd\x54\x96\x19\x9e\x4e\x61\xd8\x1d\x9d\x7d\x39\xc0\x2e\x5b\x06\xb2\x26\x79\x88\x5b\x66\x9c\xd9\x03\xc5\x69\x20\x9c\x59\x3f\x6f\xff\xbe\xc1\x94\x56\xf8\xfc\x07\xe3\xe6\xec\xdf\x74\xce\x71\xf6\x07\x96\xde\x0f\xc1\xf6\x69\x23\xcb\x76\x74\xd3\x1d\xd2\x16\xe6\x53\xe7\xa5\x4b\xd0\xf0\x20\x4f\x4d\x74\x94\x27\x31\x3a\xa0\xa1\x7b\x60\x84\x65\x46\x7c\x40\xc3\xa1\xe8\x90\x0e\x7b\xa2\x63\x2c\xcf\x4e\x93\xa6\xa1\x10\x77\xee\x4c\x79\xf0\x12\x09\xe1\xd7\x0b\x6b\xdd\x19\x84\x01\x3b\xd0\x13\xe8\x66\xd9\x8c\x0e\x42\x94\xae\x1a\xdd\xb6\x80\x75\xce\x3a\x74\x87\xfd\x1d\x1c\xeb\x77\x00\x9f\x42\x0f\x32\x90\x54\x97\xd6\x4d\x37\x51\x1b\x4a\xee\x41\x48\x66\x31\xbf\x75\xfc\xda\xc8\x9a\x6b\x3b\xbd\x23\x54\xff\xe0\xc7\x75\x56\x43\xb4\xdb\xbe\xed\xcf\x2b\xdb\xe0\xc1\x86\xc1\x03\xa0\xd4\xe5\x63\x08\x15\xf9\xd6\x1e\xce\xe3\xd7\xcc\x9a\x53\x63\x3d\x80\x5d\x19\xe8\xa5\x1e\x0e\xc5\x4e\x3c\x7a\xad\x43\x40\x17\xf9\x1e\xaa\x41\x98\x54\x41\x3d\xc4\x71\x82\xc8\x1f\xbd\x1b\x98\x78\x3d\x88\x1f\x68\x11\xb3\x70\xd4\x71\x57\xee\x4e\xc0\x73\x40\x91\xc8\xf5\xbe\xd4\x54\xb3\xd1\x98\xdd\xb8\xbd\x0b\x97\xb3\x41\x72\xde\x87\xee\xf1\x04\xfa\xfd\x0f\x6d\xfc\xd2\xca\xc2\x01\x58\x30\xd2\xe1\x72\x41\x73\x98\x69\xf7\xe0\x52\x9f\xa1\x25\xb3\x23\xa8\x2d\xf1\x41\xdf\x50\xef\x60\x38\x6a\xed\x11\xf2\x73\x77\x6c\x33\x43\x14\xbf\x37\xfb\x5e\x58\x58\xf9\x0f\xe0\x42\xb3\x44\x6e\x5b\x0c\xb3\xb8\x72\xc7\x83\xd2\x01\xb2\x6c\x42\x85\xd9\x0f\xb6\x81\x7f\x7c\x92\x7e\x39\xb8\xc1\x13\x58\x08\x6a\xb2\xc0\x94\xce\x13\x70\xfb\x01\xe8\xaa\x7f\xc2\x01\x58\xf0\x3d\x18\xf1\x86\xa2\xd3\xed\x96\x39\x5d\x7e\x84\x14\x73\xde\x7c\xbf\x25\x8b\xf6\x3b\x47\x83\x0f\x17\x64\x98\xcf\x64\xe5\x23\x01\xc9\x61\x28\xd2\xf9\xc2\x1e\x8c\xe3\xef\x86\x33\x77\x04\x0d\x2f\xd7\xf4\xe9\x83\x3f\xda\x21\x0c\xb8\x1b\xd2\xd8\xbf\x5b\x40\xe4\x60\x1d\x14\xfa\xb3\x7f\xc1\xfe\x75\x4b\x0e\x03\xb3\x18\xda\x37\xc2\x1c\x31\x61\x83\xa1\x7e\x68\x64\x3f\x51\x2b\x87\x83\xfb\x78\x36\x2d\xe8\x19\x09\x77\xba\x97\xdf\x84\x4f\xbe\x9d\x43\x8f\x9d\xc3\xd7\xbb\xdc\x0d\x8c\x6c\x99\x1d\xb8\xd8\xf0\x7e\x76\x44\xa0\xe5\x2f\x45\x1f\xe9\xa6\x25\x72\x65\xf0\xb6\xe0\x85\x66\x0e\x03\xd7\xa2\xd0\x3a\x33\x3b\x3b\x2a\xc2\x9b\x43\x7f\xde\x8f\xb1\x2c\x5f\xbe\x37\x89\x29\x40\x4e\xdd\x42\x4d\x0f\x23\xed\xee\x6b\xb6\x1a\x25\x27\x60\x73\x1b\xc9\xe4\x42\xf8\xb8\x54\xce\x61\xe4\x2a\x06\xfb\xaf\x91\xf0\x79\xe7\x01\xc4\xe6\x91\x50\xb0\xd2\x1c\x79\x68\xac\x1d\x57\xa7\xf5\xf3\x79\xf7\x37\x8a\xba\xf3\x92\x6d\x14\x46\x67\x34\x6c\x98\xda\x10\xef\x3a\xfb\x59\xdb\x60\xc1\xbd\xdb\x23\xb8\xac\x92\x39\xc1\x3f\x0e\x57\x27\x33\x57\x14\xf3\x8e\x21\xdc\x1d\x1b\x09\x99\x3d\x3c\x60\x36\xa0\x08\xb5\x01\x14\x2c\xb3\x47\xe1\x9a\x37\x3f\xa4\xd2\x14\x32\x30\x3b\x68\xbd\xf7\x51\xbc\x3b\x92\xab\x89\xa7\x03\x20\x7b\x11\xb6\x6f\xf4\x17\xb2\x99\x0a\x57\x42\xfb\x7a\x5e\x2b\x18\x4e\x1d\x26\x3f\xf3\xe6\x90\xfb\xce\x57\xb4\xb3\x70\x16\x05\x64\x3d\x70\xa7\x81\xc5\xf5\xfd\x76\xbf\xa9\x97\x5e\x30\x1b\xfd\x70\x75\x0f\xf8\x9f\xff\x9d\xb5\x25\x10\x3a\xc8\xad\xd2\x98\x75\xde\x9e\x43\x87\x21\x5e\xc2\xa3\x47\xbd\x77\xee\x98\x8f\x4d\x46\xaf\x2e\xe1\x8f\xbf\xd2\xdb\x73\x34\x9d\xcc\xe4\x76\x32\xdb\x2f\xff\x76\xdf\x69\xe4\xce\x1d\xe1\x5f\xed\xbd\x46\x0e\xe0\x2e\xf8\x6a\x23\x7f\x31\xf2\x76\x23\x77\x99\x0f\xbc\xe1\x08\x2b\x11\x7c\xb5\x91\x87\xfc\xf5\xdf\x6e\x34\x70\x30\xa2\x3f\xaf\xd2\x3f\x3c\xf0\xa6\x16\xe2\x62\xd2\x2e\x68\x12\xd3\x0c\xc8\x59\xc4\x04\xed\xbf\x11\x87\x9e\x60\x8c\x50\xe3\x56\x9a\xf5\x20\xb7\xb9\xdb\x9d\x6e\x43\xd9\x4a\x73\xa4\x13\x2f\x33\x7c\xe8\x1e\xaf\xaa\x37\x38\x82\xa7\x7b\xcb\x4f\xf3\x3c\x37\xc4\x22\x6c\xe2\x17\x35\x8b\x69\xff\x31\xef\x0b\x62\xd9\x8e\x5e\x16\xa4\x42\xaf\x26\x6a\xab\x2f\x01\xbe\x1c\xfd\x08\x57\xa0\xfe\xfe\x3e\xa2\x7f\x98\xf7\x11\x79\xf9\x1e\x79\x25\xd1\xbe\xda\x36\x20\x69\x9a\x58\x73\x82\x64\xb7\xbf\xde\xc5\x53\x8d\xb6\xd1\xce\xf9\x76\x25\x3d\x99\x0d\xd7\x6e\x3a\xae\x61\x16\x0d\x23\xbe\xbf\xdc\xe8\xfb\xcb\x8d\xbe\xbf\xdc\xe8\xe4\x97\x1b\x0d\x1c\x8a\x15\x39\x0c\xab\xcd\x2f\xc2\x0d\x40\x1d\x55\x9f\x0d\xb4\xd1\x80\x5b\x91\xdf\x33\x41\xcd\x21\x2f\xdd\x93\x6f\xfd\x0a\xdf\xa1\x2b\xee\xfe\x0b\xbf\x7f\x65\xfa\x9b\x56\x02\x20\xe9\x50\xe3\x0b\xf7\xe6\x94\xaf\xfd\x4e\x95\x6f\xf3\x3e\x95\x63\xde\xa5\x12\x97\x8a\xc6\xb9\x04\xbe\x8f\xca\x68\x2c\x6b\x19\xe9\x47\x8a\x08\x71\xb4\x07\xef\xd4\x28\xd3\x1f\x15\x74\xec\x21\xdc\x4f\x82\xa7\x37\x8e\x7b\x37\x7f\xb4\x4c\x73\x32\xee\x22\x31\x28\x25\x2d\x4a\xa1\x09\xac\x15\x1e\xc8\xbf\x23\xab\xbd\x31\x99\x4d\x9e\x93\xf0\x7c\x1c\x76\x0b\xc6\x6c\x49\xa0\x6c\x32\x10\x42\xb8\xca\xc9\x68\x07\x51\x0f\x1e\x00\xdb\x03\x93\xcc\xa6\xc9\x69\x1b\x77\x8f\xc8\xc9\x69\x09\xc1\x01\x4c\x72\xe6\x36\x45\x98\x38\x21\xa1\x64\xbf\x97\x1b\x8f\xa0\xdd\x0c\xfc\xda\x8d\x50\x2d\xea\xb6\xf5\x89\x65\x19\xd5\x4c\xdc\x3b\x64\x2c\x95\x8e\x0b\xdf\xdb\xa1\xbe\xb7\x43\x7d\x6f\x87\xfa\xde\x0e\xf5\xbd\x1d\xea\x2f\xda\x0e\x65\x0a\x34\xa7\xf2\x60\xa8\x45\x67\x84\x84\xbf\x92\x1e\x1c\xe3\x92\xdc\xd2\x73\x08\x99\x1e\x2f\xdf\xf5\x06\x1f\xda\xc9\xde\x21\x94\x93\x3c\x3e\x15\x9f\x9a\xf5\x27\x5f\xe2\x18\xf2\xff\xe3\x16\x72\x80\xef\x84\x92\xa1\xc1\xac\x38\x87\xdd\x43\x8f\xe0\xf7\x07\x37\x9c\xe4\x1c\x1a\x76\x74\xd6\x3f\x4c\x14\xe0\x05\xb3\x13\x36\x98\x09\xf9\xde\x93\x30\xa1\x27\x21\x28\xf1\x7f\xff\xab\x3b\xff\x37\x00\x76\xac\x72\x25\xb2\x82\x00\x00"),
 		},
 		"/deployment.yaml.tmpl": &vfsgen۰CompressedFileInfo{
 			name:             "deployment.yaml.tmpl",
 			modTime:          time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC),
 			uncompressedSize: 6627,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcc\x58\x5f\x8f\xe3\xb6\x11\x7f\xdf\x4f\x31\xd8\x3c\x14\x28\x2c\x79\x2f\x7b\xc9\x83\x0e\xf7\x70\x6d\x9a\xdc\x01\xb9\xed\x22\x7b\x28\xd0\xa7\x98\xa6\xc6\x16\xbb\x14\xa9\x92\x23\xbb\xea\x21\xfd\xec\xc5\x50\x7f\x2c\xca\x92\xbd\x5b\x20\x40\xfd\xb2\x5a\x92\xf3\x7f\x7e\x33\x43\x26\x49\x72\x23\x2a\xf5\x37\x74\x5e\x59\x93\x81\xa8\x2a\xbf\x3e\xbc\xb9\x79\x56\x26\xcf\xe0\x07\xac\xb4\x6d\x4a\x34\x74\x53\x22\x89\x5c\x90\xc8\x6e\x00\x8c\x28\x31\x83\x02\x75\x99\xd8\x0a\x9d\x20\xeb\xba\x55\x5f\x09\x89\x19\x7c\xfd\x0a\xe9\x43\xff\x2f\xfc\xf6\xdb\x8d\xaf\x50\x32\xa5\xc3\x4a\x2b\x29\x7c\x06\x6f\x6e\x00\x3c\x6a\x94\x64\x1d\xef\x00\x94\x82\x64\xf1\xb3\xd8\xa2\xf6\xed\xc2\x92\x20\x4f\x4e\x10\xee\x9b\xf6\x14\x35\x15\x66\xf0\x0b\x4a\x87\x82\xf0\x06\x80\xb0\xac\xb4\x20\xec\xb8\x8e\xf4\xe6\x9f\x8e\x04\x2c\x89\xe0\x9f\x30\xc6\x92\x20\x65\xcd\xe8\x78\xe5\x6c\x89\x54\x60\xed\x53\x65\xd7\x5e\x3a\xc1\xd2\x6f\xc9\xd5\x78\x1b\x0e\xf5\x96\x86\x6f\x74\x07\x25\xf1\x83\x94\xb6\x36\xf4\xb0\x28\xe9\x60\x75\x5d\xe2\x20\xe5\x9b\xfe\x2f\xfc\xdd\xd6\x70\x54\x5a\x83\x41\xcc\x81\x0a\xf4\x08\x74\xb4\x3d\x01\xa8\x1d\x34\x7c\x44\x18\x02\xb2\x80\x9e\xc4\x56\x2b\x5f\xc0\x41\x68\x95\x0b\xc2\x1c\xbe\xfc\xfc\x34\xb0\x93\xd6\x18\x94\xc1\x22\x10\x7b\xa1\x8c\x27\xf8\xa2\xb4\x46\x97\x9e\x89\x4e\x3a\xcf\x50\xd8\x4f\x48\xfb\x44\x8a\x61\x17\x98\xd7\x4e\xed\x3f\x8b\x2a\x1b\x2d\xc2\x1c\x51\xd2\x1e\x8d\x8e\xe5\xb8\x13\xb5\xa6\xcf\x36\xc7\x0c\xee\xbe\xbf\xbb\x3b\x93\xff\xc4\x01\x25\xb0\xbb\x10\x60\xd8\x3c\xd7\x5b\x74\x06\x09\x83\xe3\x49\xfb\xcd\xb9\xbb\xe6\x74\x46\x47\x23\xc9\x3e\x70\x8d\x55\x6e\xd7\x1e\xae\x50\x4e\x75\x7e\x3b\xa3\xf3\x97\x02\x61\x67\xb5\xb6\x47\x65\xf6\x5d\x90\x40\x79\xd8\x59\x07\xb5\xe7\x35\x01\xb2\xf6\x64\x4b\xe5\x31\x87\xcd\xb3\xb1\x47\xf3\x6b\x61\x3d\xf9\x0d\xec\x94\xc6\xd5\xc0\xea\x58\x28\x59\xb4\xc1\x3d\xc5\xdf\x42\x6e\xfb\x98\x33\x15\x7f\x38\xb0\x47\x03\x7b\x45\x8c\x2d\xeb\x15\x59\xd7\x80\x13\x54\xa0\x1b\x98\x51\x21\x4c\xa7\xc0\x4f\x8a\x3e\xd6\x5b\xb0\x8e\xb3\x09\xb4\x7a\xc6\xf4\x94\x65\x42\x7b\x3b\x88\x2a\x39\x67\x41\x9d\x7c\xa0\x0c\xd9\x40\x25\xad\x21\xa1\x0c\xba\x15\x6c\x51\xdb\xe3\x79\xf2\x30\xc7\x52\x34\x2d\xc3\x23\x27\x24\x59\x86\xce\x41\xe5\x08\xc2\xc0\xc6\xfb\xe2\xd7\x36\x2d\xa6\x86\x73\xb1\x51\xd6\xb0\xae\xa5\x75\xd8\xea\x6e\x0d\xc2\xe6\x53\xce\x5b\xd4\xfc\xa8\x34\x6e\xde\x05\xa7\x72\x06\x0b\x23\x71\xd5\x7b\x45\x38\x1c\x38\xb5\x06\xc7\x4c\x3a\xf3\x4f\xae\x4a\xe1\xe1\x4f\x59\xb0\x0a\x0d\xb9\x06\x9e\xb1\x01\x5f\xd8\x5a\xe7\xb0\x3d\xb1\xba\x6d\x75\xbd\xed\x1c\xdb\xf2\xbb\x3d\x19\x71\xcb\xf2\x83\xc3\x30\x07\x65\xe0\x3f\xeb\xd4\xfb\x62\xbd\x8c\x2a\xef\x8b\x5c\xb9\x97\xc2\x69\xa7\xeb\x7f\x25\xde\x17\xd7\x91\xc4\x59\xf9\xf5\x6b\xc2\xea\xa4\x4f\x4f\x1f\x9f\x86\xd4\xe6\x22\x3c\x40\xeb\xe9\x23\x54\x4e\x1d\x04\x61\xb0\x97\x2c\x08\x29\xd1\x7b\x76\xcf\xc9\x37\x0a\x7d\x3a\xd4\xbd\x5e\xf1\xbd\xa2\xe4\x19\x9b\x61\x7d\x0a\x29\x38\x83\x14\xf7\x82\x05\x55\x60\xde\x82\x90\xfe\x68\x06\x87\x3a\x14\x79\x62\x8d\x6e\x56\x70\x44\x38\x5a\xf3\x07\x82\x2d\x82\xd8\x6a\x64\xdd\x65\x51\xda\x3c\x58\x8d\xda\x8f\x0d\x5d\x2c\xa3\xca\x0f\xe0\x1c\xd2\x66\x00\xe8\x04\x4a\x54\x88\x13\x04\x98\xde\x73\x02\xb3\x0b\xd9\x75\x9c\x84\xad\xef\xde\x01\xa6\xfb\x74\x05\xa2\xcf\xb1\x3c\xf4\x4e\x3e\x95\xc2\xa7\xdd\xc0\x22\x16\xf7\x8f\xda\x53\x48\x4c\x5f\xcb\x62\x24\x76\x15\x52\xb2\xf3\x4c\x04\x97\x81\x91\xd0\xec\x97\x06\x2a\xab\x0c\x79\x10\x04\x9b\x35\x92\x5c\x73\xb2\xe4\x6b\x4e\x3f\xd5\x01\x66\x03\xc2\x83\xe8\x35\x61\x0d\x4e\x65\xa6\xeb\x1c\xb5\xc7\x09\x52\x9e\xb1\x59\x9d\xd7\x9f\x1e\xc3\x7d\xe1\x19\x18\x45\x88\x16\x5b\x7b\xc0\x15\x1c\x15\x15\x01\xed\x11\x72\x3b\xa0\x85\x6e\xcf\x2e\x40\x21\x8b\x81\x0d\xfb\x54\x99\x60\x7c\x9b\x43\x7d\x3d\xc0\x1c\x0a\x74\xb8\x8c\xa8\x38\x31\x5f\x52\xeb\x03\xaa\x98\xac\x8d\xd4\x65\x54\xfd\xef\x39\x69\xf2\xd7\xa5\x24\x59\x50\x65\x65\x1d\x81\x30\x4d\xd7\x2e\xe0\x23\xea\x32\x42\xe6\x29\x0d\x4c\x18\x0c\x94\x03\xe9\x30\xf8\x59\x68\x4e\xd1\x7c\x6d\x1d\x70\x0f\x53\x3b\x25\x19\xe9\x6d\x70\x6a\xd7\x4e\x34\x93\xaa\x1f\x65\xf8\xb8\xfa\xcf\x54\xfd\x20\xd2\x23\x85\xd5\x4d\x92\x84\x89\xe6\x94\xba\x49\xab\xfd\x28\x51\xdd\xbe\xe6\x28\x2e\x76\x8b\x3e\x84\x63\xfb\x92\x46\x94\xfa\xb5\xc1\x8c\x35\x19\x7b\x69\xbe\x52\xb6\xbb\x83\x61\xc3\x00\x96\x5c\x98\x0a\xbb\x3e\xef\x30\xa0\xd8\x58\xb8\xcd\x78\xd8\xf4\x74\x0b\xaa\x14\x7b\x6c\xbb\x7d\x44\x99\xc2\x8f\xaa\x8d\x12\x94\xdc\xb5\x1d\x4a\x9e\xa8\x4f\xfc\x1c\x6a\x14\x1e\xb9\x2b\x07\x1e\x70\x68\xc7\x71\x06\x75\x41\x54\xf9\x6c\xbd\x2e\xea\x6d\x9a\x5b\xf9\x8c\x2e\x95\xb6\x5c\xbb\x80\x73\x99\xaf\x23\x49\x6b\x12\x7b\x3f\x62\xcc\x91\xe2\x91\x9b\xa7\x70\x16\x4f\x62\x1f\xc1\x08\x5a\x79\x19\x74\x9c\x95\x9d\x65\x9b\xbd\x49\xdf\xa6\x6f\x63\x9a\xc7\x5a\xeb\x47\xab\x95\x6c\x32\xf8\xb4\x7b\xb0\xf4\xe8\xd0\x8f\xcd\xe2\x24\x18\x0d\xce\x83\x4f\x89\xaa\x51\xf9\x1f\x9c\xff\x68\x1d\x65\x70\x7f\x77\x7f\x37\xec\x6a\x75\x40\x83\xde\x3f\x3a\xbb\xc5\x71\x83\x61\x1e\x3f\x4d\x7b\x4e\x75\xce\x20\x2c\x0b\x2a\x32\x58\x17\x28\x34\x15\xff\x1e\x6d\x29\xa3\x18\x2b\x3f\xa0\x16\xcd\x13\x4a\x6b\xf2\xee\x56\xd2\xff\x48\x95\x68\x6b\x1a\xf6\xbe\x1b\xf6\x18\xfc\xea\xff\x54\x33\x6f\x6b\x27\xd1\x8f\x35\x70\xf8\xcf\x1a\x3d\xf9\x58\x2b\x59\xd5\x19\x7c\x77\x57\x46\x8b\x25\x96\xd6\x35\x19\x7c\xff\xf6\xb3\x1a\x36\xda\xc2\xf4\x99\x2b\xc2\x88\xc7\x37\xa3\x3c\xfb\x64\xa4\xae\x73\x6c\xeb\x58\xd7\x53\xe3\x4a\x72\x61\xf0\xb5\x6e\xae\xc1\x31\x5b\x9e\x0c\xdf\x9d\x9a\xd0\x68\x3c\xe5\xca\xd3\xce\x50\x9b\xbe\x6e\x86\xa6\x93\xce\xaa\x37\x3b\x75\xb5\x65\x21\x68\xf7\xd8\x46\xc2\x59\x4b\x61\x70\x8b\x4e\x70\xb0\xff\x6a\x74\x93\x01\x5f\xf2\xae\x4d\x57\xfd\x35\x60\x66\xc6\xe2\x1e\xcb\xb5\x81\x87\xac\x16\x59\xa1\x10\x8f\xfc\xbf\x3c\x64\xc5\x8a\x46\x6d\x7e\x66\xf2\xb9\x1e\x9c\x2b\x03\x4f\x34\xe8\x8c\x58\x8d\x2d\x1a\x8f\x3e\x4b\x21\xea\x0c\x79\x4d\x8c\xa6\xb6\x4f\x83\x34\x63\xfb\xb8\xc3\x5e\x37\xbd\x1f\x79\x46\x4d\xf6\xac\xbb\x9e\xba\xea\x88\xd9\xab\xfa\xeb\x92\x4f\x46\xfc\x36\x67\xfd\x2e\xf6\xd3\xaa\xd7\xe2\x7a\x93\x1d\xb7\xd9\xb8\xcb\xce\x3b\x79\xa9\xd3\x2e\x61\x82\xa5\xaf\x4f\xd2\xd7\xd1\x1d\xe1\x8c\xde\xd7\xdb\x96\xfa\xf2\xb1\x09\xb0\x2e\xc7\x0f\x3d\xbe\xf0\xb5\x63\xfc\xca\x31\x76\x4f\xf4\xde\xb1\x9c\xb2\x93\x17\x80\x0d\x07\x61\x1c\xb4\xe8\x69\x63\x33\xbc\xc4\xe4\x28\xb5\x70\x98\xcf\x84\x6e\x44\xf1\xc7\xb9\x80\xf9\x97\x44\x6c\xfe\x61\xe2\x12\x3c\x38\x66\x2f\xf2\xf7\xc5\xa7\x9e\x6b\x12\xa6\x27\x17\x84\x08\xb7\x8f\xda\x0f\x87\x16\x8e\xa1\xd2\x0c\xcf\x84\x6d\x64\x40\x0a\xc3\x93\xf4\xce\xd6\x26\x1f\xdf\x3f\x07\x47\x0e\x14\xef\xf9\x6a\xd9\x52\x45\x8f\x8d\x73\x7e\xfc\xb3\x2d\x03\x38\x6c\x1d\x72\xe7\x65\xe9\xb3\xf0\x48\x36\x8d\x50\x14\x62\x29\x42\x80\x12\xee\xee\xef\xcf\x9d\xb5\x96\x22\x95\x51\xfc\x26\xf4\x68\xf8\x0e\xf1\xfe\x2c\x44\xd1\xa1\x67\x6c\x66\x05\xac\x49\xfb\x34\xae\x9e\x53\xf5\x96\x74\x0b\xa4\x17\x35\x3b\xa0\x53\xbb\xe6\x8a\x66\xaf\x35\xff\xf7\x2f\xd6\xbf\x7b\xad\x36\x79\xff\x19\x60\xb2\xba\xdc\xe5\x96\x4a\xf9\xfb\xc3\xb7\xd9\x4b\xab\xed\xea\x70\xff\xe2\xb3\xc3\xc0\xc2\x7e\xeb\x9e\xf9\x7d\x3c\xaf\xfc\x25\xa4\x5c\xde\x7a\xf6\xd0\x1f\x09\x73\x40\x07\x46\x12\x6e\x8f\x0c\x90\x6d\x03\x02\x36\x7c\xf0\x97\xf6\xce\xb2\x89\x21\xda\x66\x6f\xde\xda\xd8\xb3\x0a\x38\x9d\x8a\xbf\x36\xb5\x4c\x00\xdb\x32\x06\x01\x3c\xb4\x68\x8c\x74\x9d\x55\x75\xc4\xea\x5c\xe9\x77\xfd\x65\xd0\x33\xef\xcd\x92\xde\x87\x6f\x57\x87\xfb\xcd\x72\x24\x17\xa8\xee\xc7\x63\x49\xef\xfe\x0f\x79\xae\x38\xdb\x84\xfe\xe0\xf6\x93\x00\x9c\xf6\x4e\x4d\xe1\x06\x80\x49\x9d\x30\x7b\xbc\x44\x9d\x84\x07\xb6\x76\x65\x22\xb6\xfb\xfc\x6f\x00\x00\x00\xff\xff\x93\xdd\xdc\x1e\xe3\x19\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcc\x58\x5f\x6f\xdc\xb8\x11\x7f\xf7\xa7\x18\x38\x0f\x05\x8a\x95\xd6\x39\xe7\xee\x41\x41\x1e\xd2\x5e\x2f\x09\x70\x71\x8d\x73\x50\xa0\x4f\xb7\x5c\x6a\x76\xc5\x9a\x22\x55\x72\xb4\x5b\x35\x48\x3f\x7b\x31\xa2\x44\x49\xbb\x92\xd7\x06\x52\xa0\x90\x01\xdb\x24\xe7\xff\x6f\xfe\x90\x49\x92\x5c\x89\x4a\xfd\x0d\x9d\x57\xd6\x64\x20\xaa\xca\xaf\x0f\xaf\xaf\x1e\x95\xc9\x33\xf8\x19\x2b\x6d\x9b\x12\x0d\x5d\x95\x48\x22\x17\x24\xb2\x2b\x00\x23\x4a\xcc\xa0\x40\x5d\x26\xb6\x42\x27\xc8\xba\x6e\xd5\x57\x42\x62\x06\x5f\xbf\x42\x7a\xd7\xff\x0b\xdf\xbe\x5d\xf9\x0a\x25\x53\x3a\xac\xb4\x92\xc2\x67\xf0\xfa\x0a\xc0\xa3\x46\x49\xd6\xf1\x0e\x40\x29\x48\x16\xbf\x8a\x2d\x6a\x1f\x16\x96\x04\x79\x72\x82\x70\xdf\x84\x53\xd4\x54\x98\xc1\x6f\x28\x1d\x0a\xc2\x2b\x00\xc2\xb2\xd2\x82\xb0\xe3\x3a\xd2\x9b\xff\xd7\x13\x01\x4b\x22\xf8\x13\xc6\x58\x12\xa4\xac\x19\x1d\xaf\x9c\x2d\x91\x0a\xac\x7d\xaa\xec\xda\x4b\x27\x58\xfa\x35\xb9\x1a\xaf\xdb\x43\xbd\xa5\xfc\x79\x74\x07\x25\xf1\xbd\x94\xb6\x36\x74\xb7\x28\xe9\x60\x75\x5d\x62\x94\xf2\xaa\xff\x0d\x7f\xb7\x35\x1c\x95\xd6\x60\x10\x73\xa0\x02\x3d\x02\x1d\x6d\x4f\x00\x6a\x07\x0d\x1f\x11\x86\x80\x2c\xa0\x27\xb1\xd5\xca\x17\x70\x10\x5a\xe5\x82\x30\x87\x2f\xbf\x3e\x44\x76\xd2\x1a\x83\xb2\xb5\x08\xc4\x5e\x28\xe3\x09\xbe\x28\xad\xd1\xa5\xfd\x99\x78\x36\xe9\x3c\x43\xed\x7e\x42\xda\x27\x52\xc4\x5d\x00\x69\xcd\x4e\xed\x3f\x8b\x2a\x6a\x3d\x72\xe7\x84\x28\x09\x47\x27\xc7\x72\xdc\x89\x5a\xd3\x67\x9b\x63\x06\x37\x3f\xdd\xdc\xf4\xbb\xf1\xd4\x03\x07\x94\xc0\xee\xda\x00\xc3\xe6\xb1\xde\xa2\x33\x48\xd8\x3a\x9e\xb4\xdf\x64\xcf\xd2\x19\x1d\xc5\x7d\x8e\x08\x73\x9d\xaa\x1c\xd6\xee\x2e\x50\x9e\xea\xfc\x66\x46\xe7\x2f\x05\xc2\xce\x6a\x6d\x8f\xca\xec\xbb\x20\x81\xf2\xb0\xb3\x0e\x6a\xcf\x6b\x02\x64\xed\xc9\x96\xca\x63\x0e\x9b\x47\x63\x8f\xe6\xf7\xc2\x7a\xf2\x1b\xd8\x29\x8d\xab\xc8\xea\x58\x28\x59\x40\x33\x8d\xbf\x85\xdc\xf6\x31\x67\x2a\xde\x77\x60\x8f\x06\xf6\x8a\xc0\x61\x65\xbd\x22\xeb\x1a\x70\x82\x0a\xec\xd1\xf5\x0a\xa8\x10\xa6\x53\xe0\x83\xa2\x8f\xf5\x16\xac\x63\x34\x81\x56\x8f\x98\x0e\x28\x13\xda\xdb\x28\xaa\x64\xcc\x82\x1a\x7c\xa0\x0c\xd9\x96\x4a\x5a\x43\x42\x19\x74\x2b\xd8\xa2\xb6\xc7\x73\xf0\x30\xc7\x52\x34\x81\xe1\x91\x01\x49\x16\x2a\x67\x0f\x2a\x47\x10\x06\x36\xde\x17\xbf\x07\x58\x9c\x1a\xce\xc5\x46\x59\xc3\xce\x2a\xad\xc3\xa0\xbb\x35\x08\x9b\x4f\x39\x6f\x51\xf3\x8b\xd2\xb8\x79\xdb\x3a\x95\x11\x2c\x8c\xc4\x55\xef\x15\xe1\x30\x72\xaa\xfd\x39\x93\xce\xfc\xc1\x55\x29\xdc\xfd\x29\x6b\xad\x42\x43\xae\x81\x47\x6c\xc0\x17\xb6\xd6\x39\x6c\x07\x56\xd7\x41\xd7\xeb\xce\xb1\x81\xdf\xf5\x60\xc4\x35\xcb\x6f\x1d\x86\x39\x28\x03\xff\x59\xa7\xde\x17\xeb\xe5\xac\xf2\xbe\xc8\x95\x7b\x6e\x3a\xed\x74\xfd\xaf\xc4\xfb\xe2\x72\x26\x31\x2a\xbf\x7e\x4d\x58\x9d\xf4\xe1\xe1\xe3\x43\x84\x36\x17\xe1\x9e\xec\xe1\xe1\x23\x54\x4e\x1d\x04\x61\x6b\x2f\x59\x10\x52\xa2\xf7\xf0\x61\x0c\x23\x85\xbe\x37\x00\xa2\xe2\x7b\x45\xc9\x23\x36\x71\xfd\x34\xa5\xc6\x6b\x2c\x37\xf4\x82\x05\x55\x60\xde\x82\x16\xfe\x68\xa2\x43\x1d\x8a\x3c\xb1\x46\x37\x2b\x38\x22\x1c\xad\xf9\x03\xc1\x16\x41\x6c\x35\x72\x4e\xc8\xa2\xb4\x79\x6b\x35\x6a\x3f\x36\x74\xb1\x8c\x2a\x1f\x93\x33\xc2\xa6\x83\x8b\x38\x4d\x25\x2a\xc4\x90\x02\x4c\xef\x19\xc0\xec\x42\x76\x1d\x83\x30\xf8\xee\x2d\x60\xba\x4f\x57\x20\x7a\x8c\xe5\x6d\xef\x64\x07\xa7\xf0\x69\x17\x59\x4c\xc5\xfd\xa3\xf6\xd4\x02\xd3\xd7\xb2\x18\x89\x5d\xb5\x90\xec\x3c\x33\x49\x97\xc8\x48\x68\xf6\x4b\x03\x95\x55\x86\x3c\x08\x82\xcd\x1a\x49\xae\x19\x2c\xf9\x9a\xe1\xa7\xba\x84\xd9\x80\xf0\x20\x7a\x4d\xd8\xe0\xc8\xa4\xef\x1c\xb5\xc7\x93\x4c\x79\xc4\x66\x75\x5e\x7f\xfa\x1c\xee\x0b\x4f\x64\x34\xc9\x68\xb1\xb5\x07\x5c\xc1\x51\x51\xc1\xce\x9a\x66\x6e\x97\x68\x6d\xb7\x67\x17\xa0\x90\x45\x64\xc3\x3e\x55\xa6\x35\x3e\x60\xa8\xaf\x07\x98\x43\x81\x0e\xd3\xb3\xd8\xce\x03\xf3\x39\xb5\x9e\x1d\x95\x30\x59\x88\xd4\xe4\xdc\x77\xc4\xa4\xc9\x5f\x06\x49\xb2\xa0\xca\xca\x3a\x02\x61\x9a\xae\x5d\xc0\x47\xd4\xe5\x00\x0f\x85\x3e\x32\x12\x86\xc9\x51\x39\x90\x0e\x5b\x3f\x0b\xcd\x10\xcd\xd7\xd6\x81\x44\x47\x6a\xa7\x24\x67\x7a\xc0\x4f\xed\xc2\x44\x73\x52\xf5\x23\xbb\xd3\xea\x3f\x53\xf5\x99\x39\x78\x24\x16\x0b\x9b\x24\x69\x27\x9a\x01\xba\x49\xd0\x7e\x33\x68\xe8\xf6\x35\x47\x71\xb1\x5b\xf4\x21\x1c\xdb\x97\x34\xa2\xd4\x2f\x0d\xe6\x54\x93\xb1\x97\x66\x63\xda\xed\x46\xc3\xe2\x00\x96\x3c\x31\x15\x76\x7d\xde\x61\x9b\xc5\xc6\xc2\x75\xc6\xc3\xa6\xa7\x6b\x50\xa5\xd8\x63\xe8\xf6\x13\xca\x14\x7e\x51\x21\x4a\x50\x72\xd7\x76\x28\x79\xa2\x1e\xf8\x39\xd4\x28\x3c\x72\x57\x6e\x79\xc0\x21\x8c\xe3\x9c\xd4\x05\x51\xe5\xb3\xf5\xba\xa8\xb7\x69\x6e\xe5\x23\xba\x54\xda\x72\xed\xda\x3c\x97\xf9\x7a\x22\x69\x4d\x62\xdf\x1b\xdd\x83\x83\x47\x6e\x21\x39\xb3\x11\x48\xec\x27\x69\x04\x41\xe7\x0c\x3a\xce\xca\xce\xb2\xcd\x5e\xa7\x6f\xd2\x37\x53\x9a\xfb\x5a\xeb\x7b\xab\x95\x6c\x32\xf8\xb4\xbb\xb3\x74\xef\xd0\x8f\xcd\x62\x10\xf8\xec\xac\x81\xb0\x3d\x71\x71\xe4\xfc\x7b\xeb\x28\x83\xdb\x9b\xdb\x7e\xac\x02\xd0\xea\x80\x06\xbd\xbf\x77\x76\xdb\x4d\xf3\xe1\x87\x79\x7c\x18\xd0\x30\xc8\x3b\x61\xc0\x3f\x95\xa0\x22\x83\x75\x81\x42\x53\xf1\xef\xd1\x96\x32\x8a\x94\xd0\x3f\xa3\x16\xcd\x03\x4a\x6b\xf2\xee\x56\xd2\x7f\xa4\x4a\xb4\x35\xc5\xbd\x1f\xe3\x1e\x17\x5e\xf5\x7f\xaa\x99\xb7\xb5\x93\xc3\x5d\x82\x3f\x87\xff\xac\xd1\x8f\x83\xc1\x9f\xac\xea\x0c\x7e\xbc\x29\x27\x8b\x25\x96\xd6\x35\x19\xfc\xf4\xe6\xb3\x8a\x1b\xa1\x57\x7e\xe6\xca\x37\xe2\xd1\xe7\x2f\xe3\xec\x93\x91\xba\xce\x19\x63\x2a\x5e\x4a\xa6\x95\xe4\x89\xc1\xd7\xba\xb9\x06\xc7\x6c\x79\x32\x7c\x0b\xcd\xcc\x78\xca\x95\x27\xcc\x50\x9b\xbe\x6e\xb6\x4d\x27\x9d\x55\x6f\x76\xea\xe2\x0d\x08\xf5\xfc\x3e\x44\xc2\x59\x4b\xed\xe0\x36\x39\xc1\xc1\xfe\xab\xd1\x4d\x06\x7c\xc9\xbb\x34\x5d\xf5\xd7\x80\x99\x19\x8b\x7b\x2c\xd7\x06\x1e\xb2\xc2\xb5\xb9\x2d\xc4\x91\x10\x16\x7a\x59\x17\x99\x91\xa2\x93\x36\x3f\x33\xf9\x5c\x0e\xce\x85\x81\x67\x32\xe8\x8c\x58\x8d\x2d\x1a\x8f\x3e\x4b\x21\xea\x0c\x79\x49\x8c\x4e\x6d\x7f\x75\xd9\x76\x93\xbf\xc4\xf4\x7e\xe4\x19\x35\xd9\xb3\xee\x3a\x74\xd5\x11\xb3\x17\xf5\xd7\x25\x9f\x8c\xf8\x6d\xc6\x12\xdb\x7e\x37\xf5\xd3\xaa\xd7\xe2\x72\x93\x1d\xb7\xd9\x69\x97\x9d\x77\xf2\x99\xe4\x45\x77\x87\x9c\x60\xe9\xeb\x41\xfa\xf0\x27\xdf\x11\xce\xe8\x7d\xbd\x0d\xd4\x4f\x1f\x3b\x49\xac\xb8\x35\x1b\x3f\x7e\xf9\x78\xde\x6b\xc7\xf8\x95\x63\xc4\x6a\xfa\xde\xb1\x0c\xd9\x93\x17\x80\x0d\x07\x61\xc4\x66\xb2\x2f\xfa\x80\x79\xc8\x51\x6a\xe1\x30\x9f\x09\xdd\x88\xe2\x8f\x73\x01\xf3\xcf\x89\xd8\xfc\xc3\xc4\x53\xe9\xc1\x31\x7b\x96\xbf\x9f\x7c\xea\xb9\x24\xe1\xf4\xe4\x82\x10\xe1\xf6\x93\xf6\xc3\xa9\x09\xc7\xb6\xd2\xc4\x67\xc2\x10\x19\x90\xc2\xf0\x24\xbd\xb3\xb5\xc9\x07\x9f\x24\x10\x1d\x19\xdf\x19\xdf\xf1\xd5\x32\x50\x4d\x1e\x1b\xe7\xfc\xf8\x67\x5b\xb2\xaf\xc1\xd6\x2d\x76\x9e\x07\x9f\x85\x47\xb2\xd3\x08\x4d\x42\x2c\x45\x1b\xa0\x84\xe7\x8e\x77\xe7\xce\x5a\x4b\x91\x4a\x47\xcb\xf4\x68\xf8\x0e\xf1\xee\x2c\x44\x93\x43\x8f\xd8\xcc\x0a\x58\x93\xf6\xe9\xb4\x7a\x9e\x50\x2e\xea\xd6\x92\x3e\xa9\xd9\x01\x9d\xda\x35\x17\x34\x7b\xa9\xf9\xb3\xc9\xfe\x5d\x8b\xf5\xff\xbc\x56\x9b\xbc\xff\xb3\x4d\x93\xd5\xd3\x5d\x6e\xa9\x94\xbf\x3b\xfc\x90\x3d\xb7\xda\xae\x0e\xb7\xcf\x3e\x1b\x07\x16\x6e\x72\xdd\x33\xbf\x9f\x64\x09\xfc\xa5\x85\x5c\x1e\x3c\xdb\x5d\x3d\x7c\x98\x03\xba\x64\x24\xe1\xf6\xc8\xaf\xc9\xdb\x06\x04\x6c\xf8\xe0\x6f\xe1\xce\xb2\x19\xec\x64\xdb\x02\x7a\xf3\x60\x63\xcf\xaa\xcd\xd3\x53\xf1\x97\xa6\x96\x93\x84\x0d\x8c\x41\x00\xbf\xd2\x68\x9c\xe8\x3a\xab\xea\x88\xd5\xb9\xd2\x6f\xfb\xcb\xa0\x67\x70\x6d\x96\xf4\x3e\xfc\xb0\x3a\xdc\x6e\xd2\x59\xfd\x96\xad\x3d\xdc\x8e\xc7\x92\xde\xfd\xef\xf3\x5c\xf1\x64\x20\xf4\x7b\xb7\x3f\x09\xc0\xb0\x37\x34\x85\x2b\x00\x26\x75\xc2\xec\xf1\x29\xea\xa4\x7d\x60\x0b\x2b\x27\x62\xd1\xe4\xf0\xed\xdb\xd5\x7f\x07\x00\x93\xdd\xdc\x1e\xe3\x19\x00\x00"),
 		},
 		"/rbac.yaml.tmpl": &vfsgen۰CompressedFileInfo{
 			name:             "rbac.yaml.tmpl",
 			modTime:          time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC),
 			uncompressedSize: 691,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x92\x3f\x4f\x03\x31\x0c\xc5\xf7\x7c\x0a\x6f\x95\x90\x72\x88\x0d\x65\x03\x06\x16\xc4\x70\x08\x16\xc4\xe0\xcb\x19\x6a\x9a\x8b\x23\x27\xb9\x81\xaa\xdf\x1d\xb5\x6a\x11\x7f\xda\x5b\xb8\x2d\x79\xb2\xad\xdf\x7b\x7a\xd6\x5a\x83\x89\x9f\x48\x33\x4b\x74\x30\x5e\x98\x15\xc7\xde\xc1\x03\xe9\xc8\x9e\xae\xbc\x97\x1a\x8b\x19\xa8\x60\x8f\x05\x9d\x01\x08\xd8\x51\xc8\xdb\x17\x40\xc4\x81\x1c\x2c\x29\x0c\x56\x12\x29\x16\x51\x33\xa5\xe6\x84\x9e\x1c\xac\xd7\xd0\xdc\x1f\xbe\xb0\xd9\x98\xdf\x1c\xda\xa1\x6f\xb0\x96\xa5\x28\x7f\x60\x61\x89\xcd\xea\x32\x37\x2c\xe7\x5f\x84\x37\xa1\xe6\x42\xda\x4a\xa0\x19\xf0\xb4\x06\xda\x2d\x59\xc0\xc4\xb7\x2a\x35\x65\x07\xcf\x8b\xb3\xc5\xcb\xee\x92\x52\x96\xaa\x9e\x7e\x88\x23\x69\xf7\x4d\xb0\x10\x25\xb6\xfb\xc1\xc7\xf6\xee\xf4\xec\xff\xec\x5e\x73\xec\x39\xbe\xcd\xe1\x5a\x02\xb5\xf4\xba\x5d\x3b\xb8\x9e\x80\x31\x00\x7f\xa3\x3f\x7e\x38\xd7\xee\x9d\x7c\xd9\x27\x7a\xb4\x52\xa7\x49\xa7\xab\xf2\x19\x00\x00\xff\xff\xa8\x83\xad\xec\xb3\x02\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x92\xbf\x4e\x03\x31\x0c\x87\xf7\x3c\x85\xb7\x4a\x48\x39\xc4\x86\xb2\x01\x03\x0b\x62\x38\x04\x0b\x62\xf0\xe5\x0c\x35\xcd\xc5\x91\x93\xdc\x40\xd5\x77\x47\xad\x5a\xc4\x9f\xf6\x16\x6e\x4b\x7e\xb2\xad\xef\xb3\x6c\xad\x35\x98\xf8\x89\x34\xb3\x44\x07\xe3\x85\x59\x71\xec\x1d\x3c\x90\x8e\xec\xe9\xca\x7b\xa9\xb1\x98\x81\x0a\xf6\x58\xd0\x19\x80\x80\x1d\x85\xbc\x7d\x01\x44\x1c\xc8\xc1\x92\xc2\x60\x25\x91\x62\x11\x35\x53\x69\x4e\xe8\xc9\xc1\x7a\x0d\xcd\xfd\xe1\x0b\x9b\x8d\xf9\xcd\xa1\x1d\xfa\x06\x6b\x59\x8a\xf2\x07\x16\x96\xd8\xac\x2e\x73\xc3\x72\xfe\x45\x78\x13\x6a\x2e\xa4\xad\x04\x9a\x01\x4f\x6b\xa0\x5d\x93\x05\x4c\x7c\xab\x52\x53\x76\xf0\xbc\x38\x5b\xbc\xec\x26\x29\x65\xa9\xea\xe9\x47\x38\x92\x76\xdf\x02\x0b\x51\x62\xbb\x2f\x7c\x6c\xef\x4e\xd7\xfe\x4f\xf7\x9a\x63\xcf\xf1\x6d\x0e\x6b\x09\xd4\xd2\xeb\xd6\xfb\x60\x3d\x01\x63\x00\xfe\xae\xfe\xf8\xe0\x5c\xbb\x77\xf2\x65\xbf\xd1\xa3\x27\x75\x9a\x74\xfa\x54\x3e\x07\x00\xa8\x83\xad\xec\xb3\x02\x00\x00"),
 		},
 	}
 	fs["/"].(*vfsgen۰DirInfo).entries = []os.FileInfo{
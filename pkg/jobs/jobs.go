@@ -0,0 +1,184 @@
+package jobs
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/utils/clock"
+)
+
+const (
+	// DefaultMaxJobs is the default maximum amount of jobs held in
+	// the store.
+	DefaultMaxJobs = 1000
+	// DefaultRetention is the default duration a finished job is held
+	// in the store.
+	DefaultRetention = 1 * time.Hour
+)
+
+// ID is the unique identifier of a job.
+type ID string
+
+// State is the state a job is in.
+type State string
+
+const (
+	StateQueued    State = "queued"
+	StateRunning   State = "running"
+	StateSucceeded State = "succeeded"
+	StateFailed    State = "failed"
+)
+
+// Job holds the information about an operation triggered through the
+// API.
+type Job struct {
+	ID         ID         `json:"id"`
+	Type       string     `json:"type"`
+	State      State      `json:"state"`
+	Releases   []string   `json:"releases,omitempty"`
+	Error      string     `json:"error,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	StartedAt  *time.Time `json:"startedAt,omitempty"`
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+}
+
+// Finished returns if the job has reached a final state.
+func (j Job) Finished() bool {
+	return j.State == StateSucceeded || j.State == StateFailed
+}
+
+// Store holds jobs in memory. Finished jobs are removed once they
+// exceed the retention, and the oldest jobs are removed once the
+// maximum amount of jobs is exceeded.
+type Store struct {
+	mu        sync.Mutex
+	jobs      map[ID]*Job
+	order     []ID
+	maxJobs   int
+	retention time.Duration
+	clock     clock.Clock
+}
+
+// NewStore returns a new Store that holds at most maxJobs jobs, and
+// retains finished jobs for the given retention.
+func NewStore(maxJobs int, retention time.Duration) *Store {
+	return &Store{
+		jobs:      make(map[ID]*Job),
+		maxJobs:   maxJobs,
+		retention: retention,
+		clock:     clock.RealClock{},
+	}
+}
+
+// New records a new queued job of the given type for the given
+// releases, and returns it.
+func (s *Store) New(jobType string, releases ...string) Job {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	j := &Job{
+		ID:        ID(uuid.NewUUID()),
+		Type:      jobType,
+		State:     StateQueued,
+		Releases:  releases,
+		CreatedAt: s.clock.Now().UTC(),
+	}
+	s.jobs[j.ID] = j
+	s.order = append(s.order, j.ID)
+	s.prune()
+	return *j
+}
+
+// Get returns the job with the given ID, and a boolean indicating if
+// it was found.
+func (s *Store) Get(id ID) (Job, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.prune()
+	j, ok := s.jobs[id]
+	if !ok {
+		return Job{}, false
+	}
+	return *j, true
+}
+
+// Start marks the job with the given ID as running.
+func (s *Store) Start(id ID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	j, ok := s.jobs[id]
+	if !ok || j.State != StateQueued {
+		return
+	}
+	now := s.clock.Now().UTC()
+	j.State = StateRunning
+	j.StartedAt = &now
+}
+
+// Finish marks the job with the given ID as succeeded, or as failed
+// if the given error is not nil.
+func (s *Store) Finish(id ID, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	j, ok := s.jobs[id]
+	if !ok || j.Finished() {
+		return
+	}
+	now := s.clock.Now().UTC()
+	if j.StartedAt == nil {
+		j.StartedAt = &now
+	}
+	j.FinishedAt = &now
+	j.State = StateSucceeded
+	if err != nil {
+		j.State = StateFailed
+		j.Error = err.Error()
+	}
+}
+
+// Observer returns an observer that records the progress of a sync
+// on the job with the given ID.
+func (s *Store) Observer(id ID) *Observer {
+	return &Observer{store: s, id: id}
+}
+
+// prune removes the finished jobs that exceed the retention, and
+// the oldest jobs that exceed the maximum amount of jobs. It must be
+// called while holding the lock.
+func (s *Store) prune() {
+	now := s.clock.Now()
+	order := s.order[:0]
+	for _, id := range s.order {
+		j := s.jobs[id]
+		if j.Finished() && now.Sub(*j.FinishedAt) > s.retention {
+			delete(s.jobs, id)
+			continue
+		}
+		order = append(order, id)
+	}
+	for len(order) > s.maxJobs {
+		delete(s.jobs, order[0])
+		order = order[1:]
+	}
+	s.order = order
+}
+
+// Observer records the progress of a sync on a job.
+type Observer struct {
+	store *Store
+	id    ID
+}
+
+// SyncStarted marks the job as running.
+func (o *Observer) SyncStarted() {
+	o.store.Start(o.id)
+}
+
+// SyncFinished marks the job as finished with the given error.
+func (o *Observer) SyncFinished(err error) {
+	o.store.Finish(o.id, err)
+}
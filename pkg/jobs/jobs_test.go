@@ -0,0 +1,62 @@
+package jobs
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+func TestStore_Lifecycle(t *testing.T) {
+	s := NewStore(DefaultMaxJobs, DefaultRetention)
+
+	j := s.New("sync-release", "default/podinfo")
+	assert.Equal(t, StateQueued, j.State)
+	assert.Equal(t, []string{"default/podinfo"}, j.Releases)
+
+	o := s.Observer(j.ID)
+	o.SyncStarted()
+	j, ok := s.Get(j.ID)
+	assert.True(t, ok)
+	assert.Equal(t, StateRunning, j.State)
+	assert.NotNil(t, j.StartedAt)
+
+	o.SyncFinished(errors.New("upgrade failed"))
+	j, _ = s.Get(j.ID)
+	assert.Equal(t, StateFailed, j.State)
+	assert.Equal(t, "upgrade failed", j.Error)
+	assert.NotNil(t, j.FinishedAt)
+
+	// A finished job can not be finished again.
+	o.SyncFinished(nil)
+	j, _ = s.Get(j.ID)
+	assert.Equal(t, StateFailed, j.State)
+}
+
+func TestStore_Retention(t *testing.T) {
+	clock := clocktesting.NewFakeClock(time.Now())
+	s := NewStore(2, time.Minute)
+	s.clock = clock
+
+	finished := s.New("sync-git")
+	s.Finish(finished.ID, nil)
+	running := s.New("sync-git")
+	s.Start(running.ID)
+
+	clock.Step(2 * time.Minute)
+	_, ok := s.Get(finished.ID)
+	assert.False(t, ok, "finished job should have been removed after retention")
+	_, ok = s.Get(running.ID)
+	assert.True(t, ok, "running job should be retained")
+
+	second := s.New("sync-git")
+	third := s.New("sync-git")
+	_, ok = s.Get(running.ID)
+	assert.False(t, ok, "oldest job should have been removed when exceeding max jobs")
+	for _, id := range []ID{second.ID, third.ID} {
+		_, ok = s.Get(id)
+		assert.True(t, ok)
+	}
+}
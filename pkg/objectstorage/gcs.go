@@ -0,0 +1,58 @@
+package objectstorage
+
+import (
+	"context"
+	"sync"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+const (
+	gcsHost = "storage.googleapis.com"
+	// gcsScope is the OAuth2 scope requested for the application
+	// default credentials.
+	gcsScope = "https://www.googleapis.com/auth/devstorage.read_only"
+)
+
+var (
+	gcsTokenSourceOnce sync.Once
+	gcsTokenSource     oauth2.TokenSource
+)
+
+// newGCSClient returns a client for Google Cloud Storage, making use
+// of its interoperability with the S3 API. Requests are signed with
+// the HMAC keys from the given options, or else authorized with the
+// application default credentials.
+func newGCSClient(opts Options) (*minio.Client, error) {
+	if opts.HasCredentials() {
+		return minio.New(gcsHost, &minio.Options{
+			Creds:     credentials.NewStaticV2(opts.AccessKeyID, opts.SecretAccessKey, ""),
+			Secure:    true,
+			Transport: transport,
+			Region:    "auto",
+		})
+	}
+
+	gcsTokenSourceOnce.Do(func() {
+		// Without application default credentials the request is
+		// made anonymously, which works for public buckets.
+		if ts, err := google.DefaultTokenSource(context.Background(), gcsScope); err == nil {
+			gcsTokenSource = ts
+		}
+	})
+	rt := transport
+	if gcsTokenSource != nil {
+		// The client makes anonymous requests, the transport adds
+		// the OAuth2 token of the credentials.
+		rt = &oauth2.Transport{Source: gcsTokenSource, Base: transport}
+	}
+	return minio.New(gcsHost, &minio.Options{
+		Creds:     credentials.NewStaticV2("", "", ""),
+		Secure:    true,
+		Transport: rt,
+		Region:    "auto",
+	})
+}
@@ -0,0 +1,113 @@
+// Package objectstorage implements the fetching of objects from S3
+// compatible object storage (`s3://` URLs) and Google Cloud Storage
+// (`gs://` URLs), for chart repositories hosted in a bucket.
+package objectstorage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// Schemes are the URL schemes supported by Get.
+var Schemes = []string{"s3", "gs"}
+
+// maxObjectSize is the maximum size of an object fetched by Get.
+// Helm getters return the whole object in memory, chart packages and
+// repository indexes are well below this size.
+const maxObjectSize = 256 << 20
+
+// requestTimeout is the timeout for fetching an object.
+const requestTimeout = 5 * time.Minute
+
+// transport is shared by all clients, so that connections are reused.
+var transport = func() http.RoundTripper {
+	tr, err := minio.DefaultTransport(true)
+	if err != nil {
+		return http.DefaultTransport
+	}
+	return tr
+}()
+
+// Options holds the options to access a bucket.
+type Options struct {
+	// AccessKeyID and SecretAccessKey are the static credentials for
+	// the bucket. For Google Cloud Storage these are the HMAC keys of
+	// a service account.
+	AccessKeyID     string
+	SecretAccessKey string
+	// Endpoint is the URL of the S3 compatible service, e.g.
+	// `http://minio.minio.svc:9000`. It is ignored for Google Cloud
+	// Storage.
+	Endpoint string
+	// Region is the region of the S3 bucket, it is looked up when
+	// not set.
+	Region string
+}
+
+// HasCredentials returns true if static credentials are set.
+func (o Options) HasCredentials() bool {
+	return o.AccessKeyID != "" || o.SecretAccessKey != ""
+}
+
+// Get fetches the object at the given `s3://<bucket>/<key>` or
+// `gs://<bucket>/<key>` URL. When the options hold no credentials,
+// the ambient credentials of the pod are used: for S3 the `AWS_`
+// access key environment variables or the web identity of the pod
+// (e.g. IAM roles for service accounts), for Google Cloud Storage the
+// application default credentials (e.g. workload identity). If none
+// are found, the object is requested anonymously.
+//
+// The object is read into a buffer of its size, as required by the
+// Helm getters; objects larger than 256MiB are refused.
+func Get(ctx context.Context, href string, opts Options) (*bytes.Buffer, error) {
+	u, err := url.Parse(href)
+	if err != nil {
+		return nil, err
+	}
+	bucket, key := u.Host, strings.TrimPrefix(u.Path, "/")
+	if bucket == "" || key == "" {
+		return nil, fmt.Errorf("URL %q does not refer to an object in a bucket", href)
+	}
+
+	var client *minio.Client
+	switch u.Scheme {
+	case "s3":
+		client, err = newS3Client(opts)
+	case "gs":
+		client, err = newGCSClient(opts)
+	default:
+		return nil, fmt.Errorf("scheme %q not supported", u.Scheme)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+	obj, err := client.GetObject(ctx, bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", href, err)
+	}
+	defer obj.Close()
+	info, err := obj.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", href, err)
+	}
+	if info.Size > maxObjectSize {
+		return nil, fmt.Errorf("failed to fetch %s: object size %d exceeds the maximum of %d bytes", href, info.Size, maxObjectSize)
+	}
+
+	buf := bytes.NewBuffer(make([]byte, 0, info.Size))
+	if _, err = io.Copy(buf, io.LimitReader(obj, maxObjectSize)); err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", href, err)
+	}
+	return buf, nil
+}
@@ -0,0 +1,99 @@
+package objectstorage
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+const (
+	awsHost = "s3.amazonaws.com"
+	// stsTimeout is the timeout for exchanging the web identity
+	// token of the pod for temporary credentials.
+	stsTimeout = 30 * time.Second
+)
+
+var (
+	ambientS3CredentialsOnce sync.Once
+	ambientS3Credentials     *credentials.Credentials
+)
+
+// newS3Client returns a client for the S3 compatible service at the
+// endpoint from the given options, or else the one from the
+// `AWS_ENDPOINT_URL_S3` (or `AWS_ENDPOINT_URL`) environment variable,
+// or else AWS. The region defaults to the `AWS_REGION` (or
+// `AWS_DEFAULT_REGION`) environment variable.
+func newS3Client(opts Options) (*minio.Client, error) {
+	host, secure, lookup := awsHost, true, minio.BucketLookupAuto
+	endpoint := opts.Endpoint
+	if endpoint == "" {
+		endpoint = firstEnv("AWS_ENDPOINT_URL_S3", "AWS_ENDPOINT_URL")
+	}
+	if endpoint != "" {
+		u, err := url.Parse(endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("invalid S3 endpoint %q: %w", endpoint, err)
+		}
+		if u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") || (u.Path != "" && u.Path != "/") {
+			return nil, fmt.Errorf("invalid S3 endpoint %q: expected an URL of the form http(s)://<host>[:<port>]", endpoint)
+		}
+		// Other services than AWS generally only support path-style
+		// requests.
+		host, secure, lookup = u.Host, u.Scheme == "https", minio.BucketLookupPath
+	}
+
+	region := opts.Region
+	if region == "" {
+		region = firstEnv("AWS_REGION", "AWS_DEFAULT_REGION")
+	}
+
+	creds := s3AmbientCredentials()
+	if opts.HasCredentials() {
+		creds = credentials.NewStaticV4(opts.AccessKeyID, opts.SecretAccessKey, "")
+	}
+	return minio.New(host, &minio.Options{
+		Creds:        creds,
+		Secure:       secure,
+		Transport:    transport,
+		Region:       region,
+		BucketLookup: lookup,
+	})
+}
+
+// s3AmbientCredentials returns the ambient credentials of the pod:
+// the `AWS_ACCESS_KEY_ID` and `AWS_SECRET_ACCESS_KEY` environment
+// variables, or else the temporary credentials for the web identity
+// of the pod. Without either, requests are made anonymously. The
+// credentials are shared, so temporary credentials are only renewed
+// once they expire.
+func s3AmbientCredentials() *credentials.Credentials {
+	ambientS3CredentialsOnce.Do(func() {
+		providers := []credentials.Provider{&credentials.EnvAWS{}}
+		if os.Getenv("AWS_WEB_IDENTITY_TOKEN_FILE") != "" {
+			// IAM roles for service accounts, the token is exchanged
+			// for temporary credentials of `AWS_ROLE_ARN`.
+			providers = append(providers, &credentials.IAM{
+				Client: &http.Client{Transport: transport, Timeout: stsTimeout},
+			})
+		}
+		ambientS3Credentials = credentials.NewChainCredentials(providers)
+	})
+	return ambientS3Credentials
+}
+
+// firstEnv returns the value of the first set environment variable
+// of the given names.
+func firstEnv(names ...string) string {
+	for _, name := range names {
+		if v := os.Getenv(name); v != "" {
+			return v
+		}
+	}
+	return ""
+}
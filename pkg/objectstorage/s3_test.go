@@ -0,0 +1,80 @@
+package objectstorage
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newS3Server returns a server serving the given objects, recording
+// the path and authorization header of the last request.
+func newS3Server(objects map[string]string) (*httptest.Server, *string, *string) {
+	var path, auth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path, auth = r.URL.EscapedPath(), r.Header.Get("Authorization")
+		body, ok := objects[r.URL.Path]
+		if !ok {
+			w.Header().Set("Content-Type", "application/xml")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>`))
+			return
+		}
+		w.Header().Set("Last-Modified", "Mon, 02 Jan 2006 15:04:05 GMT")
+		w.Header().Set("ETag", `"etag"`)
+		w.Write([]byte(body))
+	}))
+	return srv, &path, &auth
+}
+
+func TestGet_S3(t *testing.T) {
+	srv, path, auth := newS3Server(map[string]string{"/charts/stable/my chart-1.0.0.tgz": "chart"})
+	defer srv.Close()
+
+	buf, err := Get(context.Background(), "s3://charts/stable/my chart-1.0.0.tgz", Options{
+		AccessKeyID:     "id",
+		SecretAccessKey: "secret",
+		Endpoint:        srv.URL,
+		Region:          "eu-west-1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "chart", buf.String())
+	assert.Equal(t, "/charts/stable/my%20chart-1.0.0.tgz", *path)
+	assert.True(t, strings.HasPrefix(*auth, "AWS4-HMAC-SHA256 Credential=id/"))
+	assert.Contains(t, *auth, "/eu-west-1/s3/aws4_request")
+
+	_, err = Get(context.Background(), "s3://charts/stable/missing-1.0.0.tgz", Options{Endpoint: srv.URL, Region: "eu-west-1"})
+	assert.Error(t, err)
+}
+
+func TestGet_S3EndpointEnv(t *testing.T) {
+	srv, path, auth := newS3Server(map[string]string{"/charts/index.yaml": "index"})
+	defer srv.Close()
+
+	for _, env := range []string{"AWS_ACCESS_KEY_ID", "AWS_ACCESS_KEY", "AWS_SECRET_ACCESS_KEY", "AWS_SECRET_KEY"} {
+		if v, ok := os.LookupEnv(env); ok {
+			os.Unsetenv(env)
+			defer os.Setenv(env, v)
+		}
+	}
+	os.Setenv("AWS_ENDPOINT_URL_S3", srv.URL)
+	defer os.Unsetenv("AWS_ENDPOINT_URL_S3")
+
+	buf, err := Get(context.Background(), "s3://charts/index.yaml", Options{Region: "us-east-1"})
+	require.NoError(t, err)
+	assert.Equal(t, "index", buf.String())
+	assert.Equal(t, "/charts/index.yaml", *path)
+	assert.Empty(t, *auth, "expected an anonymous request")
+}
+
+func TestNewS3Client_InvalidEndpoint(t *testing.T) {
+	for _, endpoint := range []string{"minio.example.com", "ftp://minio.example.com", "https://minio.example.com/path"} {
+		_, err := newS3Client(Options{Endpoint: endpoint})
+		assert.Error(t, err, endpoint)
+	}
+}
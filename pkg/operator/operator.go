@@ -1,8 +1,9 @@
 package operator
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"github.com/fluxcd/helm-operator/pkg/chartsync"
 	"os"
 	"path"
 	"sync"
@@ -12,6 +13,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
@@ -19,17 +21,24 @@ import (
 	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
-	"k8s.io/client-go/util/workqueue"
 
 	"github.com/fluxcd/helm-operator/internal/lockedfile"
+	"github.com/fluxcd/helm-operator/pkg/api"
 	helmfluxv1 "github.com/fluxcd/helm-operator/pkg/apis/helm.fluxcd.io/v1"
+	"github.com/fluxcd/helm-operator/pkg/chartsync"
 	ifscheme "github.com/fluxcd/helm-operator/pkg/client/clientset/versioned/scheme"
+	v1client "github.com/fluxcd/helm-operator/pkg/client/clientset/versioned/typed/helm.fluxcd.io/v1"
 	hrv1 "github.com/fluxcd/helm-operator/pkg/client/informers/externalversions/helm.fluxcd.io/v1"
 	iflister "github.com/fluxcd/helm-operator/pkg/client/listers/helm.fluxcd.io/v1"
+	"github.com/fluxcd/helm-operator/pkg/queue"
 	"github.com/fluxcd/helm-operator/pkg/release"
 	"github.com/fluxcd/helm-operator/pkg/status"
 )
 
+// cancelTimeout is the duration Run waits for the workers to return
+// after cancelling their in-flight releases.
+const cancelTimeout = 10 * time.Second
+
 const (
 	controllerAgentName = "helm-operator"
 	ReleaseSynced       = "ReleaseSynced"
@@ -41,6 +50,15 @@ type Controller struct {
 	logger   log.Logger
 	logDiffs bool
 
+	// syncInterval is the interval at which HelmReleases without an
+	// interval of their own are reconciled.
+	syncInterval time.Duration
+
+	// backoff tracks the consecutive failures of HelmReleases, and
+	// determines when their reconciliation is retried.
+	backoff *queue.Backoff
+
+	hrClient v1client.HelmV1Interface
 	hrLister iflister.HelmReleaseLister
 	hrSynced cache.InformerSynced
 
@@ -51,40 +69,62 @@ type Controller struct {
 	// processed instead of performing it as soon as a change happens. This
 	// means we can ensure we only process a fixed amount of resources at a
 	// time, and makes it easy to ensure we are never processing the same item
-	// simultaneously in two different workers.
-	releaseWorkqueue workqueue.RateLimitingInterface
+	// simultaneously in two different workers. Work is queued in lanes, so
+	// that changes to a HelmRelease are processed before periodic syncs.
+	releaseWorkqueue *queue.PriorityQueue
+
+	// forceUpgrades holds the keys of the HelmReleases for which an
+	// upgrade has been requested through the API, and should be
+	// performed during their next sync.
+	forceUpgrades sync.Map
+
+	// syncObservers holds the observers of API requested syncs per
+	// HelmRelease key, that should be notified about the next sync.
+	syncObserversMu sync.Mutex
+	syncObservers   map[string][]api.SyncObserver
 
 	// recorder is an event recorder for recording Event resources to the
 	// Kubernetes API.
 	recorder record.EventRecorder
 }
 
+// NewEventRecorder returns an event recorder for recording Event
+// resources about HelmReleases to the Kubernetes API.
+func NewEventRecorder(kubeclientset kubernetes.Interface) record.EventRecorder {
+	// Add helm-operator types to the default Kubernetes Scheme so Events can be
+	// logged for helm-operator types.
+	ifscheme.AddToScheme(scheme.Scheme)
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: kubeclientset.CoreV1().Events("")})
+	return eventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: controllerAgentName})
+}
+
 // New returns a new helm-operator
 func New(
 	logger log.Logger,
 	logReleaseDiffs bool,
-	kubeclientset kubernetes.Interface,
+	syncInterval time.Duration,
+	backoff *queue.Backoff,
+	recorder record.EventRecorder,
+	hrClient v1client.HelmV1Interface,
 	hrInformer hrv1.HelmReleaseInformer,
-	releaseWorkqueue workqueue.RateLimitingInterface,
+	releaseWorkqueue *queue.PriorityQueue,
 	release *release.Release,
 	gitChartSync *chartsync.GitChartSync) *Controller {
 
-	// Add helm-operator types to the default Kubernetes Scheme so Events can be
-	// logged for helm-operator types.
-	ifscheme.AddToScheme(scheme.Scheme)
-	eventBroadcaster := record.NewBroadcaster()
-	eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: kubeclientset.CoreV1().Events("")})
-	recorder := eventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: controllerAgentName})
-
 	controller := &Controller{
 		logger:           logger,
 		logDiffs:         logReleaseDiffs,
+		syncInterval:     syncInterval,
+		backoff:          backoff,
+		hrClient:         hrClient,
 		hrLister:         hrInformer.Lister(),
 		hrSynced:         hrInformer.Informer().HasSynced,
 		releaseWorkqueue: releaseWorkqueue,
 		recorder:         recorder,
 		release:          release,
 		gitChartSync:     gitChartSync,
+		syncObservers:    make(map[string][]api.SyncObserver),
 	}
 
 	controller.logger.Log("info", "setting up event handlers")
@@ -92,7 +132,7 @@ func New(
 		AddFunc: func(new interface{}) {
 			if _, ok := checkCustomResourceType(controller.logger, new); ok {
 				releaseCount.Add(1)
-				controller.enqueueJob(new)
+				controller.enqueueJob(new, queue.LaneSpec)
 			}
 		},
 		UpdateFunc: func(old, new interface{}) {
@@ -116,42 +156,83 @@ func New(
 // Run starts workers handling the enqueued events. It will block until
 // stopCh is closed, at which point it will shutdown the workqueue and
 // wait for workers to finish processing their current work items.
-func (c *Controller) Run(threadiness int, stopCh <-chan struct{}, wg *sync.WaitGroup) {
+// Work items that are still being processed after the grace period
+// are cancelled, which marks their Helm releases as failed instead of
+// leaving them in a pending state.
+//
+// The given WaitGroup must be incremented by the caller before Run is
+// started, Run marks it as done once the workers have stopped, or
+// have been abandoned because they did not return within the
+// cancelTimeout after being cancelled.
+func (c *Controller) Run(threadiness int, gracePeriod time.Duration, stopCh <-chan struct{}, wg *sync.WaitGroup) {
 	defer runtime.HandleCrash()
-	defer c.releaseWorkqueue.ShutDown()
+	defer wg.Done()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 
 	c.logger.Log("info", "starting operator")
 
 	c.logger.Log("info", "starting workers")
+	workers := &sync.WaitGroup{}
 	for i := 0; i < threadiness; i++ {
-		wg.Add(1)
-		go wait.Until(c.runWorker, time.Second, stopCh)
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			wait.Until(func() { c.runWorker(ctx, stopCh) }, time.Second, stopCh)
+		}()
 	}
 
 	<-stopCh
-	for i := 0; i < threadiness; i++ {
-		wg.Done()
+	c.logger.Log("info", "stopping workers", "grace_period", gracePeriod)
+	c.releaseWorkqueue.ShutDown()
+
+	done := make(chan struct{})
+	go func() {
+		workers.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(gracePeriod):
+		c.logger.Log("warning", "grace period expired, cancelling in-flight releases")
+		cancel()
+		select {
+		case <-done:
+		case <-time.After(cancelTimeout):
+			// Not all operations can be cancelled, e.g. those of
+			// Helm 2.
+			c.logger.Log("warning", "workers did not stop after cancellation, abandoning in-flight releases", "timeout", cancelTimeout)
+			return
+		}
 	}
-	c.logger.Log("info", "stopping workers")
+	c.logger.Log("info", "workers stopped")
 }
 
 // runWorker is a long-running function calling the
 // processNextWorkItem function to read and process a message
-// on a workqueue.
-func (c *Controller) runWorker() {
-	for c.processNextWorkItem() {
+// on a workqueue, until stopCh is closed.
+func (c *Controller) runWorker(ctx context.Context, stopCh <-chan struct{}) {
+	for c.processNextWorkItem(ctx, stopCh) {
 	}
 }
 
 // processNextWorkItem will read a single work item off the workqueue and
-// attempt to process it, by calling the syncHandler.
-func (c *Controller) processNextWorkItem() bool {
+// attempt to process it, by calling the syncHandler. It does not start
+// processing new work items once stopCh is closed.
+func (c *Controller) processNextWorkItem(ctx context.Context, stopCh <-chan struct{}) bool {
 	releaseQueueLength.Set(float64(c.releaseWorkqueue.Len()))
 
 	obj, shutdown := c.releaseWorkqueue.Get()
 	if shutdown {
 		return false
 	}
+	select {
+	case <-stopCh:
+		c.releaseWorkqueue.Done(obj)
+		return false
+	default:
+	}
 
 	// wrapping block in a func to defer c.workqueue.Done
 	err := func(obj interface{}) error {
@@ -182,7 +263,7 @@ func (c *Controller) processNextWorkItem() bool {
 		// Run the syncHandler, passing it the namespace/name string of the
 		// HelmRelease resource to sync the corresponding Chart release.
 		// If the sync failed, then we return while the item will get requeued
-		if err := c.syncHandler(key); err != nil {
+		if err := c.syncHandler(ctx, key); err != nil {
 			return fmt.Errorf("errored syncing HelmRelease '%s': %s", key, err.Error())
 		}
 		// If no error occurs we Forget this item so it does not
@@ -200,19 +281,38 @@ func (c *Controller) processNextWorkItem() bool {
 
 // syncHandler acts according to the action
 // 		Deletes/creates or updates a Chart release
-func (c *Controller) syncHandler(key string) error {
+func (c *Controller) syncHandler(ctx context.Context, key string) error {
+	// Notify the observers waiting for this sync about the start,
+	// and the result once we return.
+	var syncErr error
+	observers := c.takeSyncObservers(key)
+	for _, o := range observers {
+		o.SyncStarted()
+	}
+	defer func() {
+		for _, o := range observers {
+			o.SyncFinished(syncErr)
+		}
+	}()
+
 	// Retrieve namespace and Custom Resource name from the key
 	namespace, name, err := cache.SplitMetaNamespaceKey(key)
 	if err != nil {
 		c.logger.Log("error", fmt.Sprintf("key '%s' is invalid: %v", key, err))
 		runtime.HandleError(fmt.Errorf("key '%s' is invalid", key))
+		syncErr = err
 		return nil
 	}
 
+	// Schedule the next sync of the release, regardless of the
+	// outcome of this one.
+	defer c.requeue(key)
+
 	// acquire lock
 	unlock, err := c.lock(fmt.Sprintf("%s-%s", namespace, name))
 	if err != nil {
 		c.logger.Log("info", fmt.Sprintf("could not obtain lock: %s", err))
+		syncErr = err
 		return nil
 	}
 	defer unlock()
@@ -223,22 +323,146 @@ func (c *Controller) syncHandler(key string) error {
 		if k8serrors.IsNotFound(err) {
 			c.logger.Log("info", fmt.Sprintf("HelmRelease '%s' referred to in work queue no longer exists", key))
 			runtime.HandleError(fmt.Errorf("HelmRelease '%s' referred to in work queue no longer exists", key))
+			syncErr = api.ErrReleaseNotFound
 			return nil
 		}
 		c.logger.Log("error", err.Error())
+		syncErr = err
 		return err
 	}
-	err = c.release.Sync(hr.DeepCopy())
-	if err != nil {
+	_, forceUpgrade := c.forceUpgrades.LoadAndDelete(key)
+	err = c.release.Sync(ctx, hr.DeepCopy(), release.SyncOptions{ForceUpgrade: forceUpgrade})
+	syncErr = err
+	if err != nil && ctx.Err() != nil {
+		// The operator is shutting down, the release is synced
+		// again once it is back.
 		c.recorder.Event(hr, corev1.EventTypeWarning, FailedReleaseSync,
-			fmt.Sprintf("synchronization of release '%s' in namespace '%s' failed: %s", hr.GetReleaseName(), hr.GetTargetNamespace(), err.Error()))
+			fmt.Sprintf("synchronization of release '%s' in namespace '%s' was cancelled due to shutdown: %s", hr.GetReleaseName(), hr.GetTargetNamespace(), err.Error()))
+	} else if errors.Is(err, release.ErrLimitReached) {
+		// The worker is freed up for other releases, instead of
+		// waiting until the concurrency limits allow this one to
+		// proceed. This is not a failure, so there is no backoff.
+		if forceUpgrade {
+			c.forceUpgrades.Store(key, struct{}{})
+		}
+		c.releaseWorkqueue.Add(key, queue.LanePeriodic)
+	} else if err != nil {
+		delay := c.retry(key, hr)
+		c.recorder.Event(hr, corev1.EventTypeWarning, FailedReleaseSync,
+			fmt.Sprintf("synchronization of release '%s' in namespace '%s' failed, retrying in %s: %s", hr.GetReleaseName(), hr.GetTargetNamespace(), delay, err.Error()))
 	} else {
+		c.backoff.Forget(key)
+		if err := status.SetRetry(c.hrClient.HelmReleases(hr.Namespace), hr, 0, nil); err != nil {
+			c.logger.Log("warning", "failed to clear retry status", "resource", hr.ResourceID().String(), "err", err)
+		}
 		c.recorder.Event(hr, corev1.EventTypeNormal, ReleaseSynced,
 			fmt.Sprintf("managed release '%s' in namespace '%s' synchronized", hr.GetReleaseName(), hr.GetTargetNamespace()))
 	}
 	return nil
 }
 
+// retry records a failed sync of the given HelmRelease, and adds it
+// to the workqueue after its backoff delay, which is returned. The
+// delay and the amount of consecutive failures are recorded in the
+// status of the HelmRelease.
+func (c *Controller) retry(key string, hr *helmfluxv1.HelmRelease) time.Duration {
+	delay := c.backoff.Next(key, hr.Spec.Backoff.GetBase(c.backoff.Base), hr.Spec.Backoff.GetMax(c.backoff.Max))
+	// The backoff replaces the requeue on the sync interval, which may
+	// be due earlier.
+	c.releaseWorkqueue.Reschedule(key, queue.LanePeriodic, delay)
+
+	nextRetryTime := metav1.NewTime(status.Clock.Now().Add(delay))
+	failures := int64(c.backoff.Failures(key))
+	if err := status.SetRetry(c.hrClient.HelmReleases(hr.Namespace), hr, failures, &nextRetryTime); err != nil {
+		c.logger.Log("warning", "failed to record retry status", "resource", hr.ResourceID().String(), "err", err)
+	}
+	return delay
+}
+
+// SyncMirrors instructs all git mirrors to sync from their respective
+// upstreams.
+func (c *Controller) SyncMirrors() error {
+	return c.gitChartSync.SyncMirrors()
+}
+
+// SyncMirrorsForRemote instructs the git mirrors for the repository
+// with the given (alternative) URLs to sync from their upstream.
+func (c *Controller) SyncMirrorsForRemote(urls ...string) error {
+	if err := c.gitChartSync.SyncMirrorsForRemote(urls...); err != nil {
+		if errors.Is(err, chartsync.ErrNoMirror) {
+			return api.ErrRemoteNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// SyncRelease refreshes the git mirror of the HelmRelease with the
+// given namespace and name (if it has a git chart source), and adds
+// it to the workqueue without rate limiting, so that it is synced
+// as soon as a worker becomes available.
+func (c *Controller) SyncRelease(namespace, name string, opts api.SyncReleaseOptions) error {
+	hr, err := c.hrLister.HelmReleases(namespace).Get(name)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return api.ErrReleaseNotFound
+		}
+		return err
+	}
+
+	// A missing mirror is not a problem, as it will be requested
+	// while the release is synced.
+	if hr.Spec.GitChartSource != nil {
+		if err := c.gitChartSync.SyncMirror(hr.DeepCopy()); err != nil && !errors.Is(err, chartsync.ErrNoMirror) {
+			return err
+		}
+	}
+
+	key, err := getCacheKey(hr)
+	if err != nil {
+		return err
+	}
+	if opts.ForceUpgrade {
+		c.forceUpgrades.Store(key, struct{}{})
+	}
+	if opts.NewObserver != nil {
+		c.syncObserversMu.Lock()
+		c.syncObservers[key] = append(c.syncObservers[key], opts.NewObserver())
+		c.syncObserversMu.Unlock()
+	}
+	c.releaseWorkqueue.Add(key, queue.LaneSpec)
+	releaseQueueLength.Set(float64(c.releaseWorkqueue.Len()))
+	return nil
+}
+
+// requeue adds the HelmRelease with the given key to the workqueue
+// after its interval, unless it no longer exists or its sync failed,
+// in which case it is retried after its backoff delay instead.
+func (c *Controller) requeue(key string) {
+	if c.backoff.Failures(key) > 0 {
+		return
+	}
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return
+	}
+	hr, err := c.hrLister.HelmReleases(namespace).Get(name)
+	if err != nil {
+		return
+	}
+	c.releaseWorkqueue.AddAfter(key, queue.LanePeriodic, hr.GetInterval(c.syncInterval))
+}
+
+// takeSyncObservers removes and returns the sync observers
+// registered for the given key.
+func (c *Controller) takeSyncObservers(key string) []api.SyncObserver {
+	c.syncObserversMu.Lock()
+	defer c.syncObserversMu.Unlock()
+	observers := c.syncObservers[key]
+	delete(c.syncObservers, key)
+	return observers
+}
+
 func (c *Controller) lock(name string) (unlock func(), err error) {
 	lockFile := path.Join(os.TempDir(), name+".lock")
 	mutex := lockedfile.MutexAt(lockFile)
@@ -246,14 +470,14 @@ func (c *Controller) lock(name string) (unlock func(), err error) {
 }
 
 // enqueueJob takes a HelmRelease resource and converts it into a namespace/name
-// string which is then put onto the work queue. This method should not be
-// passed resources of any type other than HelmRelease.
-func (c *Controller) enqueueJob(obj interface{}) {
+// string which is then put onto the given lane of the work queue. This method
+// should not be passed resources of any type other than HelmRelease.
+func (c *Controller) enqueueJob(obj interface{}, lane queue.Lane) {
 	key, err := getCacheKey(obj)
 	if err != nil {
 		return
 	}
-	c.releaseWorkqueue.AddRateLimited(key)
+	c.releaseWorkqueue.AddRateLimited(key, lane)
 	releaseQueueLength.Set(float64(c.releaseWorkqueue.Len()))
 }
 
@@ -268,13 +492,18 @@ func (c *Controller) enqueueUpdateJob(old, new interface{}) {
 		return
 	}
 
+	// Filter out the periodic resyncs of the informer, as every
+	// release is requeued on its own interval after it has been
+	// synced.
+	if oldHr.ResourceVersion == newHr.ResourceVersion {
+		return
+	}
+
 	diff := cmp.Diff(oldHr.Spec, newHr.Spec)
 
 	// Filter out any update notifications that are due to status
 	// updates, as the dry-run that determines if we should upgrade
-	// is expensive, but _without_ filtering out updates that are
-	// from the periodic refresh, as we still want to detect (and
-	// undo) mutations to Helm charts.
+	// is expensive.
 	if sDiff := cmp.Diff(oldHr.Status, newHr.Status); diff == "" && sDiff != "" {
 		return
 	}
@@ -287,7 +516,38 @@ func (c *Controller) enqueueUpdateJob(old, new interface{}) {
 		c.gitChartSync.SyncMirror(&newHr)
 	}
 
-	c.enqueueJob(new)
+	// Changes to the spec take precedence over other work, any other
+	// update (e.g. of the labels) is treated like a periodic sync.
+	// A spec change may resolve the failure of a release, and resets
+	// its backoff.
+	lane := queue.LanePeriodic
+	if diff != "" || oldHr.Generation != newHr.Generation {
+		lane = queue.LaneSpec
+		if key, err := getCacheKey(new); err == nil {
+			c.backoff.Forget(key)
+		}
+	}
+	c.enqueueJob(new, lane)
+}
+
+// ReleasePriority returns a `queue.PriorityFunc` which returns the
+// priority set in the spec of the HelmRelease for a queue key.
+func ReleasePriority(lister iflister.HelmReleaseLister) queue.PriorityFunc {
+	return func(item interface{}) int {
+		key, ok := item.(string)
+		if !ok {
+			return 0
+		}
+		namespace, name, err := cache.SplitMetaNamespaceKey(key)
+		if err != nil {
+			return 0
+		}
+		hr, err := lister.HelmReleases(namespace).Get(name)
+		if err != nil {
+			return 0
+		}
+		return int(hr.Spec.Priority)
+	}
 }
 
 func checkCustomResourceType(logger log.Logger, obj interface{}) (helmfluxv1.HelmRelease, bool) {
@@ -0,0 +1,74 @@
+package queue
+
+import (
+	"sync"
+	"time"
+)
+
+// Backoff tracks the consecutive failures of items, and returns
+// exponentially increasing delays for their retries.
+type Backoff struct {
+	// Base is the default delay before the first retry of an item.
+	Base time.Duration
+	// Max is the default maximum delay between retries of an item.
+	Max time.Duration
+
+	mu       sync.Mutex
+	failures map[interface{}]int
+}
+
+// NewBackoff returns a new Backoff with the given default base and
+// maximum delays.
+func NewBackoff(base, max time.Duration) *Backoff {
+	return &Backoff{
+		Base:     base,
+		Max:      max,
+		failures: make(map[interface{}]int),
+	}
+}
+
+// minBackoff is the minimum delay before the retry of an item, so
+// that a failing item is never retried immediately.
+const minBackoff = time.Second
+
+// Next records a failure of the given item, and returns the delay
+// before it should be retried. The delay starts at the given base,
+// and doubles on every consecutive failure until it reaches the given
+// maximum. A base below the minBackoff is raised to it, and a maximum
+// below the base to the base.
+func (b *Backoff) Next(item interface{}, base, max time.Duration) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures[item]++
+	exp := b.failures[item] - 1
+
+	if base < minBackoff {
+		base = minBackoff
+	}
+	if max < base {
+		max = base
+	}
+	delay := base
+	for i := 0; i < exp && delay < max; i++ {
+		delay *= 2
+	}
+	if delay > max {
+		return max
+	}
+	return delay
+}
+
+// Failures returns the number of consecutive failures recorded for
+// the given item.
+func (b *Backoff) Failures(item interface{}) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.failures[item]
+}
+
+// Forget resets the failures recorded for the given item.
+func (b *Backoff) Forget(item interface{}) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.failures, item)
+}
@@ -0,0 +1,45 @@
+package queue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackoff_Next(t *testing.T) {
+	b := NewBackoff(time.Second, time.Minute)
+
+	var delays []time.Duration
+	for i := 0; i < 8; i++ {
+		delays = append(delays, b.Next("a", 10*time.Second, 2*time.Minute))
+	}
+	assert.Equal(t, []time.Duration{
+		10 * time.Second,
+		20 * time.Second,
+		40 * time.Second,
+		80 * time.Second,
+		2 * time.Minute,
+		2 * time.Minute,
+		2 * time.Minute,
+		2 * time.Minute,
+	}, delays)
+	assert.Equal(t, 8, b.Failures("a"))
+	assert.Equal(t, 0, b.Failures("b"))
+
+	b.Forget("a")
+	assert.Equal(t, 0, b.Failures("a"))
+	assert.Equal(t, 10*time.Second, b.Next("a", 10*time.Second, 2*time.Minute))
+}
+
+func TestBackoff_NextBounds(t *testing.T) {
+	b := NewBackoff(0, 0)
+
+	// A zero base and maximum never result in an immediate retry.
+	assert.Equal(t, minBackoff, b.Next("a", 0, 0))
+	assert.Equal(t, minBackoff, b.Next("a", -time.Second, 0))
+
+	// An inverted range is capped at the base.
+	assert.Equal(t, 10*time.Second, b.Next("b", 10*time.Second, time.Second))
+	assert.Equal(t, 10*time.Second, b.Next("b", 10*time.Second, time.Second))
+}
@@ -0,0 +1,26 @@
+package queue
+
+import (
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	LabelLane = "lane"
+)
+
+var (
+	laneLength = prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+		Namespace: "flux",
+		Subsystem: "helm_operator",
+		Name:      "release_queue_lane_length_count",
+		Help:      "Count of release jobs waiting in a lane of the queue to be processed.",
+	}, []string{LabelLane})
+	laneWaitDuration = prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+		Namespace: "flux",
+		Subsystem: "helm_operator",
+		Name:      "release_queue_wait_duration_seconds",
+		Help:      "Duration in seconds release jobs waited in a lane of the queue before being processed.",
+		Buckets:   []float64{0.1, 0.5, 1, 5, 10, 30, 60, 120, 300, 600, 1800},
+	}, []string{LabelLane})
+)
@@ -0,0 +1,372 @@
+// Package queue provides the work queue for the reconciliation of
+// HelmReleases, which processes items in priority lanes.
+package queue
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+)
+
+// Lane is the lane of the queue an item is added to. Items in a lane
+// are only processed when all lanes before it are empty.
+type Lane int
+
+const (
+	// LaneSpec is the lane for changes to the resources themselves,
+	// e.g. a change of the spec, and syncs requested by a human.
+	LaneSpec Lane = iota
+	// LaneSource is the lane for changes to the sources of the
+	// resources, e.g. new commits in a Git repository.
+	LaneSource
+	// LanePeriodic is the lane for the periodic reconciliation of
+	// the resources, e.g. to detect and correct drift.
+	LanePeriodic
+
+	numLanes
+)
+
+func (l Lane) String() string {
+	switch l {
+	case LaneSpec:
+		return "spec"
+	case LaneSource:
+		return "source"
+	case LanePeriodic:
+		return "periodic"
+	}
+	return "unknown"
+}
+
+// PriorityFunc returns the priority of the given item within its
+// lane. Items with a higher priority are processed first, items with
+// the same priority in the order they were added.
+type PriorityFunc func(item interface{}) int
+
+// PriorityQueue is a rate limited work queue with priority lanes.
+// Like a `workqueue.RateLimitingInterface`, it guarantees an item is
+// only processed by one worker at a time, and an item that is added
+// multiple times before it is processed is only processed once (in
+// the first lane it was added to).
+type PriorityQueue struct {
+	rateLimiter workqueue.RateLimiter
+	priority    PriorityFunc
+
+	mu           sync.Mutex
+	cond         *sync.Cond
+	lanes        [numLanes]laneHeap
+	queued       map[interface{}]*entry
+	processing   map[interface{}]struct{}
+	dirty        map[interface{}]*entry
+	waiting      map[interface{}]*waitingEntry
+	seq          uint64
+	shuttingDown bool
+}
+
+// entry is an item in a lane.
+type entry struct {
+	item     interface{}
+	lane     Lane
+	priority int
+	seq      uint64
+	added    time.Time
+	index    int
+}
+
+// waitingEntry is an item waiting to be added to a lane.
+type waitingEntry struct {
+	timer   *time.Timer
+	readyAt time.Time
+	lane    Lane
+}
+
+// New returns a new PriorityQueue, which uses the given rate limiter
+// for `AddRateLimited`, and the given function to determine the
+// priority of items within their lane.
+func New(rateLimiter workqueue.RateLimiter, priority PriorityFunc) *PriorityQueue {
+	q := &PriorityQueue{
+		rateLimiter: rateLimiter,
+		priority:    priority,
+		queued:      make(map[interface{}]*entry),
+		processing:  make(map[interface{}]struct{}),
+		dirty:       make(map[interface{}]*entry),
+		waiting:     make(map[interface{}]*waitingEntry),
+	}
+	q.cond = sync.NewCond(&q.mu)
+	return q
+}
+
+// Add adds the given item to the given lane. If the item is already
+// queued in a later lane, it is moved to the given lane.
+func (q *PriorityQueue) Add(item interface{}, lane Lane) {
+	priority := q.priority(item)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.shuttingDown {
+		return
+	}
+
+	if _, ok := q.processing[item]; ok {
+		// The item is added again once it has been processed.
+		if e, ok := q.dirty[item]; ok {
+			e.lane = minLane(e.lane, lane)
+			e.priority = priority
+			return
+		}
+		q.dirty[item] = &entry{item: item, lane: lane, priority: priority}
+		return
+	}
+
+	if e, ok := q.queued[item]; ok {
+		e.priority = priority
+		if lane < e.lane {
+			heap.Remove(&q.lanes[e.lane], e.index)
+			q.observeDepth(e.lane)
+			e.lane = lane
+			heap.Push(&q.lanes[lane], e)
+		} else {
+			heap.Fix(&q.lanes[e.lane], e.index)
+		}
+		q.observeDepth(e.lane)
+		return
+	}
+
+	q.push(&entry{item: item, lane: lane, priority: priority})
+}
+
+// AddAfter adds the given item to the given lane after the given
+// duration. If the item is already waiting to be added, it is added
+// at the earliest of both times, to the first of both lanes.
+func (q *PriorityQueue) AddAfter(item interface{}, lane Lane, duration time.Duration) {
+	q.addAfter(item, lane, duration, false)
+}
+
+// Reschedule adds the given item to the given lane after the given
+// duration, replacing any addition of the item that is already
+// waiting, even if it was due earlier.
+func (q *PriorityQueue) Reschedule(item interface{}, lane Lane, duration time.Duration) {
+	q.addAfter(item, lane, duration, true)
+}
+
+func (q *PriorityQueue) addAfter(item interface{}, lane Lane, duration time.Duration, replace bool) {
+	q.mu.Lock()
+	if q.shuttingDown {
+		q.mu.Unlock()
+		return
+	}
+
+	readyAt := time.Now().Add(duration)
+	if w, ok := q.waiting[item]; ok {
+		if !replace {
+			lane = minLane(w.lane, lane)
+			if !readyAt.Before(w.readyAt) {
+				w.lane = lane
+				q.mu.Unlock()
+				return
+			}
+		}
+		w.timer.Stop()
+		delete(q.waiting, item)
+	}
+	if duration <= 0 {
+		q.mu.Unlock()
+		q.Add(item, lane)
+		return
+	}
+
+	w := &waitingEntry{readyAt: readyAt, lane: lane}
+	w.timer = time.AfterFunc(duration, func() {
+		q.mu.Lock()
+		if q.waiting[item] != w {
+			// Replaced by another addition.
+			q.mu.Unlock()
+			return
+		}
+		delete(q.waiting, item)
+		q.mu.Unlock()
+		q.Add(item, w.lane)
+	})
+	q.waiting[item] = w
+	q.mu.Unlock()
+}
+
+// AddRateLimited adds the given item to the given lane after the rate
+// limiter says it is ok.
+func (q *PriorityQueue) AddRateLimited(item interface{}, lane Lane) {
+	q.AddAfter(item, lane, q.rateLimiter.When(item))
+}
+
+// Forget indicates that an item is finished being retried.
+func (q *PriorityQueue) Forget(item interface{}) {
+	q.rateLimiter.Forget(item)
+}
+
+// NumRequeues returns back how many times the item was requeued.
+func (q *PriorityQueue) NumRequeues(item interface{}) int {
+	return q.rateLimiter.NumRequeues(item)
+}
+
+// Get blocks until it can return an item to be processed, taking the
+// item with the highest priority from the first non-empty lane. If
+// shutdown is true, the caller should end their goroutine. Done must
+// be called with the item once it has been processed.
+func (q *PriorityQueue) Get() (item interface{}, shutdown bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for q.len() == 0 && !q.shuttingDown {
+		q.cond.Wait()
+	}
+	if q.len() == 0 {
+		return nil, true
+	}
+
+	for lane := range q.lanes {
+		if q.lanes[lane].Len() == 0 {
+			continue
+		}
+		e := heap.Pop(&q.lanes[lane]).(*entry)
+		delete(q.queued, e.item)
+		q.processing[e.item] = struct{}{}
+		q.observeDepth(e.lane)
+		laneWaitDuration.With(LabelLane, e.lane.String()).Observe(time.Since(e.added).Seconds())
+		return e.item, false
+	}
+	return nil, false // unreachable
+}
+
+// Done marks the given item as done processing. If it has been added
+// again while it was being processed, it is queued again.
+func (q *PriorityQueue) Done(item interface{}) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	delete(q.processing, item)
+	if e, ok := q.dirty[item]; ok {
+		delete(q.dirty, item)
+		if !q.shuttingDown {
+			q.push(e)
+		}
+	}
+}
+
+// Len returns the number of items queued in all lanes.
+func (q *PriorityQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.len()
+}
+
+// ShutDown makes the queue ignore all items added from now on, and
+// makes the workers return once the queue has been drained.
+func (q *PriorityQueue) ShutDown() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.shuttingDown = true
+	for item, w := range q.waiting {
+		w.timer.Stop()
+		delete(q.waiting, item)
+	}
+	q.cond.Broadcast()
+}
+
+// ShuttingDown returns true if the queue is shutting down.
+func (q *PriorityQueue) ShuttingDown() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.shuttingDown
+}
+
+// Lane returns a view of the queue which adds items to the given
+// lane, for consumers that are not aware of lanes.
+func (q *PriorityQueue) Lane(lane Lane) LaneQueue {
+	return LaneQueue{queue: q, lane: lane}
+}
+
+// push queues the given entry in its lane. It must be called with
+// the lock held.
+func (q *PriorityQueue) push(e *entry) {
+	q.seq++
+	e.seq = q.seq
+	e.added = time.Now()
+	q.queued[e.item] = e
+	heap.Push(&q.lanes[e.lane], e)
+	q.observeDepth(e.lane)
+	q.cond.Signal()
+}
+
+// len returns the number of items queued in all lanes. It must be
+// called with the lock held.
+func (q *PriorityQueue) len() int {
+	return len(q.queued)
+}
+
+// observeDepth records the number of items queued in the given lane.
+// It must be called with the lock held.
+func (q *PriorityQueue) observeDepth(lane Lane) {
+	laneLength.With(LabelLane, lane.String()).Set(float64(q.lanes[lane].Len()))
+}
+
+// LaneQueue adds items to a single lane of a PriorityQueue.
+type LaneQueue struct {
+	queue *PriorityQueue
+	lane  Lane
+}
+
+// Add adds the given item to the lane.
+func (l LaneQueue) Add(item interface{}) {
+	l.queue.Add(item, l.lane)
+}
+
+// AddAfter adds the given item to the lane after the given duration.
+func (l LaneQueue) AddAfter(item interface{}, duration time.Duration) {
+	l.queue.AddAfter(item, l.lane, duration)
+}
+
+// AddRateLimited adds the given item to the lane after the rate
+// limiter says it is ok.
+func (l LaneQueue) AddRateLimited(item interface{}) {
+	l.queue.AddRateLimited(item, l.lane)
+}
+
+// laneHeap orders the entries in a lane by priority, and the order
+// in which they were added.
+type laneHeap []*entry
+
+func (h laneHeap) Len() int { return len(h) }
+
+func (h laneHeap) Less(i, j int) bool {
+	if h[i].priority != h[j].priority {
+		return h[i].priority > h[j].priority
+	}
+	return h[i].seq < h[j].seq
+}
+
+func (h laneHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *laneHeap) Push(x interface{}) {
+	e := x.(*entry)
+	e.index = len(*h)
+	*h = append(*h, e)
+}
+
+func (h *laneHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	e := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return e
+}
+
+func minLane(a, b Lane) Lane {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,115 @@
+package queue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/util/workqueue"
+)
+
+func newTestQueue(priorities map[string]int) *PriorityQueue {
+	return New(workqueue.DefaultControllerRateLimiter(), func(item interface{}) int {
+		return priorities[item.(string)]
+	})
+}
+
+func drain(q *PriorityQueue) []string {
+	var items []string
+	for q.Len() > 0 {
+		item, _ := q.Get()
+		q.Done(item)
+		items = append(items, item.(string))
+	}
+	return items
+}
+
+func TestPriorityQueue_Order(t *testing.T) {
+	q := newTestQueue(map[string]int{"platform": 10})
+	defer q.ShutDown()
+
+	q.Add("periodic", LanePeriodic)
+	q.Add("source", LaneSource)
+	q.Add("spec-a", LaneSpec)
+	q.Add("spec-b", LaneSpec)
+	q.Add("platform", LaneSpec)
+
+	assert.Equal(t, []string{"platform", "spec-a", "spec-b", "source", "periodic"}, drain(q))
+}
+
+func TestPriorityQueue_AddMovesToEarlierLane(t *testing.T) {
+	q := newTestQueue(nil)
+	defer q.ShutDown()
+
+	q.Add("a", LanePeriodic)
+	q.Add("b", LaneSource)
+	q.Add("a", LaneSpec)
+	q.Add("b", LanePeriodic)
+
+	assert.Equal(t, 2, q.Len())
+	assert.Equal(t, []string{"a", "b"}, drain(q))
+}
+
+func TestPriorityQueue_AddWhileProcessing(t *testing.T) {
+	q := newTestQueue(nil)
+	defer q.ShutDown()
+
+	q.Add("a", LanePeriodic)
+	item, _ := q.Get()
+	q.Add("a", LanePeriodic)
+	q.Add("a", LaneSpec)
+	q.Add("b", LaneSource)
+	assert.Equal(t, 1, q.Len())
+
+	q.Done(item)
+	assert.Equal(t, []string{"a", "b"}, drain(q))
+}
+
+func TestPriorityQueue_AddAfter(t *testing.T) {
+	q := newTestQueue(nil)
+	defer q.ShutDown()
+
+	q.AddAfter("a", LanePeriodic, time.Hour)
+	q.AddAfter("a", LaneSource, 10*time.Millisecond)
+	q.AddAfter("a", LaneSpec, time.Hour)
+
+	item, shutdown := q.Get()
+	assert.False(t, shutdown)
+	assert.Equal(t, "a", item)
+	q.Done(item)
+	assert.Equal(t, 0, q.Len())
+}
+
+func TestPriorityQueue_Reschedule(t *testing.T) {
+	q := newTestQueue(nil)
+	defer q.ShutDown()
+
+	// A pending requeue on the sync interval is replaced by a longer
+	// backoff.
+	q.AddAfter("a", LanePeriodic, 10*time.Millisecond)
+	q.Reschedule("a", LanePeriodic, time.Hour)
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, 0, q.Len())
+
+	q.Reschedule("a", LanePeriodic, 10*time.Millisecond)
+	item, shutdown := q.Get()
+	assert.False(t, shutdown)
+	assert.Equal(t, "a", item)
+	q.Done(item)
+	assert.Equal(t, 0, q.Len())
+}
+
+func TestPriorityQueue_ShutDown(t *testing.T) {
+	q := newTestQueue(nil)
+	q.Add("a", LaneSpec)
+	q.ShutDown()
+	q.Add("b", LaneSpec)
+
+	item, shutdown := q.Get()
+	assert.False(t, shutdown)
+	assert.Equal(t, "a", item)
+	q.Done(item)
+
+	_, shutdown = q.Get()
+	assert.True(t, shutdown)
+}
@@ -0,0 +1,142 @@
+package release
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	apiV1 "github.com/fluxcd/helm-operator/pkg/apis/helm.fluxcd.io/v1"
+)
+
+// Limits holds the maximum amount of concurrent installs and upgrades
+// of Helm releases.
+type Limits struct {
+	// PerNamespace is the maximum amount of concurrent installs and
+	// upgrades in a target namespace, zero means unlimited.
+	PerNamespace int
+	// PerChart holds the maximum amount of concurrent installs and
+	// upgrades per chart name. Charts that are not listed are
+	// unlimited.
+	PerChart map[string]int
+}
+
+// ParseChartLimits parses the given `<chart>=<limit>` pairs into a map
+// of limits per chart name.
+func ParseChartLimits(pairs []string) (map[string]int, error) {
+	limits := make(map[string]int, len(pairs))
+	for _, p := range pairs {
+		parts := strings.SplitN(p, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("invalid chart limit %q, expected format is <chart>=<limit>", p)
+		}
+		limit, err := strconv.Atoi(parts[1])
+		if err != nil || limit < 1 {
+			return nil, fmt.Errorf("invalid chart limit %q, limit must be a positive number", p)
+		}
+		limits[parts[0]] = limit
+	}
+	return limits, nil
+}
+
+// limitWaitTimeout is the maximum duration an install or upgrade waits
+// for a slot, after which the release is requeued so that the worker
+// is freed up for other releases.
+const limitWaitTimeout = 30 * time.Second
+
+// ErrLimitReached is returned when no slot became available for an
+// install or upgrade within the limitWaitTimeout.
+var ErrLimitReached = errors.New("timed out waiting for concurrency limit")
+
+// limiter enforces the configured limits by blocking installs and
+// upgrades until a slot is available in both the target namespace and
+// for the chart of the release.
+type limiter struct {
+	limits Limits
+
+	mu         sync.Mutex
+	released   chan struct{}
+	namespaces map[string]int
+	charts     map[string]int
+}
+
+func newLimiter(limits Limits) *limiter {
+	return &limiter{
+		limits:     limits,
+		released:   make(chan struct{}),
+		namespaces: make(map[string]int),
+		charts:     make(map[string]int),
+	}
+}
+
+// acquire blocks until a slot is available for the given target
+// namespace and chart name, and returns the function to release it,
+// or until the given context is done, and returns its error. The
+// given function is called once if it has to wait.
+func (l *limiter) acquire(ctx context.Context, namespace, chart string, waiting func()) (release func(), err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.available(namespace, chart) {
+		waiting()
+		concurrencyWaiting.Add(1)
+		defer concurrencyWaiting.Add(-1)
+		for !l.available(namespace, chart) {
+			released := l.released
+			l.mu.Unlock()
+			select {
+			case <-released:
+			case <-ctx.Done():
+				l.mu.Lock()
+				return nil, ctx.Err()
+			}
+			l.mu.Lock()
+		}
+	}
+	l.namespaces[namespace]++
+	l.charts[chart]++
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			l.mu.Lock()
+			defer l.mu.Unlock()
+			l.namespaces[namespace]--
+			l.charts[chart]--
+			// Wake up all waiters, and start over for the next
+			// release.
+			close(l.released)
+			l.released = make(chan struct{})
+		})
+	}, nil
+}
+
+// available returns if a slot is available for the given target
+// namespace and chart name. It must be called with the lock held.
+func (l *limiter) available(namespace, chart string) bool {
+	if max := l.limits.PerNamespace; max > 0 && l.namespaces[namespace] >= max {
+		return false
+	}
+	if max, ok := l.limits.PerChart[chart]; ok && chart != "" && l.charts[chart] >= max {
+		return false
+	}
+	return true
+}
+
+// chartName returns the name of the chart of the given HelmRelease
+// the chart limits apply to: the name of a chart from a Helm
+// repository, or the last element of the path of a chart from Git.
+// Charts from other sources have no name.
+func chartName(hr *apiV1.HelmRelease) string {
+	switch {
+	case hr.Spec.RepoChartSource != nil:
+		return hr.Spec.RepoChartSource.Name
+	case hr.Spec.GitChartSource != nil:
+		return path.Base(path.Clean(hr.Spec.GitChartSource.Path))
+	}
+	return ""
+}
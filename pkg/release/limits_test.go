@@ -0,0 +1,120 @@
+package release
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apiV1 "github.com/fluxcd/helm-operator/pkg/apis/helm.fluxcd.io/v1"
+)
+
+func TestParseChartLimits(t *testing.T) {
+	limits, err := ParseChartLimits([]string{"nginx=2", "podinfo=1"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"nginx": 2, "podinfo": 1}, limits)
+
+	for _, p := range []string{"nginx", "=1", "nginx=0", "nginx=one"} {
+		_, err := ParseChartLimits([]string{p})
+		assert.Error(t, err, p)
+	}
+}
+
+func TestLimiter(t *testing.T) {
+	l := newLimiter(Limits{PerNamespace: 1, PerChart: map[string]int{"nginx": 2}})
+	acquire := func(namespace, chart string, waiting func()) func() {
+		release, err := l.acquire(context.Background(), namespace, chart, waiting)
+		assert.NoError(t, err)
+		return release
+	}
+	noWait := func() { t.Error("unexpected wait") }
+
+	releaseA := acquire("a", "nginx", noWait)
+	releaseB := acquire("b", "nginx", noWait)
+	releaseC := acquire("c", "podinfo", noWait)
+	acquire("d", "", noWait)()
+
+	acquired := make(chan string, 2)
+	waited := make(chan struct{}, 2)
+	wait := func() { waited <- struct{}{} }
+	go func() {
+		acquire("c", "podinfo", wait)()
+		acquired <- "c"
+	}()
+	go func() {
+		acquire("d", "nginx", wait)()
+		acquired <- "d"
+	}()
+	<-waited
+	<-waited
+
+	releaseC()
+	assert.Equal(t, "c", <-acquired)
+	select {
+	case <-acquired:
+		t.Fatal("chart limit exceeded")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	releaseA()
+	assert.Equal(t, "d", <-acquired)
+	releaseB()
+}
+
+func TestLimiter_Cancel(t *testing.T) {
+	l := newLimiter(Limits{PerNamespace: 1})
+	release, err := l.acquire(context.Background(), "a", "", func() {})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = l.acquire(ctx, "a", "", func() {})
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+
+	// The slot is still held by the first acquisition only.
+	release()
+	release, err = l.acquire(context.Background(), "a", "", func() { t.Error("unexpected wait") })
+	require.NoError(t, err)
+	release()
+}
+
+func TestBeforeAction(t *testing.T) {
+	r := &Release{limiter: newLimiter(Limits{PerNamespace: 1})}
+	hr := &apiV1.HelmRelease{}
+	logger := log.NewNopLogger()
+
+	var slot func()
+	assert.NoError(t, r.beforeAction(context.Background(), logger, hr, DryRunCompareAction, &slot))
+	assert.Nil(t, slot)
+	assert.NoError(t, r.beforeAction(context.Background(), logger, hr, UpgradeAction, &slot))
+	assert.NotNil(t, slot)
+	// The slot is held for the rest of the release cycle.
+	assert.NoError(t, r.beforeAction(context.Background(), logger, hr, RollbackAction, &slot))
+	assert.NoError(t, r.beforeAction(context.Background(), logger, hr, UpgradeAction, &slot))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var other func()
+	err := r.beforeAction(ctx, logger, hr, InstallAction, &other)
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Nil(t, other)
+	slot()
+}
+
+func TestChartName(t *testing.T) {
+	repo := &apiV1.HelmRelease{Spec: apiV1.HelmReleaseSpec{ChartSource: apiV1.ChartSource{
+		RepoChartSource: &apiV1.RepoChartSource{Name: "nginx"},
+	}}}
+	assert.Equal(t, "nginx", chartName(repo))
+
+	git := &apiV1.HelmRelease{Spec: apiV1.HelmReleaseSpec{ChartSource: apiV1.ChartSource{
+		GitChartSource: &apiV1.GitChartSource{Path: "charts/podinfo/"},
+	}}}
+	assert.Equal(t, "podinfo", chartName(git))
+
+	assert.Equal(t, "", chartName(&apiV1.HelmRelease{}))
+}
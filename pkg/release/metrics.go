@@ -43,6 +43,19 @@ var (
 		ConstLabels: nil,
 		Buckets:     durationBuckets,
 	}, []string{LabelAction, LabelSuccess, LabelTargetNamespace, LabelReleaseName})
+	dependencyUpdateDuration = prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+		Namespace: "flux",
+		Subsystem: "helm_operator",
+		Name:      "dependency_update_duration_seconds",
+		Help:      "Duration of updating the dependencies of a chart in seconds.",
+		Buckets:   []float64{0.5, 1, 5, 10, 30, 60, 120, 300},
+	}, []string{LabelSuccess})
+	concurrencyWaiting = prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+		Namespace: "flux",
+		Subsystem: "helm_operator",
+		Name:      "release_concurrency_waiting_count",
+		Help:      "Count of installs and upgrades waiting for a concurrency limit.",
+	}, []string{})
 	syncAction = "sync"
 )
 
@@ -74,3 +87,9 @@ func ObserveReleaseAction(start time.Time, action action, success bool, namespac
 		LabelReleaseName, releaseName,
 	).Observe(time.Since(start).Seconds())
 }
+
+func ObserveDependencyUpdate(start time.Time, success bool) {
+	dependencyUpdateDuration.With(
+		LabelSuccess, fmt.Sprint(success),
+	).Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,131 @@
+package release
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiV1 "github.com/fluxcd/helm-operator/pkg/apis/helm.fluxcd.io/v1"
+	"github.com/fluxcd/helm-operator/pkg/helm"
+	"github.com/fluxcd/helm-operator/pkg/status"
+)
+
+// Reasons of the Recovered condition and events.
+const (
+	ReasonPendingRolledBack   = "PendingRolledBack"
+	ReasonPendingMarkedFailed = "PendingMarkedFailed"
+	ReasonRecoveryFailed      = "RecoveryFailed"
+)
+
+// isPending returns if the given status is one of the pending states
+// Helm leaves a release in while an operation is underway.
+func isPending(s helm.Status) bool {
+	switch s {
+	case helm.StatusPendingInstall, helm.StatusPendingUpgrade, helm.StatusPendingRollback:
+		return true
+	}
+	return false
+}
+
+// shouldRecover returns if the given release of the given HelmRelease
+// is stuck in a pending state for longer than the configured recovery
+// age. A release is never considered stuck before the timeouts of the
+// operations that may have left it pending have passed, so that an
+// install, upgrade or rollback that is still running is not recovered.
+func (r *Release) shouldRecover(hr *apiV1.HelmRelease, rel *helm.Release) bool {
+	if r.config.RecoverPendingAfter <= 0 || !isPending(rel.Info.Status) {
+		return false
+	}
+	after := r.config.RecoverPendingAfter
+	for _, timeout := range []time.Duration{hr.GetTimeout(), hr.Spec.Rollback.GetTimeout()} {
+		if timeout > after {
+			after = timeout
+		}
+	}
+	return status.Clock.Since(rel.Info.LastDeployed) > after
+}
+
+// recover recovers the given release from a stale pending state, by
+// rolling it back to the last deployed revision, or by marking the
+// pending revision as failed if there is no such revision. The outcome
+// is recorded in the Recovered condition and an event.
+func (r *Release) recover(client helm.Client, hr *apiV1.HelmRelease, curRel *helm.Release) (rel *helm.Release, err error) {
+	defer func(start time.Time) {
+		ObserveReleaseAction(start, RecoverAction, err == nil, hr.GetTargetNamespace(), hr.GetReleaseName())
+	}(time.Now())
+
+	stuck := fmt.Sprintf("Helm release '%s' in '%s' was stuck in %s at revision %d since %s",
+		hr.GetReleaseName(), hr.GetTargetNamespace(), curRel.Info.Status, curRel.Version,
+		curRel.Info.LastDeployed.UTC().Format(time.RFC3339))
+
+	target, err := lastDeployed(client, hr, curRel)
+	var reason, message string
+	switch {
+	case err != nil:
+	case target != nil:
+		rel, err = client.Rollback(hr.GetReleaseName(), helm.RollbackOptions{
+			Namespace:     hr.GetTargetNamespace(),
+			Version:       target.Version,
+			Timeout:       hr.Spec.Rollback.GetTimeout(),
+			Wait:          hr.Spec.Rollback.Wait,
+			DisableHooks:  hr.Spec.Rollback.DisableHooks,
+			Recreate:      hr.Spec.Rollback.Recreate,
+			Force:         hr.Spec.Rollback.Force,
+			StorageDriver: hr.Spec.StorageDriver,
+		})
+		reason = ReasonPendingRolledBack
+		message = fmt.Sprintf("%s, rolled back to revision %d.", stuck, target.Version)
+	default:
+		err = client.MarkFailed(hr.GetReleaseName(), helm.MarkFailedOptions{
+			Namespace:     hr.GetTargetNamespace(),
+			Version:       curRel.Version,
+			Description:   fmt.Sprintf("Marked as failed by the Helm Operator after being stuck in %s", curRel.Info.Status),
+			StorageDriver: hr.Spec.StorageDriver,
+		})
+		reason = ReasonPendingMarkedFailed
+		message = fmt.Sprintf("%s, marked revision %d as failed.", stuck, curRel.Version)
+	}
+
+	now := metav1.NewTime(status.Clock.Now())
+	condition := apiV1.HelmReleaseCondition{
+		Type:               apiV1.HelmReleaseRecovered,
+		Status:             apiV1.ConditionTrue,
+		Reason:             reason,
+		Message:            message,
+		LastUpdateTime:     &now,
+		LastTransitionTime: &now,
+	}
+	eventType := corev1.EventTypeNormal
+	if err != nil {
+		err = fmt.Errorf("failed to recover release stuck in %s: %w", curRel.Info.Status, err)
+		condition.Status = apiV1.ConditionFalse
+		condition.Reason = ReasonRecoveryFailed
+		condition.Message = fmt.Sprintf("%s, recovery failed: %s", stuck, err)
+		eventType = corev1.EventTypeWarning
+	}
+	status.SetCondition(r.hrClient.HelmReleases(hr.Namespace), hr, condition)
+	r.recorder.Event(hr, eventType, condition.Reason, condition.Message)
+	return
+}
+
+// lastDeployed returns the latest revision of the given release that
+// was deployed successfully before the current one, or nil. The full
+// history is consulted, as the deployed revision may be followed by
+// more failed revisions than the configured maximum history.
+func lastDeployed(client helm.Client, hr *apiV1.HelmRelease, curRel *helm.Release) (*helm.Release, error) {
+	hist, err := client.History(hr.GetReleaseName(), helm.HistoryOptions{Namespace: hr.GetTargetNamespace(), StorageDriver: hr.Spec.StorageDriver})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve history of release: %w", err)
+	}
+	for _, rel := range hist {
+		if rel.Version >= curRel.Version {
+			continue
+		}
+		if rel.Info.Status == helm.StatusDeployed || rel.Info.Status == helm.StatusSuperseded {
+			return rel, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,125 @@
+package release
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	apiV1 "github.com/fluxcd/helm-operator/pkg/apis/helm.fluxcd.io/v1"
+	"github.com/fluxcd/helm-operator/pkg/client/clientset/versioned/fake"
+	"github.com/fluxcd/helm-operator/pkg/helm"
+	"github.com/fluxcd/helm-operator/pkg/status"
+)
+
+// recoveryClient records the recovery operations performed on it.
+type recoveryClient struct {
+	helm.Client
+	history    []*helm.Release
+	historyMax int
+	rolledBack int
+	markedFail int
+}
+
+func (c *recoveryClient) History(releaseName string, opts helm.HistoryOptions) ([]*helm.Release, error) {
+	c.historyMax = opts.Max
+	return c.history, nil
+}
+
+func (c *recoveryClient) Rollback(releaseName string, opts helm.RollbackOptions) (*helm.Release, error) {
+	c.rolledBack = opts.Version
+	return c.history[len(c.history)-1], nil
+}
+
+func (c *recoveryClient) MarkFailed(releaseName string, opts helm.MarkFailedOptions) error {
+	c.markedFail = opts.Version
+	return nil
+}
+
+func pendingRelease(version int, s helm.Status, age time.Duration) *helm.Release {
+	return &helm.Release{
+		Name:    "podinfo",
+		Version: version,
+		Info:    &helm.Info{Status: s, LastDeployed: time.Now().Add(-age)},
+	}
+}
+
+func TestShouldRecover(t *testing.T) {
+	r := &Release{config: Config{RecoverPendingAfter: 10 * time.Minute}}
+	hr := &apiV1.HelmRelease{}
+	assert.True(t, r.shouldRecover(hr, pendingRelease(2, helm.StatusPendingUpgrade, time.Hour)))
+	assert.True(t, r.shouldRecover(hr, pendingRelease(1, helm.StatusPendingInstall, time.Hour)))
+	assert.False(t, r.shouldRecover(hr, pendingRelease(2, helm.StatusPendingUpgrade, time.Minute)))
+	assert.False(t, r.shouldRecover(hr, pendingRelease(2, helm.StatusFailed, time.Hour)))
+
+	// A release is not recovered before the timeouts of the release
+	// and its rollback have passed.
+	timeout, rollbackTimeout := int64(3600), int64(7200)
+	hr.Spec.Timeout = &timeout
+	assert.False(t, r.shouldRecover(hr, pendingRelease(2, helm.StatusPendingUpgrade, 30*time.Minute)))
+	assert.True(t, r.shouldRecover(hr, pendingRelease(2, helm.StatusPendingUpgrade, 90*time.Minute)))
+	hr.Spec.Rollback.Timeout = &rollbackTimeout
+	assert.False(t, r.shouldRecover(hr, pendingRelease(2, helm.StatusPendingRollback, 90*time.Minute)))
+	assert.True(t, r.shouldRecover(hr, pendingRelease(2, helm.StatusPendingRollback, 3*time.Hour)))
+
+	r.config.RecoverPendingAfter = 0
+	assert.False(t, r.shouldRecover(hr, pendingRelease(2, helm.StatusPendingUpgrade, 3*time.Hour)))
+}
+
+func TestRecover(t *testing.T) {
+	testCases := []struct {
+		name           string
+		history        []*helm.Release
+		expectedReason string
+		rolledBack     int
+		markedFailed   int
+	}{
+		{
+			name: "rollback to last deployed revision",
+			history: []*helm.Release{
+				pendingRelease(3, helm.StatusPendingUpgrade, time.Hour),
+				pendingRelease(2, helm.StatusDeployed, 2*time.Hour),
+				pendingRelease(1, helm.StatusSuperseded, 3*time.Hour),
+			},
+			expectedReason: ReasonPendingRolledBack,
+			rolledBack:     2,
+		},
+		{
+			name: "mark pending install as failed",
+			history: []*helm.Release{
+				pendingRelease(1, helm.StatusPendingInstall, time.Hour),
+			},
+			expectedReason: ReasonPendingMarkedFailed,
+			markedFailed:   1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hr := &apiV1.HelmRelease{ObjectMeta: metav1.ObjectMeta{Name: "podinfo", Namespace: "default"}}
+			hrClient := fake.NewSimpleClientset(hr)
+			recorder := record.NewFakeRecorder(1)
+			r := &Release{hrClient: hrClient.HelmV1(), recorder: recorder}
+			client := &recoveryClient{history: tc.history}
+
+			_, err := r.recover(client, hr, tc.history[0])
+			assert.NoError(t, err)
+			assert.Equal(t, tc.rolledBack, client.rolledBack)
+			assert.Equal(t, tc.markedFailed, client.markedFail)
+			// The full history is requested, regardless of the
+			// maximum history of the release.
+			assert.Zero(t, client.historyMax)
+
+			updated, err := hrClient.HelmV1().HelmReleases("default").Get("podinfo", metav1.GetOptions{})
+			assert.NoError(t, err)
+			condition := status.GetCondition(updated.Status, apiV1.HelmReleaseRecovered)
+			if assert.NotNil(t, condition) {
+				assert.Equal(t, apiV1.ConditionTrue, condition.Status)
+				assert.Equal(t, tc.expectedReason, condition.Reason)
+			}
+			assert.Contains(t, <-recorder.Events, tc.expectedReason)
+		})
+	}
+}
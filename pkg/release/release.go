@@ -2,18 +2,21 @@ package release
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"path/filepath"
 	"time"
 
-	"github.com/fluxcd/flux/pkg/git"
 	"github.com/go-kit/kit/log"
 
 	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
 
 	apiV1 "github.com/fluxcd/helm-operator/pkg/apis/helm.fluxcd.io/v1"
 	"github.com/fluxcd/helm-operator/pkg/chartsync"
 	v1client "github.com/fluxcd/helm-operator/pkg/client/clientset/versioned/typed/helm.fluxcd.io/v1"
+	iflister "github.com/fluxcd/helm-operator/pkg/client/listers/helm.fluxcd.io/v1"
+	"github.com/fluxcd/helm-operator/pkg/git"
 	"github.com/fluxcd/helm-operator/pkg/helm"
 	helmV3 "github.com/fluxcd/helm-operator/pkg/helm/v3"
 	"github.com/fluxcd/helm-operator/pkg/status"
@@ -22,9 +25,14 @@ import (
 // Config holds the configuration for releases.
 type Config struct {
 	ChartCache         string
+	ChartCacheMaxSize  int64
 	UpdateDeps         bool
 	LogDiffs           bool
 	DefaultHelmVersion string
+	Limits             Limits
+	// RecoverPendingAfter is the age after which a release stuck in
+	// a pending state is recovered, zero disables the recovery.
+	RecoverPendingAfter time.Duration
 }
 
 // WithDefaults sets the default values for the release config.
@@ -42,28 +50,46 @@ type Release struct {
 	helmClients  *helm.Clients
 	coreV1Client corev1client.CoreV1Interface
 	hrClient     v1client.HelmV1Interface
+	repoLister   iflister.HelmRepositoryLister
 	gitChartSync *chartsync.GitChartSync
+	chartCache   *chartsync.ChartCache
 	config       Config
 	converter    helmV3.Converter
+	limiter      *limiter
+	recorder     record.EventRecorder
 }
 
 // New returns a new instance of Release
 func New(logger log.Logger, helmClients *helm.Clients, coreV1Client corev1client.CoreV1Interface, hrClient v1client.HelmV1Interface,
-	gitChartSync *chartsync.GitChartSync, config Config, converter helmV3.Converter) *Release {
+	repoLister iflister.HelmRepositoryLister, gitChartSync *chartsync.GitChartSync, config Config, converter helmV3.Converter,
+	recorder record.EventRecorder) *Release {
+	config = config.WithDefaults()
 	r := &Release{
 		logger:       logger,
 		helmClients:  helmClients,
 		coreV1Client: coreV1Client,
 		hrClient:     hrClient,
+		repoLister:   repoLister,
 		gitChartSync: gitChartSync,
-		config:       config.WithDefaults(),
+		chartCache:   chartsync.NewChartCache(config.ChartCache, config.ChartCacheMaxSize),
+		config:       config,
 		converter:    converter,
+		limiter:      newLimiter(config.Limits),
+		recorder:     recorder,
 	}
 	return r
 }
 
-// Sync synchronizes the given HelmRelease with Helm.
-func (r *Release) Sync(hr *apiV1.HelmRelease) (err error) {
+// SyncOptions holds the options available for a release sync.
+type SyncOptions struct {
+	// ForceUpgrade will result in an upgrade, even if the dry-run
+	// upgrade does not detect any changes.
+	ForceUpgrade bool
+}
+
+// Sync synchronizes the given HelmRelease with Helm. Installs and
+// upgrades are cancelled when the given context is done.
+func (r *Release) Sync(ctx context.Context, hr *apiV1.HelmRelease, opts SyncOptions) (err error) {
 	client, ok := r.helmClients.Load(hr.GetHelmVersion(r.config.DefaultHelmVersion))
 	if !ok {
 		status.SetStatusPhase(r.hrClient.HelmReleases(hr.GetTargetNamespace()), hr, apiV1.HelmReleasePhaseFailed)
@@ -80,7 +106,13 @@ func (r *Release) Sync(hr *apiV1.HelmRelease) (err error) {
 
 	chart, cleanup, err := r.prepareChart(client, hr)
 	if err != nil {
-		status.SetStatusPhase(r.hrClient.HelmReleases(hr.Namespace), hr, apiV1.HelmReleasePhaseChartFetchFailed)
+		phase := apiV1.HelmReleasePhaseChartFetchFailed
+		var cuErr chartsync.ChartUnavailableError
+		if errors.As(err, &chartsync.CommitVerificationError{}) ||
+			(errors.As(err, &cuErr) && cuErr.Reason == chartsync.ReasonVerificationFailed) {
+			phase = apiV1.HelmReleasePhaseChartVerificationFailed
+		}
+		status.SetStatusPhase(r.hrClient.HelmReleases(hr.Namespace), hr, phase)
 		err = fmt.Errorf("failed to prepare chart for release: %w", err)
 		logger.Log("error", err)
 		return
@@ -91,6 +123,14 @@ func (r *Release) Sync(hr *apiV1.HelmRelease) (err error) {
 	if chart.changed {
 		status.SetStatusPhase(r.hrClient.HelmReleases(hr.Namespace), hr, apiV1.HelmReleasePhaseChartFetched)
 	}
+	if chart.gitSource != nil {
+		status.SetGitSource(r.hrClient.HelmReleases(hr.Namespace), hr, chart.gitSource)
+	}
+	if hr.Spec.ChartSource.Verify != nil {
+		if c := status.GetCondition(hr.Status, apiV1.HelmReleaseChartVerified); chart.changed || c == nil || c.Status != apiV1.ConditionTrue {
+			status.SetStatusPhase(r.hrClient.HelmReleases(hr.Namespace), hr, apiV1.HelmReleasePhaseChartVerified)
+		}
+	}
 
 	var values []byte
 	values, err = composeValues(r.coreV1Client, hr, chart.chartPath)
@@ -102,14 +142,14 @@ func (r *Release) Sync(hr *apiV1.HelmRelease) (err error) {
 	}
 	var action action
 	var curRel *helm.Release
-	action, curRel, err = r.determineSyncAction(client, hr, chart)
+	action, curRel, err = r.determineSyncAction(client, hr, chart, opts)
 	if err != nil {
 		status.SetStatusPhase(r.hrClient.HelmReleases(hr.GetTargetNamespace()), hr, apiV1.HelmReleasePhaseFailed)
 		err = fmt.Errorf("failed to determine sync action for release: %w", err)
 		logger.Log("error", err)
 		return
 	}
-	return r.run(logger, client, action, hr, curRel, chart, values)
+	return r.run(ctx, logger, client, action, hr, curRel, chart, values)
 }
 
 // Uninstalls removes the Helm release for the given HelmRelease,
@@ -120,7 +160,7 @@ func (r *Release) Uninstall(hr *apiV1.HelmRelease) error {
 		return fmt.Errorf(`no client found for Helm '%s'`, r.config.DefaultHelmVersion)
 	}
 	logger := releaseLogger(r.logger, client, hr)
-	return r.run(logger, client, UninstallAction, hr, nil, chart{}, nil)
+	return r.run(context.Background(), logger, client, UninstallAction, hr, nil, chart{}, nil)
 }
 
 // chart is a reference to a Helm chart used internally during the release.
@@ -128,6 +168,8 @@ type chart struct {
 	chartPath string
 	revision  string
 	changed   bool
+	// gitSource holds the resolved reference for Git chart sources.
+	gitSource *apiV1.GitSourceStatus
 }
 
 // prepareChart returns the chart for the configured chart source in
@@ -138,39 +180,92 @@ func (r *Release) prepareChart(client helm.Client, hr *apiV1.HelmRelease) (chart
 	switch {
 	case hr.Spec.GitChartSource != nil && hr.Spec.GitURL != "" && hr.Spec.Path != "":
 		var export *git.Export
+		var tag string
 		var err error
 
-		export, revision, err = r.gitChartSync.GetMirrorCopy(hr)
+		export, revision, tag, err = r.gitChartSync.GetMirrorCopy(hr)
 		if err != nil {
 			return chart{}, nil, err
 		}
 		chartPath = filepath.Join(export.Dir(), hr.Spec.GitChartSource.Path)
-		changed = func() bool {
-			i, _ := export.ChangedFiles(context.Background(), hr.Status.LastAttemptedRevision, []string{hr.Spec.GitChartSource.Path})
-			return 0 < len(i)
-		}()
+		changed, err = gitChartChanged(export, hr.Status.LastAttemptedRevision, revision, hr.Spec.GitChartSource.Path)
+		if err != nil {
+			export.Clean()
+			return chart{}, nil, fmt.Errorf("failed to determine changes to chart since %s: %w", hr.Status.LastAttemptedRevision, err)
+		}
 		if r.config.UpdateDeps && !hr.Spec.GitChartSource.SkipDepUpdate {
-			if err := client.DependencyUpdate(chartPath); err != nil {
+			start := time.Now()
+			err := client.DependencyUpdate(chartPath)
+			ObserveDependencyUpdate(start, err == nil)
+			if err != nil {
 				return chart{}, nil, err
 			}
 		}
-		return chart{chartPath, revision, changed}, export.Clean, nil
+		gitSource := &apiV1.GitSourceStatus{Tag: tag, Commit: revision}
+		return chart{chartPath: chartPath, revision: revision, changed: changed, gitSource: gitSource}, export.Clean, nil
 	case hr.Spec.RepoChartSource != nil && hr.Spec.RepoURL != "" && hr.Spec.Name != "" && hr.Spec.Version != "":
 		var err error
 
-		chartPath, _, err = chartsync.EnsureChartFetched(client, r.config.ChartCache, hr.Spec.RepoChartSource)
+		chartPath, _, err = chartsync.EnsureChartFetched(client, r.coreV1Client, r.repoLister, r.chartCache, hr.Namespace, hr.Spec.RepoChartSource, hr.Spec.ChartSource.Verify)
 		if err != nil {
 			return chart{}, nil, err
 		}
+		// The chart is pinned in the cache until the release is done
+		// with it, so that it is not evicted while in use.
+		release := func() error {
+			r.chartCache.Release(chartPath)
+			return nil
+		}
 		revision, err = client.GetChartRevision(chartPath)
 		if err != nil {
+			release()
 			return chart{}, nil, err
 		}
 		changed = hr.Status.LastAttemptedRevision != revision
+		return chart{chartPath: chartPath, revision: revision, changed: changed}, release, nil
+	case hr.Spec.ObjectChartSource != nil && hr.Spec.ObjectChartSource.Name != "":
+		if hr.Spec.ChartSource.Verify != nil {
+			return chart{}, nil, chartsync.ChartUnavailableError{
+				Err:    fmt.Errorf("verification is not supported for charts sourced from a %s", hr.Spec.ObjectChartSource.Kind),
+				Reason: chartsync.ReasonVerificationFailed,
+			}
+		}
+
+		var clean func() error
+		var err error
+
+		chartPath, revision, clean, err = chartsync.ExportObjectChart(r.coreV1Client, hr.Namespace, hr.Spec.ObjectChartSource)
+		if err != nil {
+			return chart{}, nil, err
+		}
+		changed = hr.Status.LastAttemptedRevision != revision
+		return chart{chartPath: chartPath, revision: revision, changed: changed}, clean, nil
 	default:
 		return chart{}, nil, fmt.Errorf("could not find valid chart source configuration for release")
 	}
-	return chart{chartPath, revision, changed}, nil, nil
+}
+
+// gitChartChanged returns true if anything under the chart path in
+// the given export changed since the given revision. If the revision
+// is not available in the export, e.g. because the mirror is shallow,
+// only the revisions are compared.
+func gitChartChanged(export *git.Export, since, revision, path string) (bool, error) {
+	if since == "" {
+		return true, nil
+	}
+	ctx := context.Background()
+	ok, err := export.HasCommit(ctx, since)
+	if err != nil {
+		return false, err
+	}
+	if !ok {
+		return since != revision, nil
+	}
+	files, err := export.ChangedFiles(ctx, since, []string{path})
+	if err != nil {
+		return false, err
+	}
+	return 0 < len(files), nil
 }
 
 type action string
@@ -185,6 +280,7 @@ const (
 	DryRunCompareAction action = "dry-run-compare"
 	AnnotateAction      action = "annotate"
 	TestAction          action = "test"
+	RecoverAction       action = "recover"
 )
 
 const (
@@ -197,8 +293,8 @@ const (
 // this revision of the resource); before running the dry-run release to
 // determine if any undefined mutations have occurred. It returns a
 // booleans indicating if the release should be synced, or an error.
-func (r *Release) determineSyncAction(client helm.Client, hr *apiV1.HelmRelease, chart chart) (action, *helm.Release, error) {
-	curRel, err := client.Get(hr.GetReleaseName(), helm.GetOptions{Namespace: hr.GetTargetNamespace()})
+func (r *Release) determineSyncAction(client helm.Client, hr *apiV1.HelmRelease, chart chart, opts SyncOptions) (action, *helm.Release, error) {
+	curRel, err := client.Get(hr.GetReleaseName(), helm.GetOptions{Namespace: hr.GetTargetNamespace(), StorageDriver: hr.Spec.StorageDriver})
 	if err != nil {
 		return SkipAction, nil, fmt.Errorf("failed to retrieve Helm release: %w", err)
 	}
@@ -244,7 +340,11 @@ func (r *Release) determineSyncAction(client helm.Client, hr *apiV1.HelmRelease,
 	}
 
 	// If the current state of the release does not allow us to safely
-	// upgrade, we skip.
+	// upgrade, we skip, unless it is stuck in a pending state that we
+	// should recover from.
+	if r.shouldRecover(hr, curRel) {
+		return RecoverAction, curRel, nil
+	}
 	if s := curRel.Info.Status; !s.AllowsUpgrade() {
 		return SkipAction, nil, fmt.Errorf("status '%s' of release does not allow a safe upgrade", s.String())
 	}
@@ -255,12 +355,18 @@ func (r *Release) determineSyncAction(client helm.Client, hr *apiV1.HelmRelease,
 		return UpgradeAction, curRel, nil
 	}
 
+	// If an upgrade was explicitly requested, there is no need to
+	// determine if anything has changed.
+	if opts.ForceUpgrade {
+		return UpgradeAction, curRel, nil
+	}
+
 	// The release has been rolled back, inspect state.
 	if status.HasRolledBack(hr) {
 		if chart.changed || status.ShouldRetryUpgrade(hr) {
 			return UpgradeAction, curRel, nil
 		}
-		hist, err := client.History(hr.GetReleaseName(), helm.HistoryOptions{Namespace: hr.GetTargetNamespace(), Max: hr.GetMaxHistory()})
+		hist, err := client.History(hr.GetReleaseName(), helm.HistoryOptions{Namespace: hr.GetTargetNamespace(), Max: hr.GetMaxHistory(), StorageDriver: hr.Spec.StorageDriver})
 		if err != nil {
 			return SkipAction, nil, fmt.Errorf("failed to retreive history for rolled back release: %w", err)
 		}
@@ -276,14 +382,75 @@ func (r *Release) determineSyncAction(client helm.Client, hr *apiV1.HelmRelease,
 	return DryRunCompareAction, curRel, nil
 }
 
+// acquireSlot blocks until the concurrency limits allow the given
+// HelmRelease to be installed or upgraded, and returns the function
+// to release the acquired slot. It returns ErrLimitReached if no slot
+// became available within the limitWaitTimeout, or the error of the
+// given context if it is done before.
+func (r *Release) acquireSlot(ctx context.Context, logger log.Logger, hr *apiV1.HelmRelease, action action) (func(), error) {
+	waitCtx, cancel := context.WithTimeout(ctx, limitWaitTimeout)
+	defer cancel()
+	release, err := r.limiter.acquire(waitCtx, hr.GetTargetNamespace(), chartName(hr), func() {
+		logger.Log("info", "waiting for concurrency limit", "phase", action)
+	})
+	if err != nil && ctx.Err() == nil {
+		return nil, ErrLimitReached
+	}
+	return release, err
+}
+
+// beforeAction prepares the given action of the release cycle of the
+// given HelmRelease. Installs, upgrades, migrations and recoveries
+// acquire a slot within the concurrency limits, which is stored in
+// the given slot and held for the rest of the cycle, and are not
+// started once the given context is done. It returns ErrLimitReached
+// if no slot became available, or an error if the action must not be
+// started.
+func (r *Release) beforeAction(ctx context.Context, logger log.Logger, hr *apiV1.HelmRelease, action action, slot *func()) error {
+	switch action {
+	case InstallAction, MigrateAction, UpgradeAction, RecoverAction:
+	default:
+		return nil
+	}
+	if *slot == nil {
+		release, err := r.acquireSlot(ctx, logger, hr, action)
+		if errors.Is(err, ErrLimitReached) {
+			logger.Log("info", "concurrency limit reached, requeueing release", "phase", action)
+			return err
+		}
+		*slot = release
+	}
+	if err := ctx.Err(); err != nil {
+		logger.Log("info", "operator is shutting down, skipping release", "phase", action)
+		return fmt.Errorf("%s cancelled: %w", action, err)
+	}
+	return nil
+}
+
 // run starts on the given action and loops through the release cycle.
-func (r *Release) run(logger log.Logger, client helm.Client, action action, hr *apiV1.HelmRelease, curRel *helm.Release,
+func (r *Release) run(ctx context.Context, logger log.Logger, client helm.Client, action action, hr *apiV1.HelmRelease, curRel *helm.Release,
 	chart chart, values []byte) error {
 
 	var newRel *helm.Release
 	errs := errCollection{}
+
+	// Installs and upgrades (and the rollbacks or uninstalls that
+	// follow on failure) are subject to the concurrency limits, the
+	// slot is held until the release cycle has finished.
+	var releaseSlot func()
+	defer func() {
+		if releaseSlot != nil {
+			releaseSlot()
+		}
+	}()
 next:
 	var err error
+	if err = r.beforeAction(ctx, logger, hr, action, &releaseSlot); err != nil {
+		if errors.Is(err, ErrLimitReached) {
+			return err
+		}
+		return append(errs, err)
+	}
 	switch action {
 	case DryRunCompareAction:
 		logger.Log("info", fmt.Sprintf("running dry-run upgrade to compare with release version '%d'", curRel.Version), "action", action)
@@ -311,10 +478,15 @@ next:
 		logger.Log("info", "no changes", "phase", action)
 	case InstallAction:
 		logger.Log("info", "running installation", "phase", action)
-		newRel, err = r.install(client, hr, chart, values)
+		newRel, err = r.install(ctx, client, hr, chart, values)
 		if err != nil {
 			logger.Log("error", err, "phase", action)
 			errs = append(errs, err)
+			if ctx.Err() != nil {
+				// The release has been marked as failed, and is
+				// retried once the operator is back.
+				break
+			}
 
 			action = UninstallAction
 			goto next
@@ -351,11 +523,16 @@ next:
 		goto next
 	case UpgradeAction:
 		logger.Log("info", "running upgrade", "action", action)
-		newRel, err = r.upgrade(client, hr, chart, values)
+		newRel, err = r.upgrade(ctx, client, hr, chart, values)
 
 		if err != nil {
 			logger.Log("error", err, "action", action)
 			errs = append(errs, err)
+			if ctx.Err() != nil {
+				// The release has been marked as failed, and is
+				// retried once the operator is back.
+				break
+			}
 
 			action = RollbackAction
 			goto next
@@ -398,7 +575,7 @@ next:
 		}
 	case RollbackAction:
 		if hr.Spec.Rollback.Enable {
-			latestRel, err := client.Get(hr.GetReleaseName(), helm.GetOptions{Namespace: hr.GetTargetNamespace(), Version: 0})
+			latestRel, err := client.Get(hr.GetReleaseName(), helm.GetOptions{Namespace: hr.GetTargetNamespace(), Version: 0, StorageDriver: hr.Spec.StorageDriver})
 			if err != nil {
 				err = fmt.Errorf("unable to determine if rollback should be performed: %w", err)
 				logger.Log("error", err, "phase", action)
@@ -418,6 +595,16 @@ next:
 				goto next
 			}
 		}
+	case RecoverAction:
+		logger.Log("info", fmt.Sprintf("recovering release stuck in %s", curRel.Info.Status), "phase", action)
+		if _, err = r.recover(client, hr, curRel); err != nil {
+			logger.Log("error", err, "phase", action)
+			errs = append(errs, err)
+			break
+		}
+		// The release is upgraded during the next sync, if the
+		// recovered state allows it.
+		logger.Log("info", "recovery succeeded", "phase", action)
 	case UninstallAction:
 		logger.Log("info", "running uninstall", "phase", action)
 		if err := uninstall(client, hr); err != nil {
@@ -443,11 +630,12 @@ func (r *Release) dryRunCompare(client helm.Client, rel *helm.Release, hr *apiV1
 		ObserveReleaseAction(start, DryRunCompareAction, err == nil, hr.GetTargetNamespace(), hr.GetReleaseName())
 	}(time.Now())
 	dryRel, err = client.UpgradeFromPath(chart.chartPath, hr.GetReleaseName(), values, helm.UpgradeOptions{
-		DryRun:      true,
-		Namespace:   hr.GetTargetNamespace(),
-		Force:       hr.Spec.ForceUpgrade,
-		ReuseValues: hr.GetReuseValues(),
-		ResetValues: !hr.GetReuseValues(),
+		DryRun:        true,
+		Namespace:     hr.GetTargetNamespace(),
+		Force:         hr.Spec.ForceUpgrade,
+		ReuseValues:   hr.GetReuseValues(),
+		ResetValues:   !hr.GetReuseValues(),
+		StorageDriver: hr.Spec.StorageDriver,
 	})
 	if err != nil {
 		err = fmt.Errorf("dry-run upgrade for comparison failed: %w", err)
@@ -460,7 +648,7 @@ func (r *Release) dryRunCompare(client helm.Client, rel *helm.Release, hr *apiV1
 // install performs an installation with the given HelmRelease,
 // chart, and values while recording the phases on the HelmRelease.
 // It returns the release result or an error.
-func (r *Release) install(client helm.Client, hr *apiV1.HelmRelease, chart chart, values []byte) (rel *helm.Release, err error) {
+func (r *Release) install(ctx context.Context, client helm.Client, hr *apiV1.HelmRelease, chart chart, values []byte) (rel *helm.Release, err error) {
 	defer func(start time.Time) {
 		ObserveReleaseAction(start, InstallAction, err == nil, hr.GetTargetNamespace(), hr.GetReleaseName())
 	}(time.Now())
@@ -475,6 +663,8 @@ func (r *Release) install(client helm.Client, hr *apiV1.HelmRelease, chart chart
 		MaxHistory:        hr.GetMaxHistory(),
 		Wait:              hr.GetWait(),
 		DisableValidation: hr.Spec.DisableOpenAPIValidation,
+		StorageDriver:     hr.Spec.StorageDriver,
+		Context:           ctx,
 	})
 	if err != nil {
 		status.SetStatusPhase(r.hrClient.HelmReleases(hr.Namespace), hr, apiV1.HelmReleasePhaseDeployFailed)
@@ -507,7 +697,7 @@ func (r *Release) migrate(client helm.Client, hr *apiV1.HelmRelease, chart chart
 // upgrade performs an upgrade with the given HelmRelease,
 // chart and values while recording the phases and revision on
 // the HelmRelease. It returns the release result or an error.
-func (r *Release) upgrade(client helm.Client, hr *apiV1.HelmRelease, chart chart, values []byte) (rel *helm.Release, err error) {
+func (r *Release) upgrade(ctx context.Context, client helm.Client, hr *apiV1.HelmRelease, chart chart, values []byte) (rel *helm.Release, err error) {
 	defer func(start time.Time) {
 		ObserveReleaseAction(start, UpgradeAction, err == nil, hr.GetTargetNamespace(), hr.GetReleaseName())
 	}(time.Now())
@@ -523,6 +713,8 @@ func (r *Release) upgrade(client helm.Client, hr *apiV1.HelmRelease, chart chart
 		MaxHistory:        hr.GetMaxHistory(),
 		Wait:              hr.GetWait(),
 		DisableValidation: hr.Spec.DisableOpenAPIValidation,
+		StorageDriver:     hr.Spec.StorageDriver,
+		Context:           ctx,
 	})
 	if err != nil {
 		status.SetStatusPhase(r.hrClient.HelmReleases(hr.Namespace), hr, apiV1.HelmReleasePhaseDeployFailed)
@@ -543,12 +735,13 @@ func (r *Release) rollback(client helm.Client, hr *apiV1.HelmRelease, revision s
 
 	status.SetStatusPhase(r.hrClient.HelmReleases(hr.Namespace), hr, apiV1.HelmReleasePhaseRollingBack)
 	rel, err = client.Rollback(hr.GetReleaseName(), helm.RollbackOptions{
-		Namespace:    hr.GetTargetNamespace(),
-		Timeout:      hr.Spec.Rollback.GetTimeout(),
-		Wait:         hr.Spec.Rollback.Wait,
-		DisableHooks: hr.Spec.Rollback.DisableHooks,
-		Recreate:     hr.Spec.Rollback.Recreate,
-		Force:        hr.Spec.Rollback.Force,
+		Namespace:     hr.GetTargetNamespace(),
+		Timeout:       hr.Spec.Rollback.GetTimeout(),
+		Wait:          hr.Spec.Rollback.Wait,
+		DisableHooks:  hr.Spec.Rollback.DisableHooks,
+		Recreate:      hr.Spec.Rollback.Recreate,
+		Force:         hr.Spec.Rollback.Force,
+		StorageDriver: hr.Spec.StorageDriver,
 	})
 	if err != nil {
 		status.SetStatusPhase(r.hrClient.HelmReleases(hr.Namespace), hr, apiV1.HelmReleasePhaseRollbackFailed)
@@ -568,9 +761,10 @@ func (r *Release) test(client helm.Client, hr *apiV1.HelmRelease) (err error) {
 	}(time.Now())
 	status.SetStatusPhase(r.hrClient.HelmReleases(hr.Namespace), hr, apiV1.HelmReleasePhaseTesting)
 	err = client.Test(hr.GetReleaseName(), helm.TestOptions{
-		Namespace: hr.GetTargetNamespace(),
-		Timeout:   hr.Spec.Test.GetTimeout(),
-		Cleanup:   hr.Spec.Test.GetCleanup(),
+		Namespace:     hr.GetTargetNamespace(),
+		Timeout:       hr.Spec.Test.GetTimeout(),
+		Cleanup:       hr.Spec.Test.GetCleanup(),
+		StorageDriver: hr.Spec.StorageDriver,
 	})
 	if err != nil {
 		status.SetStatusPhase(r.hrClient.HelmReleases(hr.Namespace), hr, apiV1.HelmReleasePhaseTestFailed)
@@ -599,9 +793,10 @@ func uninstall(client helm.Client, hr *apiV1.HelmRelease) (err error) {
 		ObserveReleaseAction(start, UninstallAction, err == nil, hr.GetTargetNamespace(), hr.GetReleaseName())
 	}(time.Now())
 	err = client.Uninstall(hr.GetReleaseName(), helm.UninstallOptions{
-		Namespace:   hr.GetTargetNamespace(),
-		KeepHistory: false,
-		Timeout:     hr.GetTimeout(),
+		Namespace:     hr.GetTargetNamespace(),
+		KeepHistory:   false,
+		Timeout:       hr.GetTimeout(),
+		StorageDriver: hr.Spec.StorageDriver,
 	})
 	if err != nil {
 		err = fmt.Errorf("uninstall failed: %w", err)
@@ -0,0 +1,262 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	helmfluxv1 "github.com/fluxcd/helm-operator/pkg/apis/helm.fluxcd.io/v1"
+	ifclient "github.com/fluxcd/helm-operator/pkg/client/clientset/versioned/typed/helm.fluxcd.io/v1"
+	hrv1 "github.com/fluxcd/helm-operator/pkg/client/informers/externalversions/helm.fluxcd.io/v1"
+	iflister "github.com/fluxcd/helm-operator/pkg/client/listers/helm.fluxcd.io/v1"
+	"github.com/fluxcd/helm-operator/pkg/helm"
+)
+
+const (
+	ReasonIndexFetched           = "IndexFetched"
+	ReasonIndexFetchFailed       = "IndexFetchFailed"
+	ReasonCredentialsUnavailable = "CredentialsUnavailable"
+)
+
+// Controller fetches the index of the HelmRepository resources and
+// records the result in their status, refetching it on every resync
+// of the informer. The repositories are not added to the (shared)
+// repository configuration of the Helm clients, as their credentials
+// must only be used by the HelmReleases in the same namespace.
+type Controller struct {
+	logger log.Logger
+
+	helmClients  *helm.Clients
+	coreV1Client corev1client.CoreV1Interface
+	ifClient     ifclient.HelmV1Interface
+
+	repoLister iflister.HelmRepositoryLister
+	repoSynced cache.InformerSynced
+
+	// certsDir is the directory the certificates and keys from the
+	// secrets of the repositories are written to, as Helm expects
+	// them to be files.
+	certsDir string
+
+	workqueue workqueue.RateLimitingInterface
+}
+
+// New returns a new HelmRepository controller.
+func New(
+	logger log.Logger,
+	helmClients *helm.Clients,
+	coreV1Client corev1client.CoreV1Interface,
+	ifClient ifclient.HelmV1Interface,
+	repoInformer hrv1.HelmRepositoryInformer,
+	certsDir string) *Controller {
+
+	c := &Controller{
+		logger:       logger,
+		helmClients:  helmClients,
+		coreV1Client: coreV1Client,
+		ifClient:     ifClient,
+		repoLister:   repoInformer.Lister(),
+		repoSynced:   repoInformer.Informer().HasSynced,
+		certsDir:     certsDir,
+		workqueue:    workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "HelmRepository"),
+	}
+
+	repoInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.enqueue,
+		UpdateFunc: func(old, new interface{}) {
+			oldRepo, ok := old.(*helmfluxv1.HelmRepository)
+			if !ok {
+				return
+			}
+			newRepo, ok := new.(*helmfluxv1.HelmRepository)
+			if !ok {
+				return
+			}
+			// Periodic resyncs do not change the resource version and
+			// are used to refresh the index. Other updates without a
+			// generation change are our own status updates, and would
+			// otherwise cause the index to be refetched in a loop.
+			if oldRepo.ResourceVersion != newRepo.ResourceVersion && oldRepo.Generation == newRepo.Generation {
+				return
+			}
+			c.enqueue(new)
+		},
+		DeleteFunc: c.enqueue,
+	})
+
+	return c
+}
+
+// Run starts a worker handling the enqueued HelmRepositories. It will
+// block until stopCh is closed, at which point it will shutdown the
+// workqueue and wait for the worker to finish processing its current
+// work item.
+func (c *Controller) Run(stopCh <-chan struct{}, wg *sync.WaitGroup) {
+	defer runtime.HandleCrash()
+	defer c.workqueue.ShutDown()
+
+	c.logger.Log("info", "starting repository controller")
+
+	wg.Add(1)
+	go wait.Until(c.runWorker, time.Second, stopCh)
+
+	<-stopCh
+	wg.Done()
+	c.logger.Log("info", "stopping repository controller")
+}
+
+func (c *Controller) runWorker() {
+	for c.processNextWorkItem() {
+	}
+}
+
+func (c *Controller) processNextWorkItem() bool {
+	obj, shutdown := c.workqueue.Get()
+	if shutdown {
+		return false
+	}
+	defer c.workqueue.Done(obj)
+
+	key, ok := obj.(string)
+	if !ok {
+		c.workqueue.Forget(obj)
+		runtime.HandleError(fmt.Errorf("expected string in workqueue but got %#v", obj))
+		return true
+	}
+	if err := c.sync(key); err != nil {
+		runtime.HandleError(fmt.Errorf("errored syncing HelmRepository '%s': %s", key, err.Error()))
+		c.workqueue.AddRateLimited(key)
+		return true
+	}
+	c.workqueue.Forget(obj)
+	return true
+}
+
+// sync fetches the index of the HelmRepository with the given key
+// using all Helm clients, or cleans up after it if it no longer
+// exists, and records the result in the status of the resource.
+func (c *Controller) sync(key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		runtime.HandleError(fmt.Errorf("key '%s' is invalid", key))
+		return nil
+	}
+	repoName := repositoryName(namespace, name)
+	certsDir := filepath.Join(c.certsDir, repoName)
+
+	repo, err := c.repoLister.HelmRepositories(namespace).Get(name)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return os.RemoveAll(certsDir)
+		}
+		return err
+	}
+	repo = repo.DeepCopy()
+
+	opts, err := c.getRepositoryOptions(repo, certsDir)
+	if err != nil {
+		err = fmt.Errorf("failed to get credentials from secret: %w", err)
+		if sErr := setReadyCondition(c.ifClient.HelmRepositories(namespace), repo, nil, ReasonCredentialsUnavailable, err); sErr != nil {
+			c.logger.Log("error", "failed to update status", "resource", key, "err", sErr)
+		}
+		return err
+	}
+
+	var index *helm.RepositoryIndex
+	var errs []string
+	c.helmClients.Range(func(version string, client helm.Client) bool {
+		i, err := client.RepositoryFetchIndex(repo.Spec.URL, opts)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %s", version, err.Error()))
+			return true
+		}
+		index = i
+		return true
+	})
+
+	reason := ReasonIndexFetched
+	if len(errs) > 0 {
+		reason, err = ReasonIndexFetchFailed, fmt.Errorf("failed to fetch repository index: %s", strings.Join(errs, "; "))
+	}
+	if sErr := setReadyCondition(c.ifClient.HelmRepositories(namespace), repo, index, reason, err); sErr != nil {
+		c.logger.Log("error", "failed to update status", "resource", key, "err", sErr)
+	}
+	if err != nil {
+		return err
+	}
+	if index != nil {
+		c.logger.Log("info", "repository index fetched", "resource", key, "url", repo.Spec.URL, "charts", index.Charts)
+	}
+	return nil
+}
+
+// getRepositoryOptions returns the repository options with the
+// credentials from the secret of the given HelmRepository, and for
+// object storage repositories the endpoint and region, writing the
+// certificates and keys to the given directory.
+func (c *Controller) getRepositoryOptions(repo *helmfluxv1.HelmRepository, dir string) (helm.RepositoryOptions, error) {
+	var opts helm.RepositoryOptions
+	if repo.Spec.SecretRef == nil {
+		return opts, nil
+	}
+
+	secret, err := c.coreV1Client.Secrets(repo.Namespace).Get(context.Background(), repo.Spec.SecretRef.Name, metav1.GetOptions{})
+	if err != nil {
+		return opts, err
+	}
+	opts.Username = string(secret.Data["username"])
+	opts.Password = string(secret.Data["password"])
+	opts.Endpoint = string(secret.Data["endpoint"])
+	opts.Region = string(secret.Data["region"])
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return opts, err
+	}
+	for key, path := range map[string]*string{
+		"certFile": &opts.CertFile,
+		"keyFile":  &opts.KeyFile,
+		"caFile":   &opts.CAFile,
+	} {
+		data, ok := secret.Data[key]
+		if !ok {
+			continue
+		}
+		f := filepath.Join(dir, key)
+		if err := ioutil.WriteFile(f, data, 0600); err != nil {
+			return opts, err
+		}
+		*path = f
+	}
+	return opts, nil
+}
+
+func (c *Controller) enqueue(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+	c.workqueue.Add(key)
+}
+
+// repositoryName returns a name for the HelmRepository with the given
+// namespace and name, used for the directory its certificates and
+// keys are written to. As namespaces can not contain dots, the name
+// is unique for every HelmRepository.
+func repositoryName(namespace, name string) string {
+	return namespace + "." + name
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+
+	v1 "github.com/fluxcd/helm-operator/pkg/apis/helm.fluxcd.io/v1"
+	v1client "github.com/fluxcd/helm-operator/pkg/client/clientset/versioned/typed/helm.fluxcd.io/v1"
+	"github.com/fluxcd/helm-operator/pkg/helm"
+)
+
+// setReadyCondition records the result of fetching the index of the
+// HelmRepository in its status. The Ready condition is set to true
+// when err is nil, in which case the details of the given index are
+// recorded as well.
+func setReadyCondition(client v1client.HelmRepositoryInterface, repo *v1.HelmRepository, index *helm.RepositoryIndex,
+	reason string, err error) error {
+
+	now := metav1.Now()
+	condition := v1.HelmRepositoryCondition{
+		Type:               v1.HelmRepositoryReady,
+		Status:             v1.ConditionTrue,
+		LastUpdateTime:     &now,
+		LastTransitionTime: &now,
+		Reason:             reason,
+		Message:            "repository index fetched",
+	}
+	if err != nil {
+		condition.Status = v1.ConditionFalse
+		condition.Message = err.Error()
+	}
+
+	firstTry := true
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() (err error) {
+		if !firstTry {
+			var getErr error
+			repo, getErr = client.Get(repo.Name, metav1.GetOptions{})
+			if getErr != nil {
+				return getErr
+			}
+		}
+
+		cRepo := repo.DeepCopy()
+		var conditions []v1.HelmRepositoryCondition
+		for _, c := range cRepo.Status.Conditions {
+			if c.Type != condition.Type {
+				conditions = append(conditions, c)
+				continue
+			}
+			if c.Status == condition.Status {
+				condition.LastTransitionTime = c.LastTransitionTime
+			}
+		}
+		cRepo.Status.Conditions = append(conditions, condition)
+		cRepo.Status.ObservedGeneration = cRepo.Generation
+		if index != nil && condition.Status == v1.ConditionTrue {
+			generated := metav1.NewTime(index.Generated)
+			cRepo.Status.LastIndexFetchTime = &now
+			cRepo.Status.IndexGenerated = &generated
+			cRepo.Status.ChartCount = index.Charts
+		}
+
+		_, err = client.UpdateStatus(cRepo)
+		firstTry = false
+		return
+	})
+}
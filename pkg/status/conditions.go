@@ -85,6 +85,17 @@ func SetStatusPhaseWithRevision(client v1client.HelmReleaseInterface, hr *v1.Hel
 	})
 }
 
+// SetCondition updates the given condition of the HelmRelease, unless
+// the status, reason and message of the current condition of the same
+// type are equal to those of the given condition.
+func SetCondition(client v1client.HelmReleaseInterface, hr *v1.HelmRelease, condition v1.HelmReleaseCondition) error {
+	if curr := GetCondition(hr.Status, condition.Type); curr != nil && curr.Status == condition.Status &&
+		curr.Reason == condition.Reason && curr.Message == condition.Message {
+		return nil
+	}
+	return SetConditions(client, hr, []v1.HelmReleaseCondition{condition})
+}
+
 // ConditionsForPhrase returns conditions for the given phase.
 func ConditionsForPhase(hr *v1.HelmRelease, phase v1.HelmReleasePhase) ([]v1.HelmReleaseCondition, bool) {
 	condition := &v1.HelmReleaseCondition{}
@@ -166,6 +177,20 @@ func ConditionsForPhase(hr *v1.HelmRelease, phase v1.HelmReleasePhase) ([]v1.Hel
 			Status:  v1.ConditionFalse,
 			Message: message,
 		})
+	case v1.HelmReleasePhaseChartVerified:
+		condition.Type = v1.HelmReleaseChartVerified
+		condition.Status = v1.ConditionTrue
+		condition.Message = fmt.Sprintf(`Chart source verification was successful for Helm release '%s' in '%s'.`, hr.GetReleaseName(), hr.GetTargetNamespace())
+	case v1.HelmReleasePhaseChartVerificationFailed:
+		message := fmt.Sprintf(`Chart source verification failed for Helm release '%s' in '%s'.`, hr.GetReleaseName(), hr.GetTargetNamespace())
+		condition.Type = v1.HelmReleaseChartVerified
+		condition.Status = v1.ConditionFalse
+		condition.Message = message
+		conditions = append(conditions, &v1.HelmReleaseCondition{
+			Type:    v1.HelmReleaseReleased,
+			Status:  v1.ConditionFalse,
+			Message: message,
+		})
 	default:
 		return []v1.HelmReleaseCondition{}, false
 	}
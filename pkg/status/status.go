@@ -70,7 +70,7 @@ bail:
 			if !ok {
 				continue
 			}
-			status, _ := c.Status(hr.GetReleaseName(), helm.StatusOptions{Namespace: hr.GetTargetNamespace()})
+			status, _ := c.Status(hr.GetReleaseName(), helm.StatusOptions{Namespace: hr.GetTargetNamespace(), StorageDriver: hr.Spec.StorageDriver})
 			// If we are unable to get the status, we do not care why
 			if status == "" {
 				continue
@@ -143,6 +143,33 @@ func SetReleaseRevision(client v1client.HelmReleaseInterface, hr *v1.HelmRelease
 	return err
 }
 
+// SetGitSource updates the resolved reference of the Git chart source
+// in the status of the HelmRelease.
+func SetGitSource(client v1client.HelmReleaseInterface, hr *v1.HelmRelease, gitSource *v1.GitSourceStatus) error {
+	firstTry := true
+	err := retry.RetryOnConflict(retry.DefaultBackoff, func() (err error) {
+		if !firstTry {
+			var getErr error
+			hr, getErr = client.Get(hr.Name, metav1.GetOptions{})
+			if getErr != nil {
+				return getErr
+			}
+		}
+
+		if hr.Status.GitSource != nil && *hr.Status.GitSource == *gitSource {
+			return
+		}
+
+		cHr := hr.DeepCopy()
+		cHr.Status.GitSource = gitSource.DeepCopy()
+
+		_, err = client.UpdateStatus(cHr)
+		firstTry = false
+		return
+	})
+	return err
+}
+
 // SetObservedGeneration updates the observed generation status of the
 // HelmRelease to the given generation.
 func SetObservedGeneration(client v1client.HelmReleaseInterface, hr *v1.HelmRelease, generation int64) error {
@@ -170,6 +197,35 @@ func SetObservedGeneration(client v1client.HelmReleaseInterface, hr *v1.HelmRele
 	return err
 }
 
+// SetRetry updates the amount of consecutive failures and the time of
+// the next retry in the status of the HelmRelease. A nil retry time
+// clears it, e.g. after a successful reconciliation.
+func SetRetry(client v1client.HelmReleaseInterface, hr *v1.HelmRelease, failures int64, nextRetryTime *metav1.Time) error {
+	firstTry := true
+	err := retry.RetryOnConflict(retry.DefaultBackoff, func() (err error) {
+		if !firstTry {
+			var getErr error
+			hr, getErr = client.Get(hr.Name, metav1.GetOptions{})
+			if getErr != nil {
+				return getErr
+			}
+		}
+
+		if hr.Status.Failures == failures && hr.Status.NextRetryTime.Equal(nextRetryTime) {
+			return
+		}
+
+		cHr := hr.DeepCopy()
+		cHr.Status.Failures = failures
+		cHr.Status.NextRetryTime = nextRetryTime.DeepCopy()
+
+		_, err = client.UpdateStatus(cHr)
+		firstTry = false
+		return
+	})
+	return err
+}
+
 // HasSynced returns if the HelmRelease has been processed by the
 // controller.
 func HasSynced(hr *v1.HelmRelease) bool {